@@ -0,0 +1,129 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package avro
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/scylladb/gocqlx/v2"
+)
+
+// SchemaRegistry resolves Avro schemas.
+type SchemaRegistry interface {
+	// ID returns ID of the schema registered under subject.
+	ID(subject, schema string) (int, error)
+	// Schema returns schema with the given ID.
+	Schema(id int) (string, error)
+}
+
+// Serde encodes and decodes Avro binary data.
+type Serde interface {
+	// Encode encodes v with schema.
+	Encode(schema string, v interface{}) ([]byte, error)
+	// Decode decodes data written with writerSchema into v using
+	// readerSchema.
+	Decode(writerSchema, readerSchema string, data []byte, v interface{}) error
+}
+
+const (
+	magicByte  = 0
+	headerSize = 5
+)
+
+// Codec is a gocqlx.Codec encoding values with a single Avro schema. Values
+// are written with the codec schema and read with any schema known to the
+// registry, the codec schema is used as the reader schema.
+type Codec struct {
+	subject  string
+	schema   string
+	registry SchemaRegistry
+	serde    Serde
+
+	mu      sync.Mutex
+	id      int
+	schemas map[int]string
+}
+
+var _ gocqlx.Codec = &Codec{}
+
+// NewCodec returns a new Codec for the schema registered under subject.
+func NewCodec(subject, schema string, registry SchemaRegistry, serde Serde) *Codec {
+	return &Codec{
+		subject:  subject,
+		schema:   schema,
+		registry: registry,
+		serde:    serde,
+		id:       -1,
+		schemas:  make(map[int]string),
+	}
+}
+
+// Marshal implements gocqlx.Codec.
+func (c *Codec) Marshal(v interface{}) ([]byte, error) {
+	id, err := c.schemaID()
+	if err != nil {
+		return nil, err
+	}
+	payload, err := c.serde.Encode(c.schema, v)
+	if err != nil {
+		return nil, fmt.Errorf("avro encode: %s", err)
+	}
+
+	b := make([]byte, headerSize, headerSize+len(payload))
+	b[0] = magicByte
+	binary.BigEndian.PutUint32(b[1:], uint32(id))
+	return append(b, payload...), nil
+}
+
+// Unmarshal implements gocqlx.Codec.
+func (c *Codec) Unmarshal(data []byte, v interface{}) error {
+	if len(data) < headerSize || data[0] != magicByte {
+		return errors.New("avro: invalid wire format header")
+	}
+	id := int(binary.BigEndian.Uint32(data[1:headerSize]))
+
+	writer, err := c.writerSchema(id)
+	if err != nil {
+		return err
+	}
+	if err := c.serde.Decode(writer, c.schema, data[headerSize:], v); err != nil {
+		return fmt.Errorf("avro decode: %s", err)
+	}
+	return nil
+}
+
+func (c *Codec) schemaID() (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.id >= 0 {
+		return c.id, nil
+	}
+	id, err := c.registry.ID(c.subject, c.schema)
+	if err != nil {
+		return 0, fmt.Errorf("avro: failed to get schema ID for %q: %s", c.subject, err)
+	}
+	c.id = id
+	c.schemas[id] = c.schema
+	return id, nil
+}
+
+func (c *Codec) writerSchema(id int) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if s, ok := c.schemas[id]; ok {
+		return s, nil
+	}
+	s, err := c.registry.Schema(id)
+	if err != nil {
+		return "", fmt.Errorf("avro: failed to get schema %d: %s", id, err)
+	}
+	c.schemas[id] = s
+	return s, nil
+}
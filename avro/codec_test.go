@@ -0,0 +1,93 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package avro
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeRegistry struct {
+	schemas map[int]string
+	calls   int
+}
+
+func (r *fakeRegistry) ID(subject, schema string) (int, error) {
+	r.calls++
+	for id, s := range r.schemas {
+		if s == schema {
+			return id, nil
+		}
+	}
+	return 0, errors.New("not found")
+}
+
+func (r *fakeRegistry) Schema(id int) (string, error) {
+	r.calls++
+	s, ok := r.schemas[id]
+	if !ok {
+		return "", errors.New("not found")
+	}
+	return s, nil
+}
+
+// jsonSerde records schemas used and encodes values as JSON.
+type jsonSerde struct {
+	writer string
+}
+
+func (s *jsonSerde) Encode(schema string, v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (s *jsonSerde) Decode(writerSchema, readerSchema string, data []byte, v interface{}) error {
+	s.writer = writerSchema
+	return json.Unmarshal(data, v)
+}
+
+type user struct {
+	Name string
+}
+
+func TestCodec(t *testing.T) {
+	r := &fakeRegistry{schemas: map[int]string{1: "v1", 258: "v2"}}
+	s := &jsonSerde{}
+	c := NewCodec("user-value", "v2", r, s)
+
+	b, err := c.Marshal(user{Name: "a"})
+	if err != nil {
+		t.Fatal("Marshal() error", err)
+	}
+	if diff := cmp.Diff([]byte("\x00\x00\x00\x01\x02{\"Name\":\"a\"}"), b); diff != "" {
+		t.Fatal(diff)
+	}
+	if _, err := c.Marshal(user{Name: "b"}); err != nil {
+		t.Fatal("Marshal() error", err)
+	}
+	if r.calls != 1 {
+		t.Fatalf("registry calls %d, expected 1", r.calls)
+	}
+
+	var u user
+	if err := c.Unmarshal([]byte("\x00\x00\x00\x00\x01{\"Name\":\"old\"}"), &u); err != nil {
+		t.Fatal("Unmarshal() error", err)
+	}
+	if u.Name != "old" {
+		t.Fatalf("Unmarshal()=%v", u)
+	}
+	if s.writer != "v1" {
+		t.Fatalf("writer schema %q, expected v1", s.writer)
+	}
+
+	if err := c.Unmarshal([]byte("{}"), &u); err == nil {
+		t.Fatal("Unmarshal() expected error")
+	}
+	if err := c.Unmarshal([]byte("\x00\x00\x00\x00\x07{}"), &u); err == nil {
+		t.Fatal("Unmarshal() expected error")
+	}
+}
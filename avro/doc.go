@@ -0,0 +1,16 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+// Package avro provides a gocqlx.Codec storing Avro encoded values in blob
+// columns in the schema registry wire format, a zero byte followed by a big
+// endian 4 byte schema ID and the Avro binary payload. The format is
+// understood by Kafka consumers using a schema registry.
+//
+// The package does not depend on any Avro or schema registry library, Avro
+// encoding is provided by a Serde and schema lookups by a SchemaRegistry.
+// Register the codec for the stored type:
+//
+//	c := avro.NewCodec("user-value", userSchema, registry, serde)
+//	gocqlx.RegisterCodec(reflect.TypeOf(User{}), c)
+package avro
@@ -0,0 +1,111 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/scylladb/go-reflectx"
+)
+
+// Batchx is a wrapper around gocql.Batch which adds struct binding
+// capabilities, every statement is bound from its own struct or map.
+type Batchx struct {
+	*gocql.Batch
+	Mapper *reflectx.Mapper
+
+	session  *gocql.Session
+	location *time.Location
+	err      error
+}
+
+// NewBatch creates a new Batchx using the session mapper.
+func (s Session) NewBatch(typ gocql.BatchType) *Batchx {
+	return &Batchx{
+		Batch:    s.Session.NewBatch(typ),
+		Mapper:   s.Mapper,
+		session:  s.Session,
+		location: s.Location,
+	}
+}
+
+// WithContext returns the batch with ctx set, unlike gocql.Batch.WithContext
+// it modifies the batch.
+func (b *Batchx) WithContext(ctx context.Context) *Batchx {
+	b.Batch = b.Batch.WithContext(ctx)
+	return b
+}
+
+// Query adds a statement with positional values to the batch.
+func (b *Batchx) Query(stmt string, args ...interface{}) *Batchx {
+	b.Batch.Query(stmt, locationWrapSlice(udtWrapSlice(b.Mapper, DefaultUnsafe, args), b.location)...)
+	return b
+}
+
+// BindStruct adds a statement to the batch, named parameters are bound to
+// values from arg using mapper. The stmt and names parameters are typically
+// result of a query builder ToCql() function. If value cannot be found
+// the statement is not added and error is reported by Exec.
+func (b *Batchx) BindStruct(stmt string, names []string, arg interface{}) *Batchx {
+	arglist, err := bindStructArgs(names, arg, nil, b.Mapper)
+	return b.bind(stmt, arglist, err)
+}
+
+// BindStructMap adds a statement to the batch, named parameters are bound to
+// values from arg0 and arg1 using a mapper. If value cannot be found in arg0
+// it's looked up in arg1 before reporting an error.
+func (b *Batchx) BindStructMap(stmt string, names []string, arg0 interface{}, arg1 map[string]interface{}) *Batchx {
+	arglist, err := bindStructArgs(names, arg0, arg1, b.Mapper)
+	return b.bind(stmt, arglist, err)
+}
+
+// BindMap adds a statement to the batch, named parameters are bound to
+// values from arg.
+func (b *Batchx) BindMap(stmt string, names []string, arg map[string]interface{}) *Batchx {
+	arglist, err := bindMapArgs(names, arg)
+	return b.bind(stmt, arglist, err)
+}
+
+func (b *Batchx) bind(stmt string, arglist []interface{}, err error) *Batchx {
+	if err != nil {
+		if b.err == nil {
+			b.err = fmt.Errorf("bind error: statement %d %q: %s", b.Size(), stmt, err)
+		}
+		return b
+	}
+	return b.Query(stmt, arglist...)
+}
+
+// Err returns the first binding error.
+func (b *Batchx) Err() error {
+	return b.err
+}
+
+// Exec executes the batch. If any statement failed to bind the batch is not
+// executed and the binding error is returned.
+func (b *Batchx) Exec() error {
+	if b.err != nil {
+		return b.err
+	}
+	return b.session.ExecuteBatch(b.Batch)
+}
+
+// ExecCAS executes the batch with Lightweight Transaction statements,
+// returns whether the batch was applied. If the batch was not applied the
+// existing rows returned by the server are discarded.
+// See: https://docs.scylladb.com/using-scylla/lwt/ for more details.
+func (b *Batchx) ExecCAS() (applied bool, err error) {
+	if b.err != nil {
+		return false, b.err
+	}
+	applied, iter, err := b.session.MapExecuteBatchCAS(b.Batch, make(map[string]interface{}))
+	if err != nil {
+		return false, err
+	}
+	return applied, iter.Close()
+}
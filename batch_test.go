@@ -0,0 +1,74 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBatchxBind(t *testing.T) {
+	v := &struct {
+		ID   int
+		Name string
+	}{
+		ID:   1,
+		Name: "name",
+	}
+
+	b := &Batchx{
+		Batch:  &gocql.Batch{},
+		Mapper: DefaultMapper,
+	}
+	b.BindStruct("INSERT INTO t (id,name) VALUES (?,?)", []string{"id", "name"}, v).
+		BindMap("UPDATE t SET name=? WHERE id=?", []string{"name", "id"}, map[string]interface{}{"id": 2, "name": "other"}).
+		BindStructMap("DELETE FROM t WHERE id=? AND name=?", []string{"id", "other"}, v, map[string]interface{}{"other": "x"}).
+		Query("DELETE FROM t WHERE id=?", 3)
+
+	if err := b.Err(); err != nil {
+		t.Fatal("Err() error:", err)
+	}
+
+	var args [][]interface{}
+	for _, e := range b.Entries {
+		args = append(args, e.Args)
+	}
+	expected := [][]interface{}{
+		{1, "name"},
+		{"other", 2},
+		{1, "x"},
+		{3},
+	}
+	if diff := cmp.Diff(expected, args); diff != "" {
+		t.Fatal(diff)
+	}
+
+	t.Run("error", func(t *testing.T) {
+		b := &Batchx{
+			Batch:  &gocql.Batch{},
+			Mapper: DefaultMapper,
+		}
+		b.BindStruct("INSERT INTO t (id) VALUES (?)", []string{"id"}, v).
+			BindStruct("INSERT INTO t (id,age) VALUES (?,?)", []string{"id", "age"}, v).
+			BindMap("INSERT INTO t (id) VALUES (?)", []string{"id"}, nil)
+
+		const msg = `bind error: statement 1 "INSERT INTO t (id,age) VALUES (?,?)": could not find name "age" in`
+		if err := b.Err(); err == nil || !strings.HasPrefix(err.Error(), msg) {
+			t.Fatalf("Err()=%v, expected %s...", err, msg)
+		}
+		if b.Size() != 1 {
+			t.Fatalf("Size()=%d, expected 1", b.Size())
+		}
+		if err := b.Exec(); err != b.Err() {
+			t.Fatalf("Exec() error %v, expected %v", err, b.Err())
+		}
+		if _, err := b.ExecCAS(); err != b.Err() {
+			t.Fatalf("ExecCAS() error %v, expected %v", err, b.Err())
+		}
+	})
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+// Command schemagen generates Go structs, table metadata and typed query
+// builders from the schema of a keyspace, see package schemagen for details.
+//
+// Usage:
+//
+//	schemagen -cluster 127.0.0.1 -keyspace examples -pkgname models -output models
+//
+// If -keyspace lists several keyspaces every keyspace is written to its own
+// package in the output folder named after the keyspace, -include and
+// -exclude can be repeated to filter tables with regular expressions:
+//
+//	schemagen -keyspace users,billing -exclude '_tmp$' -output models
+//
+// Go types of single columns can be overridden with -column, it can be
+// repeated. Use it for blob columns storing values encoded with a codec
+// registered with gocqlx.RegisterCodec, i.e. protobuf messages:
+//
+//	schemagen -keyspace events -column 'events.payload=*github.com/acme/pb.Event'
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/scylladb/gocqlx/v2/schemagen"
+)
+
+var (
+	flagCluster  = flag.String("cluster", "127.0.0.1", "a comma-separated list of host:port tuples")
+	flagKeyspace = flag.String("keyspace", "", "a comma-separated list of keyspaces to inspect")
+	flagPkgname  = flag.String("pkgname", schemagen.DefaultPackage, "the name you wish to assign to your generated package")
+	flagOutput   = flag.String("output", schemagen.DefaultPackage, "the name of the folder to output to")
+	flagUser     = flag.String("user", "", "user for password authentication")
+	flagPassword = flag.String("password", "", "password for password authentication")
+	flagRepo     = flag.Bool("repository", false, "generate a repository with CRUD methods for every table")
+	flagUDT      = flag.Bool("udt-marshalers", false, "generate MarshalUDT and UnmarshalUDT methods of user defined types")
+)
+
+var (
+	flagInclude, flagExclude regexpsFlag
+	flagColumns              = columnsFlag{}
+)
+
+func init() {
+	flag.Var(&flagInclude, "include", "generate only tables matching the regular expression, can be repeated")
+	flag.Var(&flagExclude, "exclude", "skip tables matching the regular expression, can be repeated")
+	flag.Var(flagColumns, "column", "override Go type of a column with table.column=type, type is qualified with import path, can be repeated")
+}
+
+// regexpsFlag is a repeated flag of regular expressions.
+type regexpsFlag []*regexp.Regexp
+
+func (f *regexpsFlag) String() string {
+	var s []string
+	for _, re := range *f {
+		s = append(s, re.String())
+	}
+	return strings.Join(s, ",")
+}
+
+func (f *regexpsFlag) Set(v string) error {
+	re, err := regexp.Compile(v)
+	if err != nil {
+		return err
+	}
+	*f = append(*f, re)
+	return nil
+}
+
+// columnsFlag is a repeated flag of table.column=type Go type overrides.
+type columnsFlag map[string]string
+
+func (f columnsFlag) String() string {
+	var s []string
+	for k, v := range f {
+		s = append(s, k+"="+v)
+	}
+	return strings.Join(s, ",")
+}
+
+func (f columnsFlag) Set(v string) error {
+	i := strings.IndexByte(v, '=')
+	if i == -1 || !strings.Contains(v[:i], ".") || i == len(v)-1 {
+		return fmt.Errorf("expected table.column=type got %q", v)
+	}
+	f[v[:i]] = v[i+1:]
+	return nil
+}
+
+func main() {
+	flag.Parse()
+	if *flagKeyspace == "" {
+		fmt.Fprintln(os.Stderr, "missing required flag: -keyspace")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cluster := gocql.NewCluster(strings.Split(*flagCluster, ",")...)
+	if *flagUser != "" {
+		cluster.Authenticator = gocql.PasswordAuthenticator{
+			Username: *flagUser,
+			Password: *flagPassword,
+		}
+	}
+
+	cfg := schemagen.Config{
+		Cluster:       cluster,
+		Include:       flagInclude,
+		Exclude:       flagExclude,
+		Columns:       flagColumns,
+		Repository:    *flagRepo,
+		UDTMarshalers: *flagUDT,
+	}
+	if keyspaces := strings.Split(*flagKeyspace, ","); len(keyspaces) == 1 {
+		cfg.Keyspace = keyspaces[0]
+		cfg.Package = *flagPkgname
+		cfg.Output = filepath.Join(*flagOutput, *flagPkgname+".go")
+	} else {
+		for _, ks := range keyspaces {
+			pkg := schemagen.PackageName(ks)
+			cfg.Keyspaces = append(cfg.Keyspaces, schemagen.KeyspaceConfig{
+				Name:    ks,
+				Package: pkg,
+				Output:  filepath.Join(*flagOutput, pkg, pkg+".go"),
+			})
+		}
+	}
+
+	if err := schemagen.Generate(cfg); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gocql/gocql"
+)
+
+// Codec encodes values stored in blob columns, i.e. protobuf messages.
+// Marshal gets the bound value, Unmarshal gets a non-nil pointer to
+// the scanned value.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+type codecEntry struct {
+	typ   reflect.Type
+	codec Codec
+}
+
+var (
+	codecsMu sync.Mutex
+	codecs   atomic.Value // []codecEntry
+)
+
+// RegisterCodec registers codec for values of type t. If t is an interface all
+// types implementing the interface are handled by codec. Values are then
+// automatically encoded on bind and decoded on scan, there is no need to
+// implement gocql.Marshaler and gocql.Unmarshaler. Codecs are matched in
+// registration order.
+//
+// The registry is global and keyed by Go type only, codec is used for values
+// of type t regardless of the CQL type of the column, it's not limited to
+// blob columns. Register codecs for types that are stored in columns of
+// a single CQL type, and use a distinct Go type for each encoding.
+//
+// To store protobuf messages register a codec for the proto.Message
+// interface:
+//
+//	type protoCodec struct{}
+//
+//	func (protoCodec) Marshal(v interface{}) ([]byte, error) {
+//		return proto.Marshal(v.(proto.Message))
+//	}
+//
+//	func (protoCodec) Unmarshal(data []byte, v interface{}) error {
+//		return proto.Unmarshal(data, v.(proto.Message))
+//	}
+//
+//	gocqlx.RegisterCodec(reflect.TypeOf((*proto.Message)(nil)).Elem(), protoCodec{})
+//
+// RegisterCodec is meant to be called during program initialization.
+func RegisterCodec(t reflect.Type, codec Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+
+	entries, _ := codecs.Load().([]codecEntry)
+	n := make([]codecEntry, len(entries), len(entries)+1)
+	copy(n, entries)
+	codecs.Store(append(n, codecEntry{typ: t, codec: codec}))
+}
+
+func lookupCodec(entries []codecEntry, t reflect.Type) Codec {
+	for _, e := range entries {
+		if t == e.typ || e.typ.Kind() == reflect.Interface && t.Implements(e.typ) {
+			return e.codec
+		}
+	}
+	return nil
+}
+
+// codecWrapValue adds codec wrapper if a codec is registered for the type of
+// value or for the type value points to, otherwise it returns nil.
+func codecWrapValue(value reflect.Value) interface{} {
+	entries, _ := codecs.Load().([]codecEntry)
+	if len(entries) == 0 || !value.IsValid() {
+		return nil
+	}
+
+	t := value.Type()
+	if c := lookupCodec(entries, t); c != nil {
+		return codecValue{value: value, codec: c}
+	}
+	if t.Kind() == reflect.Ptr {
+		if c := lookupCodec(entries, t.Elem()); c != nil {
+			return codecValue{value: value, codec: c, indirect: true}
+		}
+	}
+	return nil
+}
+
+var (
+	_ gocql.Marshaler   = codecValue{}
+	_ gocql.Unmarshaler = codecValue{}
+)
+
+// codecValue wraps value with codec, if indirect is set value is a pointer to
+// the encoded value.
+type codecValue struct {
+	value    reflect.Value
+	codec    Codec
+	indirect bool
+}
+
+func (c codecValue) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+	v := c.value
+	if c.indirect {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, nil
+	}
+	return c.codec.Marshal(v.Interface())
+}
+
+func (c codecValue) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	v := c.value
+	if c.indirect {
+		v = v.Elem()
+		if data == nil {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		if v.Kind() != reflect.Ptr {
+			return c.codec.Unmarshal(data, c.value.Interface())
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+	}
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("can not unmarshal into non-pointer %s", v.Type())
+	}
+	if data == nil {
+		return nil
+	}
+	return c.codec.Unmarshal(data, v.Interface())
+}
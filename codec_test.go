@@ -0,0 +1,133 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+)
+
+// codecMessage mimics a generated protobuf message.
+type codecMessage struct {
+	ID   int
+	Name string
+}
+
+func (m *codecMessage) ProtoMessage() {}
+
+type protoMessage interface {
+	ProtoMessage()
+}
+
+// codecPoint is stored by value.
+type codecPoint struct {
+	X, Y int
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+var registerTestCodecsOnce sync.Once
+
+func registerTestCodecs() {
+	registerTestCodecsOnce.Do(func() {
+		RegisterCodec(reflect.TypeOf((*protoMessage)(nil)).Elem(), jsonCodec{})
+		RegisterCodec(reflect.TypeOf(codecPoint{}), jsonCodec{})
+	})
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	registerTestCodecs()
+
+	type row struct {
+		ID    int32
+		Msg   *codecMessage
+		Point codecPoint
+	}
+	columns := []gocql.ColumnInfo{
+		{Name: "id", TypeInfo: nativeType(gocql.TypeInt)},
+		{Name: "msg", TypeInfo: nativeType(gocql.TypeBlob)},
+		{Name: "point", TypeInfo: nativeType(gocql.TypeBlob)},
+	}
+
+	table := []struct {
+		Name string
+		V    row
+	}{
+		{
+			Name: "zero",
+		},
+		{
+			Name: "values",
+			V: row{
+				ID:    1,
+				Msg:   &codecMessage{ID: 2, Name: "name"},
+				Point: codecPoint{X: 3, Y: 4},
+			},
+		},
+	}
+
+	for i := range table {
+		test := table[i]
+		t.Run(test.Name, func(t *testing.T) {
+			got, err := RoundTrip(nil, columns, test.V)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.V, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestCodecScan(t *testing.T) {
+	registerTestCodecs()
+
+	info := nativeType(gocql.TypeBlob)
+	data := []byte(`{"ID":1,"Name":"name"}`)
+
+	t.Run("pointer", func(t *testing.T) {
+		v := new(codecMessage)
+		if err := gocql.Unmarshal(info, data, udtWrapSlice(DefaultMapper, false, []interface{}{v})[0]); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(&codecMessage{ID: 1, Name: "name"}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("pointer to nil pointer", func(t *testing.T) {
+		var v *codecMessage
+		if err := gocql.Unmarshal(info, data, udtWrapSlice(DefaultMapper, false, []interface{}{&v})[0]); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(&codecMessage{ID: 1, Name: "name"}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("null", func(t *testing.T) {
+		v := &codecMessage{ID: 1}
+		if err := gocql.Unmarshal(info, nil, udtWrapSlice(DefaultMapper, false, []interface{}{&v})[0]); err != nil {
+			t.Fatal(err)
+		}
+		if v != nil {
+			t.Fatal("expected nil")
+		}
+	})
+}
@@ -0,0 +1,207 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gocql/gocql"
+	"github.com/scylladb/go-reflectx"
+)
+
+// GzipOption is the struct tag option compressing a blob column with gzip
+// i.e. `db:"payload,gzip"`.
+const GzipOption = "gzip"
+
+// Compressor compresses values of blob columns. Fields tagged with the
+// option the compressor is registered for are marshalled, including
+// registered codecs, and compressed on bind, and decompressed and
+// unmarshalled on scan.
+type Compressor interface {
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+}
+
+// CompressionObserver is notified about every value compressed on bind,
+// metrics.Compression implements it.
+type CompressionObserver interface {
+	Observe(column string, size, compressedSize int)
+}
+
+var (
+	compressorsMu sync.Mutex
+	compressors   atomic.Value // map[string]Compressor
+
+	compressionObserver atomic.Value // observerHolder
+)
+
+var defaultCompressors = map[string]Compressor{
+	GzipOption: gzipCompressor{},
+}
+
+type observerHolder struct {
+	o CompressionObserver
+}
+
+// RegisterCompressor registers c for struct tag option, GzipOption is
+// registered by default. Algorithms not in the standard library, i.e. zstd,
+// can be registered with an implementation of choice:
+//
+//	type zstdCompressor struct {
+//		enc *zstd.Encoder
+//		dec *zstd.Decoder
+//	}
+//
+//	func (c zstdCompressor) Compress(data []byte) ([]byte, error) {
+//		return c.enc.EncodeAll(data, nil), nil
+//	}
+//
+//	func (c zstdCompressor) Decompress(data []byte) ([]byte, error) {
+//		return c.dec.DecodeAll(data, nil)
+//	}
+//
+//	gocqlx.RegisterCompressor("zstd", zstdCompressor{enc, dec})
+//
+// A field tagged `db:"payload,zstd"` is then compressed with zstd.
+// RegisterCompressor is meant to be called during program initialization.
+func RegisterCompressor(option string, c Compressor) {
+	compressorsMu.Lock()
+	defer compressorsMu.Unlock()
+
+	cur := loadCompressors()
+	n := make(map[string]Compressor, len(cur)+1)
+	for k, v := range cur {
+		n[k] = v
+	}
+	n[option] = c
+	compressors.Store(n)
+}
+
+func loadCompressors() map[string]Compressor {
+	if m, ok := compressors.Load().(map[string]Compressor); ok {
+		return m
+	}
+	return defaultCompressors
+}
+
+// SetCompressionObserver sets o to be notified with sizes of every value
+// compressed on bind, nil removes the observer. Use metrics.Compression to
+// verify that compression of a column pays for its CPU.
+func SetCompressionObserver(o CompressionObserver) {
+	compressionObserver.Store(observerHolder{o: o})
+}
+
+func loadCompressionObserver() CompressionObserver {
+	h, _ := compressionObserver.Load().(observerHolder)
+	return h.o
+}
+
+// fieldCompressor returns compressor registered for an option of field fi or
+// nil if there is none.
+func fieldCompressor(fi *reflectx.FieldInfo) Compressor {
+	if fi == nil || len(fi.Options) == 0 {
+		return nil
+	}
+	m := loadCompressors()
+	for o := range fi.Options {
+		if c, ok := m[o]; ok {
+			return c
+		}
+	}
+	return nil
+}
+
+// fieldCompressors returns compressed values, without the value set, of
+// fields of struct type t at traversals or nil if no field is compressed.
+func fieldCompressors(m *reflectx.Mapper, t reflect.Type, traversals [][]int) []compressedValue {
+	var cs []compressedValue
+	tm := m.TypeMap(reflectx.Deref(t))
+	for i, traversal := range traversals {
+		if len(traversal) == 0 {
+			continue
+		}
+		fi := tm.GetByTraversal(traversal)
+		if c := fieldCompressor(fi); c != nil {
+			if cs == nil {
+				cs = make([]compressedValue, len(traversals))
+			}
+			cs[i] = compressedValue{column: fi.Name, compressor: c}
+		}
+	}
+	return cs
+}
+
+var (
+	_ gocql.Marshaler   = compressedValue{}
+	_ gocql.Unmarshaler = compressedValue{}
+)
+
+// compressedValue compresses value of a blob column, value is a bound value
+// or a scan destination.
+type compressedValue struct {
+	column     string
+	value      interface{}
+	compressor Compressor
+}
+
+func (c compressedValue) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+	if info.Type() != gocql.TypeBlob {
+		return nil, fmt.Errorf("can not compress column %s of type %s", c.column, info.Type())
+	}
+	data, err := gocql.Marshal(info, c.value)
+	if err != nil || data == nil {
+		return data, err
+	}
+	b, err := c.compressor.Compress(data)
+	if err != nil {
+		return nil, fmt.Errorf("compress column %s: %s", c.column, err)
+	}
+	if o := loadCompressionObserver(); o != nil {
+		o.Observe(c.column, len(data), len(b))
+	}
+	return b, nil
+}
+
+func (c compressedValue) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	if info.Type() != gocql.TypeBlob {
+		return fmt.Errorf("can not decompress column %s of type %s", c.column, info.Type())
+	}
+	if data != nil {
+		var err error
+		if data, err = c.compressor.Decompress(data); err != nil {
+			return fmt.Errorf("decompress column %s: %s", c.column, err)
+		}
+	}
+	return gocql.Unmarshal(info, data, c.value)
+}
+
+type gzipCompressor struct{}
+
+func (gzipCompressor) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gzipCompressor) Decompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/gocqlx/v2/metrics"
+)
+
+type compressedRow struct {
+	ID      int32
+	Payload []byte `db:"payload,gzip"`
+	Doc     string `db:"doc,gzip"`
+}
+
+var compressedColumns = []gocql.ColumnInfo{
+	{Name: "id", TypeInfo: nativeType(gocql.TypeInt)},
+	{Name: "payload", TypeInfo: nativeType(gocql.TypeBlob)},
+	{Name: "doc", TypeInfo: nativeType(gocql.TypeBlob)},
+}
+
+func TestCompressionRoundTrip(t *testing.T) {
+	c := metrics.NewCompression()
+	SetCompressionObserver(c)
+	defer SetCompressionObserver(nil)
+
+	v := compressedRow{
+		ID:      1,
+		Payload: bytes.Repeat([]byte("payload"), 100),
+		Doc:     strings.Repeat("doc", 100),
+	}
+
+	args, err := bindStructArgs([]string{"id", "payload", "doc"}, v, nil, DefaultMapper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := gocql.Marshal(compressedColumns[1].TypeInfo, args[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		t.Fatalf("expected gzip data got %x", data)
+	}
+
+	got, err := RoundTrip(nil, compressedColumns, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(v, got); diff != "" {
+		t.Fatal(diff)
+	}
+
+	s := c.Snapshot()
+	if s["payload"].Count != 2 || s["payload"].Size != 1400 {
+		t.Fatalf("unexpected payload stats %+v", s["payload"])
+	}
+	if s["payload"].Ratio() <= 1 {
+		t.Fatalf("expected payload to be compressed got ratio %f", s["payload"].Ratio())
+	}
+	if _, ok := s["id"]; ok {
+		t.Fatal("unexpected id stats")
+	}
+}
+
+func TestCompressionNull(t *testing.T) {
+	got, err := RoundTrip(nil, compressedColumns, compressedRow{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(compressedRow{ID: 1}, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestCompressionNotBlob(t *testing.T) {
+	columns := []gocql.ColumnInfo{
+		{Name: "id", TypeInfo: nativeType(gocql.TypeInt)},
+		{Name: "payload", TypeInfo: nativeType(gocql.TypeBlob)},
+		{Name: "doc", TypeInfo: nativeType(gocql.TypeText)},
+	}
+	_, err := RoundTrip(nil, columns, compressedRow{Doc: "doc"})
+	if err == nil || !strings.Contains(err.Error(), "can not compress column doc of type text") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+type reverseCompressor struct{}
+
+func (reverseCompressor) Compress(data []byte) ([]byte, error) {
+	b := make([]byte, len(data))
+	for i := range data {
+		b[len(data)-1-i] = data[i]
+	}
+	return b, nil
+}
+
+func (c reverseCompressor) Decompress(data []byte) ([]byte, error) {
+	return c.Compress(data)
+}
+
+func TestRegisterCompressor(t *testing.T) {
+	RegisterCompressor("reverse", reverseCompressor{})
+
+	type row struct {
+		ID      int32
+		Payload []byte `db:"payload,reverse"`
+	}
+	v := row{ID: 1, Payload: []byte("abc")}
+
+	args, err := bindStructArgs([]string{"payload"}, v, nil, DefaultMapper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := gocql.Marshal(compressedColumns[1].TypeInfo, args[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "cba" {
+		t.Fatalf("expected reversed data got %q", data)
+	}
+
+	got, err := RoundTrip(nil, compressedColumns[:2], v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(v, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
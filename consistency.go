@@ -0,0 +1,105 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// HealthSource reports health of datacenters.
+type HealthSource interface {
+	// Healthy returns false if the datacenter is unhealthy.
+	Healthy(dc string) bool
+}
+
+// HealthSourceFunc is an adapter to allow the use of ordinary functions as
+// HealthSource.
+type HealthSourceFunc func(dc string) bool
+
+// Healthy calls f(dc).
+func (f HealthSourceFunc) Healthy(dc string) bool {
+	return f(dc)
+}
+
+// ConsistencyTransition describes a change of consistency made by
+// AdaptiveConsistency.
+type ConsistencyTransition struct {
+	From gocql.Consistency
+	To   gocql.Consistency
+	// Unhealthy lists unhealthy datacenters, it's empty when consistency
+	// is restored.
+	Unhealthy []string
+	Time      time.Time
+}
+
+// ConsistencyObserver is notified about consistency transitions.
+type ConsistencyObserver interface {
+	ObserveConsistency(t ConsistencyTransition)
+}
+
+// AdaptiveConsistency is a read consistency policy that lowers consistency
+// when any of the datacenters is reported unhealthy and restores it when all
+// of them recover. Health is checked every time the consistency is used, the
+// health source is expected to be cheap to call i.e. to return cached state.
+// AdaptiveConsistency is safe for concurrent use.
+type AdaptiveConsistency struct {
+	// Normal is the consistency used when all the datacenters are healthy.
+	Normal gocql.Consistency
+	// Degraded is the consistency used when a datacenter is unhealthy.
+	Degraded gocql.Consistency
+	// DCs lists datacenters to check.
+	DCs []string
+	// Health reports health of datacenters.
+	Health HealthSource
+	// Observers are notified about every transition.
+	Observers []ConsistencyObserver
+
+	mu       sync.Mutex
+	degraded bool
+}
+
+// Consistency returns consistency for the current health of datacenters.
+func (a *AdaptiveConsistency) Consistency() gocql.Consistency {
+	var unhealthy []string
+	for _, dc := range a.DCs {
+		if !a.Health.Healthy(dc) {
+			unhealthy = append(unhealthy, dc)
+		}
+	}
+	degraded := len(unhealthy) > 0
+
+	a.mu.Lock()
+	changed := a.degraded != degraded
+	a.degraded = degraded
+	a.mu.Unlock()
+
+	if changed {
+		t := ConsistencyTransition{
+			From:      a.Normal,
+			To:        a.Degraded,
+			Unhealthy: unhealthy,
+			Time:      time.Now(),
+		}
+		if !degraded {
+			t.From, t.To = t.To, t.From
+		}
+		for _, o := range a.Observers {
+			o.ObserveConsistency(t)
+		}
+	}
+
+	if degraded {
+		return a.Degraded
+	}
+	return a.Normal
+}
+
+// Apply sets consistency of the query.
+func (a *AdaptiveConsistency) Apply(q *Queryx) *Queryx {
+	return q.Consistency(a.Consistency())
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+)
+
+type consistencyRecorder []ConsistencyTransition
+
+func (r *consistencyRecorder) ObserveConsistency(t ConsistencyTransition) {
+	*r = append(*r, t)
+}
+
+func TestAdaptiveConsistency(t *testing.T) {
+	healthy := map[string]bool{"dc1": true, "dc2": true}
+	var r consistencyRecorder
+
+	a := &AdaptiveConsistency{
+		Normal:    gocql.Quorum,
+		Degraded:  gocql.LocalQuorum,
+		DCs:       []string{"dc1", "dc2"},
+		Health:    HealthSourceFunc(func(dc string) bool { return healthy[dc] }),
+		Observers: []ConsistencyObserver{&r},
+	}
+
+	check := func(expected gocql.Consistency) {
+		t.Helper()
+		if c := a.Consistency(); c != expected {
+			t.Fatalf("Consistency()=%s, expected %s", c, expected)
+		}
+	}
+
+	check(gocql.Quorum)
+	healthy["dc2"] = false
+	check(gocql.LocalQuorum)
+	check(gocql.LocalQuorum)
+	healthy["dc2"] = true
+	check(gocql.Quorum)
+
+	var got []ConsistencyTransition
+	for _, tr := range r {
+		got = append(got, ConsistencyTransition{From: tr.From, To: tr.To, Unhealthy: tr.Unhealthy})
+	}
+	expected := []ConsistencyTransition{
+		{From: gocql.Quorum, To: gocql.LocalQuorum, Unhealthy: []string{"dc2"}},
+		{From: gocql.LocalQuorum, To: gocql.Quorum},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Fatal(diff)
+	}
+	for _, tr := range r {
+		if tr.Time.IsZero() {
+			t.Fatal("expected transition time")
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/scylladb/go-reflectx"
+)
+
+// SelectDistinctBy is like Select but keeps only the first row for every
+// distinct value of field, other rows are dropped during scanning. Field is
+// a name mapped by mapper i.e. a column name, the field must be of
+// a comparable type, a pointer to it or a byte slice. Nil pointers are
+// equal.
+func (q *Queryx) SelectDistinctBy(dest interface{}, field string) error {
+	if q.err != nil {
+		return q.err
+	}
+	return q.Iter().SelectDistinctBy(dest, field)
+}
+
+// SelectDistinctBy is like Select but keeps only the first row for every
+// distinct value of field, see Queryx.SelectDistinctBy.
+func (iter *Iterx) SelectDistinctBy(dest interface{}, field string) error {
+	d, err := newDistinctBy(dest, field, iter.Mapper)
+	if err != nil {
+		iter.Close()
+		return err
+	}
+	iter.distinct = d
+	return iter.Select(dest)
+}
+
+// distinctBy tracks values of a struct field of the scanned rows.
+type distinctBy struct {
+	index []int
+	seen  map[interface{}]struct{}
+}
+
+type nilKey struct{}
+
+func newDistinctBy(dest interface{}, field string, m *reflectx.Mapper) (*distinctBy, error) {
+	t := reflect.TypeOf(dest)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Slice {
+		return nil, fmt.Errorf("expected a pointer to slice but got %T", dest)
+	}
+	base := reflectx.Deref(t.Elem().Elem())
+	if base.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a slice of structs but got %s", t.Elem())
+	}
+
+	index := m.TraversalsByName(base, []string{field})[0]
+	if len(index) == 0 {
+		return nil, fmt.Errorf("could not find name %q in %s", field, base)
+	}
+	if ft := reflectx.Deref(base.FieldByIndex(index).Type); !ft.Comparable() && ft != reflect.TypeOf([]byte(nil)) {
+		return nil, fmt.Errorf("distinct by %q: type %s is not comparable", field, ft)
+	}
+
+	return &distinctBy{
+		index: index,
+		seen:  make(map[interface{}]struct{}),
+	}, nil
+}
+
+// first returns true if the field value of the struct pointed by vp was not
+// seen before.
+func (d *distinctBy) first(vp reflect.Value) bool {
+	var key interface{} = nilKey{}
+	if v := reflect.Indirect(reflect.Indirect(vp).FieldByIndex(d.index)); v.IsValid() {
+		if v.Kind() == reflect.Slice {
+			key = string(v.Bytes())
+		} else {
+			key = v.Interface()
+		}
+	}
+
+	if _, ok := d.seen[key]; ok {
+		return false
+	}
+	d.seen[key] = struct{}{}
+	return true
+}
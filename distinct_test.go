@@ -0,0 +1,69 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDistinctBy(t *testing.T) {
+	type row struct {
+		ID   int
+		Name *string
+		Blob []byte
+		Tags []string
+	}
+
+	t.Run("first", func(t *testing.T) {
+		a, b := "a", "b"
+		rows := []row{
+			{ID: 1, Name: &a, Blob: []byte("x")},
+			{ID: 2, Name: &b, Blob: []byte("y")},
+			{ID: 3, Name: nil, Blob: []byte("x")},
+			{ID: 4, Name: &a, Blob: []byte("z")},
+			{ID: 5, Name: nil, Blob: []byte("y")},
+		}
+
+		table := []struct {
+			Field string
+			IDs   []int
+		}{
+			{Field: "id", IDs: []int{1, 2, 3, 4, 5}},
+			{Field: "name", IDs: []int{1, 2, 3}},
+			{Field: "blob", IDs: []int{1, 2, 4}},
+		}
+		for _, test := range table {
+			d, err := newDistinctBy(&[]*row{}, test.Field, DefaultMapper)
+			if err != nil {
+				t.Fatal("newDistinctBy() error:", err)
+			}
+			var ids []int
+			for i := range rows {
+				if d.first(reflect.ValueOf(&rows[i])) {
+					ids = append(ids, rows[i].ID)
+				}
+			}
+			if diff := cmp.Diff(test.IDs, ids); diff != "" {
+				t.Error(test.Field, diff)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, dest := range []interface{}{[]row{}, &[]int{}, &row{}} {
+			if _, err := newDistinctBy(dest, "id", DefaultMapper); err == nil {
+				t.Errorf("newDistinctBy(%T) expected error", dest)
+			}
+		}
+		for _, field := range []string{"not_found", "tags"} {
+			if _, err := newDistinctBy(&[]row{}, field, DefaultMapper); err == nil {
+				t.Errorf("newDistinctBy(%q) expected error", field)
+			}
+		}
+	})
+}
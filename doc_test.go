@@ -21,6 +21,24 @@ func ExampleSession() {
 	session.Query(builder.ToCql())
 }
 
+func ExampleTimeUUIDGenerator() {
+	var session gocqlx.Session
+
+	g := gocqlx.NewTimeUUIDGenerator(nil)
+
+	type Event struct {
+		ID      gocql.UUID
+		Payload string
+	}
+	e := Event{
+		ID:      g.Next(),
+		Payload: "payload",
+	}
+
+	stmt, names := qb.Insert("events").Columns("id", "payload").ToCql()
+	session.Query(stmt, names).BindStruct(e).ExecRelease()
+}
+
 func ExampleUDT() {
 	// Just add gocqlx.UDT to a type, no need to implement marshalling functions
 	type FullName struct {
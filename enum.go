@@ -0,0 +1,229 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/gocql/gocql"
+)
+
+// UnknownEnumPolicy specifies how Enum handles stored values that are not
+// known to the enum.
+type UnknownEnumPolicy int
+
+// Unknown value policies.
+const (
+	// EnumUnknownError fails to scan unknown values.
+	EnumUnknownError UnknownEnumPolicy = iota
+	// EnumUnknownZero scans unknown values as empty string.
+	EnumUnknownZero
+	// EnumUnknownPreserve scans unknown values as their raw stored value
+	// (an int value is formatted in base 10) and writes them back unchanged.
+	// This allows older code to round trip values added by newer code.
+	EnumUnknownPreserve
+)
+
+// EnumValue is a value of an Enum. Name is the Go value, Text is stored in
+// text columns, if empty Name is used, and Int is stored in integer columns.
+// Int is used only if the enum is int-backed, that is if Int of any of its
+// values is not zero.
+type EnumValue struct {
+	Name string
+	Text string
+	Int  int64
+}
+
+// Enum maps Go string constants to values stored in text or integer columns
+// and validates the values on bind and scan. It's meant to be used in
+// gocql.Marshaler and gocql.Unmarshaler implementations of an enum type:
+//
+//	type Status string
+//
+//	const (
+//		Active  Status = "active"
+//		Blocked Status = "blocked"
+//	)
+//
+//	var statusEnum = gocqlx.NewEnum(
+//		gocqlx.EnumValue{Name: string(Active), Int: 1},
+//		gocqlx.EnumValue{Name: string(Blocked), Int: 2},
+//	)
+//
+//	func (s Status) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+//		return statusEnum.Marshal(info, string(s))
+//	}
+//
+//	func (s *Status) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+//		v, err := statusEnum.Unmarshal(info, data)
+//		*s = Status(v)
+//		return err
+//	}
+//
+// Empty Go value is stored as null, null is scanned as empty Go value.
+type Enum struct {
+	// Unknown specifies how unknown values are handled, by default unknown
+	// values are rejected.
+	Unknown UnknownEnumPolicy
+
+	names    []string
+	toText   map[string]string
+	fromText map[string]string
+	toInt    map[string]int64
+	fromInt  map[int64]string
+}
+
+// NewEnum returns a new Enum with the given values, it panics if names, texts
+// or, for int-backed enums, ints are not unique.
+func NewEnum(values ...EnumValue) *Enum {
+	e := &Enum{
+		toText:   make(map[string]string, len(values)),
+		fromText: make(map[string]string, len(values)),
+	}
+	for _, v := range values {
+		if v.Int != 0 {
+			e.toInt = make(map[string]int64, len(values))
+			e.fromInt = make(map[int64]string, len(values))
+			break
+		}
+	}
+	for _, v := range values {
+		if v.Text == "" {
+			v.Text = v.Name
+		}
+		if _, ok := e.toText[v.Name]; ok {
+			panic(fmt.Sprintf("duplicate enum name %q", v.Name))
+		}
+		if _, ok := e.fromText[v.Text]; ok {
+			panic(fmt.Sprintf("duplicate enum text %q", v.Text))
+		}
+		e.names = append(e.names, v.Name)
+		e.toText[v.Name] = v.Text
+		e.fromText[v.Text] = v.Name
+
+		if e.toInt == nil {
+			continue
+		}
+		if _, ok := e.fromInt[v.Int]; ok {
+			panic(fmt.Sprintf("duplicate enum int %d", v.Int))
+		}
+		e.toInt[v.Name] = v.Int
+		e.fromInt[v.Int] = v.Name
+	}
+	sort.Strings(e.names)
+	return e
+}
+
+// Names returns sorted names of the enum values.
+func (e *Enum) Names() []string {
+	return append([]string(nil), e.names...)
+}
+
+// Valid returns true if name is a known enum value.
+func (e *Enum) Valid(name string) bool {
+	_, ok := e.toText[name]
+	return ok
+}
+
+// Marshal marshals the enum value to a text or integer column.
+func (e *Enum) Marshal(info gocql.TypeInfo, name string) ([]byte, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	switch {
+	case isTextType(info):
+		v, ok := e.toText[name]
+		if !ok {
+			if e.Unknown != EnumUnknownPreserve {
+				return nil, fmt.Errorf("unknown enum value %q", name)
+			}
+			v = name
+		}
+		return gocql.Marshal(info, v)
+	case isIntType(info):
+		if e.toInt == nil {
+			return nil, fmt.Errorf("enum is not int-backed, unsupported column type %s", info.Type())
+		}
+		v, ok := e.toInt[name]
+		if !ok {
+			if e.Unknown != EnumUnknownPreserve {
+				return nil, fmt.Errorf("unknown enum value %q", name)
+			}
+			i, err := strconv.ParseInt(name, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("unknown enum value %q", name)
+			}
+			v = i
+		}
+		return gocql.Marshal(info, v)
+	default:
+		return nil, fmt.Errorf("unsupported enum column type %s", info.Type())
+	}
+}
+
+// Unmarshal unmarshals the enum value from a text or integer column.
+func (e *Enum) Unmarshal(info gocql.TypeInfo, data []byte) (string, error) {
+	if data == nil {
+		return "", nil
+	}
+
+	var (
+		name string
+		ok   bool
+		raw  string
+	)
+	switch {
+	case isTextType(info):
+		if err := gocql.Unmarshal(info, data, &raw); err != nil {
+			return "", err
+		}
+		name, ok = e.fromText[raw]
+	case isIntType(info):
+		if e.toInt == nil {
+			return "", fmt.Errorf("enum is not int-backed, unsupported column type %s", info.Type())
+		}
+		var v int64
+		if err := gocql.Unmarshal(info, data, &v); err != nil {
+			return "", err
+		}
+		name, ok = e.fromInt[v]
+		raw = strconv.FormatInt(v, 10)
+	default:
+		return "", fmt.Errorf("unsupported enum column type %s", info.Type())
+	}
+	if ok {
+		return name, nil
+	}
+
+	switch e.Unknown {
+	case EnumUnknownZero:
+		return "", nil
+	case EnumUnknownPreserve:
+		return raw, nil
+	default:
+		return "", fmt.Errorf("unknown enum value %q", raw)
+	}
+}
+
+func isTextType(info gocql.TypeInfo) bool {
+	switch info.Type() {
+	case gocql.TypeText, gocql.TypeVarchar, gocql.TypeAscii:
+		return true
+	default:
+		return false
+	}
+}
+
+func isIntType(info gocql.TypeInfo) bool {
+	switch info.Type() {
+	case gocql.TypeTinyInt, gocql.TypeSmallInt, gocql.TypeInt, gocql.TypeBigInt:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+)
+
+type testStatus string
+
+const (
+	testStatusActive  testStatus = "active"
+	testStatusBlocked testStatus = "blocked"
+)
+
+var testStatusEnum = NewEnum(
+	EnumValue{Name: string(testStatusActive), Int: 1},
+	EnumValue{Name: string(testStatusBlocked), Text: "BLOCKED", Int: 2},
+)
+
+func (s testStatus) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+	return testStatusEnum.Marshal(info, string(s))
+}
+
+func (s *testStatus) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	v, err := testStatusEnum.Unmarshal(info, data)
+	*s = testStatus(v)
+	return err
+}
+
+func TestEnum(t *testing.T) {
+	table := []struct {
+		Name string
+		Info gocql.TypeInfo
+		V    testStatus
+		Data []byte
+	}{
+		{
+			Name: "text",
+			Info: nativeType(gocql.TypeText),
+			V:    testStatusActive,
+			Data: []byte("active"),
+		},
+		{
+			Name: "custom text",
+			Info: nativeType(gocql.TypeVarchar),
+			V:    testStatusBlocked,
+			Data: []byte("BLOCKED"),
+		},
+		{
+			Name: "int",
+			Info: nativeType(gocql.TypeInt),
+			V:    testStatusBlocked,
+			Data: []byte{0, 0, 0, 2},
+		},
+		{
+			Name: "tinyint",
+			Info: nativeType(gocql.TypeTinyInt),
+			V:    testStatusActive,
+			Data: []byte{1},
+		},
+		{
+			Name: "null",
+			Info: nativeType(gocql.TypeText),
+		},
+	}
+
+	for i := range table {
+		test := table[i]
+		t.Run(test.Name, func(t *testing.T) {
+			b, err := gocql.Marshal(test.Info, test.V)
+			if err != nil {
+				t.Fatal("Marshal() error", err)
+			}
+			if diff := cmp.Diff(test.Data, b); diff != "" {
+				t.Fatal(diff)
+			}
+
+			var v testStatus
+			if err := gocql.Unmarshal(test.Info, b, &v); err != nil {
+				t.Fatal("Unmarshal() error", err)
+			}
+			if v != test.V {
+				t.Fatalf("Unmarshal()=%q, expected %q", v, test.V)
+			}
+		})
+	}
+}
+
+func TestEnumUnknown(t *testing.T) {
+	text := nativeType(gocql.TypeText)
+	integer := nativeType(gocql.TypeInt)
+
+	t.Run("marshal", func(t *testing.T) {
+		if _, err := testStatusEnum.Marshal(text, "deleted"); err == nil {
+			t.Fatal("Marshal() expected error")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		e := NewEnum(EnumValue{Name: "active", Int: 1})
+		if _, err := e.Unmarshal(text, []byte("deleted")); err == nil {
+			t.Fatal("Unmarshal() expected error")
+		}
+		if _, err := e.Unmarshal(integer, []byte{0, 0, 0, 3}); err == nil {
+			t.Fatal("Unmarshal() expected error")
+		}
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		e := NewEnum(EnumValue{Name: "active", Int: 1})
+		e.Unknown = EnumUnknownZero
+		v, err := e.Unmarshal(text, []byte("deleted"))
+		if err != nil {
+			t.Fatal("Unmarshal() error", err)
+		}
+		if v != "" {
+			t.Fatalf("Unmarshal()=%q, expected empty", v)
+		}
+	})
+
+	t.Run("preserve", func(t *testing.T) {
+		e := NewEnum(EnumValue{Name: "active", Int: 1})
+		e.Unknown = EnumUnknownPreserve
+		for _, test := range []struct {
+			Info gocql.TypeInfo
+			Data []byte
+		}{
+			{Info: text, Data: []byte("deleted")},
+			{Info: integer, Data: []byte{0, 0, 0, 3}},
+		} {
+			v, err := e.Unmarshal(test.Info, test.Data)
+			if err != nil {
+				t.Fatal("Unmarshal() error", err)
+			}
+			b, err := e.Marshal(test.Info, v)
+			if err != nil {
+				t.Fatal("Marshal() error", err)
+			}
+			if diff := cmp.Diff(test.Data, b); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		if _, err := testStatusEnum.Marshal(nativeType(gocql.TypeBlob), "active"); err == nil {
+			t.Fatal("Marshal() expected error")
+		}
+	})
+}
+
+func TestNewEnumPanicsOnDuplicates(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	NewEnum(EnumValue{Name: "a", Int: 1}, EnumValue{Name: "b", Int: 1})
+}
+
+func TestEnumTextOnly(t *testing.T) {
+	e := NewEnum(EnumValue{Name: "active"}, EnumValue{Name: "blocked", Text: "BLOCKED"})
+
+	text := nativeType(gocql.TypeText)
+	for _, name := range []string{"active", "blocked"} {
+		b, err := e.Marshal(text, name)
+		if err != nil {
+			t.Fatal("Marshal() error", err)
+		}
+		v, err := e.Unmarshal(text, b)
+		if err != nil {
+			t.Fatal("Unmarshal() error", err)
+		}
+		if v != name {
+			t.Fatalf("Unmarshal()=%q, expected %q", v, name)
+		}
+	}
+
+	integer := nativeType(gocql.TypeInt)
+	if _, err := e.Marshal(integer, "active"); err == nil {
+		t.Fatal("Marshal() expected error")
+	}
+	if _, err := e.Unmarshal(integer, []byte{0, 0, 0, 0}); err == nil {
+		t.Fatal("Unmarshal() expected error")
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Bindings is an iterator over values bound by ExecMany.
+type Bindings interface {
+	// Next returns the next value, it's bound with BindMap if it's
+	// a map[string]interface{}, otherwise with BindStruct. It returns false
+	// when there are no more values.
+	Next() (arg interface{}, ok bool)
+}
+
+type sliceBindings struct {
+	v reflect.Value
+	i int
+}
+
+// SliceBindings returns Bindings over elements of slice v, it returns an
+// error if v is not a slice.
+func SliceBindings(v interface{}) (Bindings, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("expected a slice but got %T", v)
+	}
+	return &sliceBindings{v: rv}, nil
+}
+
+func (b *sliceBindings) Next() (interface{}, bool) {
+	if b.i >= b.v.Len() {
+		return nil, false
+	}
+	b.i++
+	return b.v.Index(b.i - 1).Interface(), true
+}
+
+// ExecError is an error of executing a statement for a value of Bindings.
+type ExecError struct {
+	// Index is the index of the value in Bindings, starting from zero.
+	Index int
+	Err   error
+}
+
+func (e *ExecError) Error() string {
+	return fmt.Sprintf("binding %d: %s", e.Index, e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *ExecError) Unwrap() error {
+	return e.Err
+}
+
+// ExecErrors is returned by ExecMany when executions failed, errors are
+// ordered by index.
+type ExecErrors []ExecError
+
+func (e ExecErrors) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "failed to execute %d statements: ", len(e))
+	for i, r := range e {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		fmt.Fprintf(&b, "binding %d: %s", r.Index, r.Err)
+	}
+	return b.String()
+}
+
+// ExecCanceledError is returned by ExecMany when ctx is done after some
+// executions failed. It unwraps to the ctx error and can be converted to
+// ExecErrors with errors.As.
+type ExecCanceledError struct {
+	// Err is the ctx error.
+	Err error
+	// Errors are errors of executions started before ctx was done.
+	Errors ExecErrors
+}
+
+func (e *ExecCanceledError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Err, e.Errors)
+}
+
+// Unwrap returns the ctx error.
+func (e *ExecCanceledError) Unwrap() error {
+	return e.Err
+}
+
+// As sets target to the errors of executions if target is *ExecErrors.
+func (e *ExecCanceledError) As(target interface{}) bool {
+	if t, ok := target.(*ExecErrors); ok {
+		*t = e.Errors
+		return true
+	}
+	return false
+}
+
+// ExecMany executes the statement once for every value of bindings with at
+// most concurrency queries running in parallel. The statement is prepared
+// once and executed with many binding sets, unlike batches it spreads the
+// load across the cluster, it's the preferred way of high throughput writes.
+//
+// Failed executions do not stop ExecMany, their errors are returned as
+// ExecErrors. If ctx is done no new queries are started and ctx error is
+// returned, if executions started before failed *ExecCanceledError holding
+// both ctx error and ExecErrors is returned.
+func (s Session) ExecMany(ctx context.Context, stmt string, names []string, bindings Bindings, concurrency int) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs ExecErrors
+		sem  = make(chan struct{}, concurrency)
+	)
+	exec := func(i int, arg interface{}) {
+		defer func() {
+			<-sem
+			wg.Done()
+		}()
+
+		q := s.ContextQuery(ctx, stmt, names)
+		if m, ok := arg.(map[string]interface{}); ok {
+			q.BindMap(m)
+		} else {
+			q.BindStruct(arg)
+		}
+		if err := q.ExecRelease(); err != nil {
+			mu.Lock()
+			errs = append(errs, ExecError{Index: i, Err: err})
+			mu.Unlock()
+		}
+	}
+
+	var ctxErr error
+	for i := 0; ; i++ {
+		if ctxErr = ctx.Err(); ctxErr != nil {
+			break
+		}
+		arg, ok := bindings.Next()
+		if !ok {
+			break
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctxErr = ctx.Err(); ctxErr != nil {
+			break
+		}
+		wg.Add(1)
+		go exec(i, arg)
+	}
+	wg.Wait()
+
+	return execManyError(ctxErr, errs)
+}
+
+// execManyError returns error of ExecMany given ctx error and errors of
+// executions.
+func execManyError(ctxErr error, errs ExecErrors) error {
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Index < errs[j].Index })
+	}
+	switch {
+	case ctxErr != nil && len(errs) > 0:
+		return &ExecCanceledError{Err: ctxErr, Errors: errs}
+	case ctxErr != nil:
+		return ctxErr
+	case len(errs) > 0:
+		return errs
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+// +build all integration
+
+package gocqlx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/gocqlx/v2"
+	. "github.com/scylladb/gocqlx/v2/gocqlxtest"
+	"github.com/scylladb/gocqlx/v2/qb"
+)
+
+func TestSessionExecMany(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.exec_many (id int PRIMARY KEY, name text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	type row struct {
+		ID   int
+		Name string
+	}
+	var rows []interface{}
+	for i := 0; i < 100; i++ {
+		rows = append(rows, row{ID: i, Name: "name"})
+	}
+	rows = append(rows, map[string]interface{}{"id": 100, "name": "map"}, struct{ ID int }{ID: 101})
+
+	stmt, names := qb.Insert("gocqlx_test.exec_many").Columns("id", "name").ToCql()
+	bindings, err := gocqlx.SliceBindings(rows)
+	if err != nil {
+		t.Fatal("SliceBindings() error:", err)
+	}
+	err = session.ExecMany(context.Background(), stmt, names, bindings, 8)
+
+	var errs gocqlx.ExecErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Index != 101 {
+		t.Fatalf("ExecMany() error %v, expected error of binding 101", err)
+	}
+
+	var count int
+	if err := session.Query(`SELECT count(*) FROM gocqlx_test.exec_many`, nil).Get(&count); err != nil {
+		t.Fatal("count:", err)
+	}
+	if diff := cmp.Diff(101, count); diff != "" {
+		t.Fatal(diff)
+	}
+
+	t.Run("context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		bindings, err := gocqlx.SliceBindings(rows)
+		if err != nil {
+			t.Fatal("SliceBindings() error:", err)
+		}
+		if err := session.ExecMany(ctx, stmt, names, bindings, 8); err != context.Canceled {
+			t.Fatalf("ExecMany() error %v, expected %v", err, context.Canceled)
+		}
+	})
+
+	t.Run("context canceled after errors", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		bindings := &cancelBindings{
+			args:   []interface{}{struct{ ID int }{ID: 0}, row{ID: 1}},
+			cancel: cancel,
+		}
+		err := session.ExecMany(ctx, stmt, names, bindings, 1)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("ExecMany() error %v, expected %v", err, context.Canceled)
+		}
+		var errs gocqlx.ExecErrors
+		if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Index != 0 {
+			t.Fatalf("ExecMany() error %v, expected error of binding 0", err)
+		}
+	})
+
+	t.Run("not a slice", func(t *testing.T) {
+		if _, err := gocqlx.SliceBindings(row{}); err == nil {
+			t.Fatal("SliceBindings() expected error")
+		}
+	})
+}
+
+// cancelBindings calls cancel when the last argument is requested.
+type cancelBindings struct {
+	args   []interface{}
+	cancel context.CancelFunc
+}
+
+func (b *cancelBindings) Next() (interface{}, bool) {
+	if len(b.args) == 0 {
+		return nil, false
+	}
+	if len(b.args) == 1 {
+		b.cancel()
+	}
+	arg := b.args[0]
+	b.args = b.args[1:]
+	return arg, true
+}
@@ -0,0 +1,233 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+// Package gocqlxvet provides an analyzer that flags common mistakes in gocqlx
+// usage. It can be run with go vet using the gocqlxvet command
+//
+//	go install github.com/scylladb/gocqlx/v2/gocqlxvet/cmd/gocqlxvet
+//	go vet -vettool=$(which gocqlxvet) ./...
+//
+// or added to any driver of golang.org/x/tools/go/analysis analyzers.
+package gocqlxvet
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const (
+	gocqlxPath = "github.com/scylladb/gocqlx/v2"
+	qbPath     = "github.com/scylladb/gocqlx/v2/qb"
+)
+
+// AllowFilteringDirective is a comment that allows for using ALLOW FILTERING
+// in the line it's in or in the line below.
+const AllowFilteringDirective = "//gocqlx:allow-filtering"
+
+const doc = `check for common mistakes in gocqlx usage
+
+The gocqlxvet analyzer reports:
+
+- Select into a destination that is not a pointer to slice,
+- queries executed with Exec, Get, Select, ExecCAS or GetCAS straight after
+  creation that can not be released, use the Release variants instead,
+- structs bound with BindStruct that have some fields with db tags and some
+  exported fields without them,
+- ALLOW FILTERING without the ` + AllowFilteringDirective + ` comment.`
+
+// Analyzer checks gocqlx usage.
+var Analyzer = &analysis.Analyzer{
+	Name:     "gocqlxvet",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// releaseVariants maps Queryx methods to their Release variants.
+var releaseVariants = map[string]string{
+	"Exec":    "ExecRelease",
+	"Get":     "GetRelease",
+	"Select":  "SelectRelease",
+	"ExecCAS": "ExecCASRelease",
+	"GetCAS":  "GetCASRelease",
+}
+
+// selectMethods are methods scanning into a pointer to slice.
+var selectMethods = map[string]bool{
+	"Select":           true,
+	"SelectRelease":    true,
+	"SelectDistinctBy": true,
+	"SelectPage":       true,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	allowed := allowFilteringLines(pass)
+
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return
+		}
+		recv, method := methodOf(pass, sel)
+		if recv == "" {
+			return
+		}
+
+		switch recv {
+		case "Queryx", "Iterx":
+			if selectMethods[method] && len(call.Args) > 0 {
+				checkSelectDest(pass, call.Args[0])
+			}
+			if recv == "Queryx" {
+				if v, ok := releaseVariants[method]; ok && isFreshQuery(pass, sel.X) {
+					pass.Reportf(call.Pos(), "query is never released, use %s", v)
+				}
+				if (method == "BindStruct" || method == "BindStructMap") && len(call.Args) > 0 {
+					checkTags(pass, call.Args[0])
+				}
+			}
+		case "Batchx":
+			if (method == "BindStruct" || method == "BindStructMap") && len(call.Args) > 2 {
+				checkTags(pass, call.Args[2])
+			}
+		case "SelectBuilder":
+			if method == "AllowFiltering" {
+				p := pass.Fset.Position(call.Pos())
+				if !allowed[p.Filename][p.Line] {
+					pass.Reportf(call.Pos(), "ALLOW FILTERING without %s comment", AllowFilteringDirective)
+				}
+			}
+		}
+	})
+
+	return nil, nil
+}
+
+// methodOf returns receiver type name and method name if sel is a method of
+// a gocqlx or qb type.
+func methodOf(pass *analysis.Pass, sel *ast.SelectorExpr) (recv, method string) {
+	fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok {
+		return "", ""
+	}
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return "", ""
+	}
+	t := sig.Recv().Type()
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return "", ""
+	}
+	switch named.Obj().Pkg().Path() {
+	case gocqlxPath, qbPath:
+		return named.Obj().Name(), fn.Name()
+	}
+	return "", ""
+}
+
+// checkSelectDest reports if dest is not a pointer to slice, destinations of
+// interface types are not checked.
+func checkSelectDest(pass *analysis.Pass, dest ast.Expr) {
+	t := pass.TypesInfo.TypeOf(dest)
+	if t == nil {
+		return
+	}
+	if _, ok := t.Underlying().(*types.Interface); ok {
+		return
+	}
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		if _, ok := p.Elem().Underlying().(*types.Slice); ok {
+			return
+		}
+	}
+	pass.Reportf(dest.Pos(), "Select destination must be a pointer to slice, got %s", t)
+}
+
+// isFreshQuery returns true if x is a call chain creating a query i.e.
+// session.Query(stmt, names).BindStruct(v), the query can not be released
+// afterwards.
+func isFreshQuery(pass *analysis.Pass, x ast.Expr) bool {
+	for {
+		call, ok := ast.Unparen(x).(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if recv, _ := methodOf(pass, sel); recv != "Queryx" {
+			return true
+		}
+		x = sel.X
+	}
+}
+
+// checkTags reports exported fields without db tags of a struct that has
+// fields with db tags.
+func checkTags(pass *analysis.Pass, arg ast.Expr) {
+	t := pass.TypesInfo.TypeOf(arg)
+	if t == nil {
+		return
+	}
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	s, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+
+	var (
+		tagged   bool
+		untagged []string
+	)
+	for i := 0; i < s.NumFields(); i++ {
+		f := s.Field(i)
+		if !f.Exported() || f.Anonymous() {
+			continue
+		}
+		if _, ok := reflect.StructTag(s.Tag(i)).Lookup("db"); ok {
+			tagged = true
+		} else {
+			untagged = append(untagged, f.Name())
+		}
+	}
+	if tagged && len(untagged) > 0 {
+		pass.Reportf(arg.Pos(), "fields %s of %s have no db tag while other fields have", strings.Join(untagged, ", "), t)
+	}
+}
+
+// allowFilteringLines returns lines where ALLOW FILTERING is allowed by file.
+func allowFilteringLines(pass *analysis.Pass) map[string]map[int]bool {
+	lines := make(map[string]map[int]bool)
+	for _, f := range pass.Files {
+		for _, cg := range f.Comments {
+			for _, c := range cg.List {
+				if !strings.HasPrefix(c.Text, AllowFilteringDirective) {
+					continue
+				}
+				p := pass.Fset.Position(c.Pos())
+				if lines[p.Filename] == nil {
+					lines[p.Filename] = make(map[int]bool)
+				}
+				lines[p.Filename][p.Line] = true
+				lines[p.Filename][p.Line+1] = true
+			}
+		}
+	}
+	return lines
+}
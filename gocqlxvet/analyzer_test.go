@@ -0,0 +1,16 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlxvet_test
+
+import (
+	"testing"
+
+	"github.com/scylladb/gocqlx/v2/gocqlxvet"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), gocqlxvet.Analyzer, "a")
+}
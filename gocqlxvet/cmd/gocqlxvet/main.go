@@ -0,0 +1,16 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+// Command gocqlxvet runs the gocqlxvet analyzer, it's meant to be used as
+// a go vet tool, go vet -vettool=$(which gocqlxvet) ./...
+package main
+
+import (
+	"github.com/scylladb/gocqlx/v2/gocqlxvet"
+	"golang.org/x/tools/go/analysis/unitchecker"
+)
+
+func main() {
+	unitchecker.Main(gocqlxvet.Analyzer)
+}
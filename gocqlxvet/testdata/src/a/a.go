@@ -0,0 +1,50 @@
+package a
+
+import (
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+)
+
+type Person struct {
+	FirstName string `db:"first_name"`
+	LastName  string
+	Email     []string `db:"email"`
+}
+
+type Plain struct {
+	FirstName string
+	LastName  string
+}
+
+func selects(s gocqlx.Session, dest interface{}) {
+	var people []Person
+	q := s.Query("", nil)
+	defer q.Release()
+
+	_ = q.Select(&people)
+	_ = q.Select(people)           // want `Select destination must be a pointer to slice, got \[\]a.Person`
+	_ = q.Iter().Select(&Person{}) // want `Select destination must be a pointer to slice, got \*a.Person`
+	_ = q.Select(dest)
+}
+
+func releases(s gocqlx.Session, p Person) {
+	_ = s.Query("", nil).Exec()                 // want `query is never released, use ExecRelease`
+	_ = s.Query("", nil).BindStruct(&p).Get(&p) // want `query is never released, use GetRelease` `fields LastName of a.Person have no db tag while other fields have`
+	_ = s.Query("", nil).ExecRelease()
+
+	q := s.Query("", nil)
+	defer q.Release()
+	_ = q.BindStruct(Plain{}).Exec()
+}
+
+func batch(b *gocqlx.Batchx, p *Person) {
+	b.BindStruct("", nil, p) // want `fields LastName of a.Person have no db tag while other fields have`
+}
+
+func filtering() {
+	qb.Select("t").AllowFiltering() // want `ALLOW FILTERING without //gocqlx:allow-filtering comment`
+
+	//gocqlx:allow-filtering
+	qb.Select("t").AllowFiltering()
+	qb.Select("t").AllowFiltering() //gocqlx:allow-filtering
+}
@@ -0,0 +1,26 @@
+// Package gocqlx is a stub of gocqlx for analyzer tests.
+package gocqlx
+
+type Session struct{}
+
+func (s Session) Query(stmt string, names []string) *Queryx { return &Queryx{} }
+
+type Queryx struct{}
+
+func (q *Queryx) BindStruct(arg interface{}) *Queryx                                  { return q }
+func (q *Queryx) BindStructMap(arg0 interface{}, arg1 map[string]interface{}) *Queryx { return q }
+func (q *Queryx) Exec() error                                                         { return nil }
+func (q *Queryx) ExecRelease() error                                                  { return nil }
+func (q *Queryx) Get(dest interface{}) error                                          { return nil }
+func (q *Queryx) Select(dest interface{}) error                                       { return nil }
+func (q *Queryx) SelectRelease(dest interface{}) error                                { return nil }
+func (q *Queryx) Release()                                                            {}
+func (q *Queryx) Iter() *Iterx                                                        { return &Iterx{} }
+
+type Iterx struct{}
+
+func (iter *Iterx) Select(dest interface{}) error { return nil }
+
+type Batchx struct{}
+
+func (b *Batchx) BindStruct(stmt string, names []string, arg interface{}) *Batchx { return b }
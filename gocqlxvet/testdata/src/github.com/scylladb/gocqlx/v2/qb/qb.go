@@ -0,0 +1,8 @@
+// Package qb is a stub of qb for analyzer tests.
+package qb
+
+type SelectBuilder struct{}
+
+func Select(table string) *SelectBuilder { return &SelectBuilder{} }
+
+func (b *SelectBuilder) AllowFiltering() *SelectBuilder { return b }
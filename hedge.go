@@ -0,0 +1,82 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// HedgeStats counts hedged reads, see Queryx.Hedge. It's safe for concurrent
+// use and can be shared by many queries.
+type HedgeStats struct {
+	hedged int64
+	wins   int64
+}
+
+// Hedged returns the number of query executions that sent a hedge request.
+func (s *HedgeStats) Hedged() int64 {
+	return atomic.LoadInt64(&s.hedged)
+}
+
+// Wins returns the number of query executions where the hedge request
+// returned first.
+func (s *HedgeStats) Wins() int64 {
+	return atomic.LoadInt64(&s.wins)
+}
+
+// Hedge enables hedged reads. If the query does not complete within delay
+// a duplicate request is sent to the next host from the host selection policy
+// and the first response is used. The query is marked idempotent, it's meant
+// for single key reads.
+//
+// If stats is not nil the query observer is set to record hedged requests in
+// stats, it replaces any observer set on the query. A query with stats must
+// not be executed concurrently.
+func (q *Queryx) Hedge(delay time.Duration, stats *HedgeStats) *Queryx {
+	q.SetSpeculativeExecutionPolicy(&gocql.SimpleSpeculativeExecution{
+		NumAttempts:  1,
+		TimeoutDelay: delay,
+	})
+	q.Idempotent(true)
+	if stats != nil {
+		q.Observer(&hedgeObserver{stats: stats})
+	}
+	return q
+}
+
+// hedgeObserver pairs overlapping attempts of a query execution, two
+// overlapping attempts mean that a hedge request was sent.
+type hedgeObserver struct {
+	stats *HedgeStats
+
+	mu   sync.Mutex
+	prev *gocql.ObservedQuery
+}
+
+func (o *hedgeObserver) ObserveQuery(ctx context.Context, q gocql.ObservedQuery) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	p := o.prev
+	if p == nil || !q.Start.Before(p.End) || !p.Start.Before(q.End) {
+		o.prev = &q
+		return
+	}
+	o.prev = nil
+
+	atomic.AddInt64(&o.stats.hedged, 1)
+	main, hedge := p, &q
+	if hedge.Start.Before(main.Start) {
+		main, hedge = hedge, main
+	}
+	if hedge.Err == nil && hedge.End.Before(main.End) {
+		atomic.AddInt64(&o.stats.wins, 1)
+	}
+}
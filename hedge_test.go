@@ -0,0 +1,46 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+func TestHedgeObserver(t *testing.T) {
+	t0 := time.Now()
+	at := func(ms int) time.Time {
+		return t0.Add(time.Duration(ms) * time.Millisecond)
+	}
+
+	var s HedgeStats
+	o := &hedgeObserver{stats: &s}
+	observe := func(start, end int, err error) {
+		o.ObserveQuery(context.Background(), gocql.ObservedQuery{Start: at(start), End: at(end), Err: err})
+	}
+
+	// No hedge
+	observe(0, 5, nil)
+	observe(10, 15, nil)
+	// Main wins
+	observe(20, 40, nil)
+	observe(30, 41, context.Canceled)
+	// Hedge wins
+	observe(55, 65, nil)
+	observe(50, 66, context.Canceled)
+	// Hedge fails first
+	observe(70, 75, gocql.ErrTimeoutNoResponse)
+	observe(60, 80, nil)
+
+	if s.Hedged() != 3 {
+		t.Fatalf("Hedged()=%d, expected 3", s.Hedged())
+	}
+	if s.Wins() != 1 {
+		t.Fatalf("Wins()=%d, expected 1", s.Wins())
+	}
+}
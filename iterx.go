@@ -5,9 +5,11 @@
 package gocqlx
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/gocql/gocql"
 	"github.com/scylladb/go-reflectx"
@@ -22,14 +24,50 @@ type Iterx struct {
 	*gocql.Iter
 	Mapper *reflectx.Mapper
 
-	unsafe     bool
-	structOnly bool
-	applied    bool
-	err        error
+	unsafe         bool
+	structOnly     bool
+	rowErrorPolicy RowErrorPolicy
+	applied        bool
+	err            error
+
+	// Row scan errors handled according to rowErrorPolicy, deliver is set by
+	// streaming APIs.
+	row     int
+	rowErr  error
+	rowErrs RowErrors
+	deliver func(err *RowError) bool
+
+	// Fetches pages when Queryx.PageTimeout or Queryx.MaxPages is set.
+	pager *pager
+
+	// Limits set with Queryx.MaxRows and Queryx.MaxPages, pages counts pages
+	// fetched after the first one.
+	maxRows  int
+	maxPages int
+	rows     int
+	pages    int
+
+	// Stops iteration when done, see WithContext.
+	ctx context.Context
+
+	// Applied to scanned time values, see Session.Location.
+	location *time.Location
+
+	// Sorts results of Select, see Queryx.SortBy.
+	sortBy []sortKey
+
+	// Drops rows with repeated field values, see SelectDistinctBy.
+	distinct *distinctBy
+
+	// Replaces column values of rows encoded by Reader, see Mask.
+	mask func(column string, v interface{}) interface{}
 
 	// Cache memory for a rows during iteration in structScan.
-	fields [][]int
-	values []interface{}
+	fields      [][]int
+	compressors []compressedValue
+	values      []interface{}
+	scanValues  []scanValue
+	scanDest    []interface{}
 }
 
 // Unsafe forces the iterator to ignore missing fields. By default when scanning
@@ -48,6 +86,41 @@ func (iter *Iterx) StructOnly() *Iterx {
 	return iter
 }
 
+// WithContext ties the iteration to ctx, i.e. the context of an
+// http.Request or a gRPC stream. When ctx is done the iteration stops before
+// the next row and Close returns ctx.Err(), so no more pages are fetched
+// after a client goes away. The query context still controls the requests
+// sent to the database, use Session.ContextQuery to cancel a page fetch in
+// progress.
+func (iter *Iterx) WithContext(ctx context.Context) *Iterx {
+	iter.ctx = ctx
+	return iter
+}
+
+// Mask sets fn replacing column values of rows encoded by Reader, fn is
+// called for every column of every row, null values are nil. It's meant for
+// masking sensitive data in exports, see table.Metadata.PIIMask.
+func (iter *Iterx) Mask(fn func(column string, v interface{}) interface{}) *Iterx {
+	iter.mask = fn
+	return iter
+}
+
+// ContinueOnError makes struct scans skip rows that fail to scan instead of
+// stopping the iteration. Errors of skipped rows are returned by Close as
+// RowErrors, Select returns the rows that were scanned successfully along
+// with the RowErrors error. It's a shorthand for
+// ErrorPolicy(CollectRowErrors).
+func (iter *Iterx) ContinueOnError() *Iterx {
+	return iter.ErrorPolicy(CollectRowErrors)
+}
+
+// ErrorPolicy sets how struct scans handle rows that fail to scan, the
+// default is StopOnRowError.
+func (iter *Iterx) ErrorPolicy(p RowErrorPolicy) *Iterx {
+	iter.rowErrorPolicy = p
+	return iter
+}
+
 // Get scans first row into a destination and closes the iterator.
 //
 // If the destination type is a struct pointer, then StructScan will be
@@ -81,19 +154,9 @@ func (iter *Iterx) scanAny(dest interface{}) bool {
 	}
 
 	base := reflectx.Deref(value.Type())
-	scannable := iter.isScannable(base)
-
-	if iter.structOnly && scannable {
-		if base.Kind() == reflect.Struct {
-			scannable = false
-		} else {
-			iter.err = structOnlyError(base)
-			return false
-		}
-	}
-
-	if scannable && len(iter.Columns()) > 1 {
-		iter.err = fmt.Errorf("expected 1 column in result while scanning scannable type %s but got %d", base.Kind(), len(iter.Columns()))
+	scannable, err := iter.checkScannable(base)
+	if err != nil {
+		iter.err = err
 		return false
 	}
 
@@ -122,6 +185,9 @@ func (iter *Iterx) Select(dest interface{}) error {
 	iter.scanAll(dest)
 	iter.Close()
 
+	if iter.err == nil && len(iter.sortBy) > 0 {
+		iter.err = sortSlice(dest, iter.sortBy, iter.Mapper)
+	}
 	return iter.err
 }
 
@@ -146,20 +212,9 @@ func (iter *Iterx) scanAll(dest interface{}) bool {
 
 	isPtr := slice.Elem().Kind() == reflect.Ptr
 	base := reflectx.Deref(slice.Elem())
-	scannable := iter.isScannable(base)
-
-	if iter.structOnly && scannable {
-		if base.Kind() == reflect.Struct {
-			scannable = false
-		} else {
-			iter.err = structOnlyError(base)
-			return false
-		}
-	}
-
-	// if it's a base type make sure it only has 1 column;  if not return an error
-	if scannable && len(iter.Columns()) > 1 {
-		iter.err = fmt.Errorf("expected 1 column in result while scanning scannable type %s but got %d", base.Kind(), len(iter.Columns()))
+	scannable, err := iter.checkScannable(base)
+	if err != nil {
+		iter.err = err
 		return false
 	}
 
@@ -182,6 +237,9 @@ func (iter *Iterx) scanAll(dest interface{}) bool {
 		if !ok {
 			break
 		}
+		if iter.distinct != nil && !iter.distinct.first(vp) {
+			continue
+		}
 
 		// allocate memory for the page data
 		if !alloc {
@@ -204,6 +262,26 @@ func (iter *Iterx) scanAll(dest interface{}) bool {
 	return true
 }
 
+// checkScannable returns whether t is scannable taking StructOnly into
+// account, and if so makes sure the result has only one column.
+func (iter *Iterx) checkScannable(t reflect.Type) (bool, error) {
+	scannable := iter.isScannable(t)
+
+	if iter.structOnly && scannable {
+		if t.Kind() != reflect.Struct {
+			return false, structOnlyError(t)
+		}
+		scannable = false
+	}
+
+	// if it's a base type make sure it only has 1 column;  if not return an error
+	if scannable && len(iter.Columns()) > 1 {
+		return false, fmt.Errorf("expected 1 column in result while scanning scannable type %s but got %d", t.Kind(), len(iter.Columns()))
+	}
+
+	return scannable, nil
+}
+
 // isScannable takes the reflect.Type and the actual dest value and returns
 // whether or not it's Scannable. t is scannable if:
 //   * ptr to t implements gocql.Unmarshaler, gocql.UDTUnmarshaler or UDT
@@ -229,7 +307,11 @@ func (iter *Iterx) scan(value reflect.Value) bool {
 	if value.Kind() != reflect.Ptr {
 		panic("value must be a pointer")
 	}
-	return iter.Iter.Scan(udtWrapValue(value, iter.Mapper, iter.unsafe))
+	if !iter.scanRow(locationWrapValue(udtWrapValue(value, iter.Mapper, iter.unsafe), iter.location)) {
+		return false
+	}
+	iter.row++
+	return true
 }
 
 // StructScan is like gocql.Iter.Scan, but scans a single row into a single
@@ -261,30 +343,83 @@ func (iter *Iterx) structScan(value reflect.Value) bool {
 	}
 
 	if iter.fields == nil {
-		columns := columnNames(iter.Iter.Columns())
-		cas := len(columns) > 0 && columns[0] == appliedColumn
-
-		iter.fields = iter.Mapper.TraversalsByName(value.Type(), columns)
-		// if we are not unsafe and it's not CAS query and are missing fields, return an error
-		if !iter.unsafe && !cas {
-			if f, err := missingFields(iter.fields); err != nil {
-				iter.err = fmt.Errorf("missing destination name %q in %s", columns[f], reflect.Indirect(value).Type())
+		if err := iter.initStructScan(value, columnNames(iter.Iter.Columns())); err != nil {
+			iter.err = err
+			return false
+		}
+	}
+	if err := iter.bindStructScan(value); err != nil {
+		iter.err = err
+		return false
+	}
+
+	for {
+		// scan into the struct field pointers and append to our results
+		if !iter.scanRow(iter.scanDest...) {
+			return false
+		}
+		iter.row++
+		if iter.rowErr == nil {
+			return true
+		}
+		rowErr := RowError{Row: iter.row - 1, Err: iter.rowErr}
+		iter.rowErr = nil
+		if iter.deliver != nil {
+			if !iter.deliver(&rowErr) {
 				return false
 			}
+			continue
 		}
-		iter.values = make([]interface{}, len(columns))
-		if cas {
-			iter.values[0] = &iter.applied
-		}
+		iter.rowErrs = append(iter.rowErrs, rowErr)
 	}
+}
 
-	if err := iter.fieldsByTraversal(value, iter.fields, iter.values); err != nil {
-		iter.err = err
-		return false
+// initStructScan maps columns to fields of the struct type value points to
+// and prepares scan destinations.
+func (iter *Iterx) initStructScan(value reflect.Value, columns []string) error {
+	cas := len(columns) > 0 && columns[0] == appliedColumn
+
+	iter.fields = iter.Mapper.TraversalsByName(value.Type(), columns)
+	// if we are not unsafe and it's not CAS query and are missing fields, return an error
+	if !iter.unsafe && !cas {
+		if f, err := missingFields(iter.fields); err != nil {
+			return &ScanError{
+				Column: columns[f],
+				GoType: reflect.Indirect(value).Type(),
+				Err:    ErrMissingDestination,
+			}
+		}
 	}
+	iter.compressors = fieldCompressors(iter.Mapper, value.Type(), iter.fields)
+	iter.values = make([]interface{}, len(columns))
+	iter.scanValues = make([]scanValue, len(columns))
+	iter.scanDest = make([]interface{}, len(columns))
+	for i, t := range iter.fields {
+		if len(t) == 0 {
+			continue
+		}
+		iter.scanValues[i].iter = iter
+		iter.scanValues[i].column = columns[i]
+		iter.scanValues[i].field, iter.scanValues[i].goType = fieldPath(value.Type(), t)
+		iter.scanDest[i] = &iter.scanValues[i]
+	}
+	if cas {
+		iter.values[0] = &iter.applied
+		iter.scanDest[0] = &iter.applied
+	}
+	return nil
+}
 
-	// scan into the struct field pointers and append to our results
-	return iter.Iter.Scan(iter.values...)
+// bindStructScan points scan destinations to fields of the struct value
+// points to.
+func (iter *Iterx) bindStructScan(value reflect.Value) error {
+	if err := iter.fieldsByTraversal(value, iter.fields, iter.compressors, iter.values); err != nil {
+		return err
+	}
+	for i := range iter.scanValues {
+		iter.scanValues[i].dest = iter.values[i]
+	}
+	return nil
 }
 
 // fieldsByName fills a values interface with fields from the passed value based
@@ -292,7 +427,8 @@ func (iter *Iterx) structScan(value reflect.Value) bool {
 // We write this instead of using FieldsByName to save allocations and map
 // lookups when iterating over many rows.
 // Empty traversals will get an interface pointer.
-func (iter *Iterx) fieldsByTraversal(value reflect.Value, traversals [][]int, values []interface{}) error {
+// Fields with a compressor are decompressed, compressors may be nil.
+func (iter *Iterx) fieldsByTraversal(value reflect.Value, traversals [][]int, compressors []compressedValue, values []interface{}) error {
 	value = reflect.Indirect(value)
 	if value.Kind() != reflect.Struct {
 		return fmt.Errorf("expected a struct but got %s", value.Type())
@@ -303,7 +439,12 @@ func (iter *Iterx) fieldsByTraversal(value reflect.Value, traversals [][]int, va
 			continue
 		}
 		f := reflectx.FieldByIndexes(value, traversal).Addr()
-		values[i] = udtWrapValue(f, iter.Mapper, iter.unsafe)
+		values[i] = locationWrapValue(udtWrapValue(f, iter.Mapper, iter.unsafe), iter.location)
+		if compressors != nil && compressors[i].compressor != nil {
+			c := compressors[i]
+			c.value = values[i]
+			values[i] = c
+		}
 	}
 
 	return nil
@@ -326,16 +467,22 @@ func columnNames(ci []gocql.ColumnInfo) []string {
 // end of the result set was reached or if an error occurred. Close should
 // be called afterwards to retrieve any potential errors.
 func (iter *Iterx) Scan(dest ...interface{}) bool {
-	return iter.Iter.Scan(udtWrapSlice(iter.Mapper, iter.unsafe, dest)...)
+	return iter.scanRow(locationWrapSlice(udtWrapSlice(iter.Mapper, iter.unsafe, dest), iter.location)...)
 }
 
 // Close closes the iterator and returns any errors that happened during
 // the query or the iteration.
 func (iter *Iterx) Close() error {
 	err := iter.Iter.Close()
+	if iter.pager != nil {
+		iter.pager.close()
+	}
 	if iter.err == nil {
 		iter.err = err
 	}
+	if iter.err == nil && len(iter.rowErrs) > 0 {
+		iter.err = iter.rowErrs
+	}
 	return iter.err
 }
 
@@ -7,6 +7,9 @@
 package gocqlx_test
 
 import (
+	"context"
+	"errors"
+	"io/ioutil"
 	"math/big"
 	"strings"
 	"testing"
@@ -323,6 +326,10 @@ func TestIterxStructOnly(t *testing.T) {
 		if err == nil || !strings.HasPrefix(err.Error(), golden) {
 			t.Fatalf("Get() error=%q expected %s", err, golden)
 		}
+		var scanErr *gocqlx.ScanError
+		if !errors.As(err, &scanErr) || scanErr.Column != "testtextunbound" || scanErr.Err != gocqlx.ErrMissingDestination {
+			t.Fatalf("Get() error=%#v expected ScanError", err)
+		}
 	})
 
 	t.Run("select error", func(t *testing.T) {
@@ -689,3 +696,477 @@ func TestIterxCAS(t *testing.T) {
 		t.Error("GetCAS()=%=v expected to have pre-image", john)
 	}
 }
+
+func TestBatchxCAS(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.batch_cas_table (pk int, ck int, salary int, PRIMARY KEY (pk, ck))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	insert := qb.Insert("batch_cas_table").Columns("pk", "ck", "salary").Unique()
+	stmt, names := insert.ToCql()
+
+	newBatch := func() *gocqlx.Batchx {
+		return session.NewBatch(gocql.LoggedBatch).
+			BindMap(stmt, names, qb.M{"pk": 0, "ck": 0, "salary": 1000}).
+			BindMap(stmt, names, qb.M{"pk": 0, "ck": 1, "salary": 2000})
+	}
+
+	applied, err := newBatch().ExecCAS()
+	if err != nil {
+		t.Fatal("ExecCAS() failed:", err)
+	}
+	if !applied {
+		t.Error("ExecCAS() expected first batch to be applied")
+	}
+
+	applied, err = newBatch().ExecCAS()
+	if err != nil {
+		t.Fatal("ExecCAS() failed:", err)
+	}
+	if applied {
+		t.Error("ExecCAS() expected second batch to not be applied")
+	}
+}
+
+// strictName fails to unmarshal "bad" values.
+type strictName string
+
+func (n *strictName) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	if string(data) == "bad" {
+		return errors.New("bad name")
+	}
+	*n = strictName(data)
+	return nil
+}
+
+func TestIterxContinueOnError(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.continue_on_error_table (pk int, ck int, name text, PRIMARY KEY (pk, ck))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	for i, name := range []string{"a", "bad", "c", "bad"} {
+		if err := session.Query(`INSERT INTO continue_on_error_table (pk, ck, name) VALUES (0, ?, ?)`, nil).Bind(i, name).Exec(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	type Row struct {
+		Ck   int
+		Name strictName
+	}
+	const stmt = `SELECT ck, name FROM continue_on_error_table WHERE pk = 0`
+
+	var rows []Row
+	err := session.Query(stmt, nil).Iter().ContinueOnError().Select(&rows)
+
+	var rowErrs gocqlx.RowErrors
+	if !errors.As(err, &rowErrs) {
+		t.Fatalf("Select() error=%v, expected RowErrors", err)
+	}
+	if len(rowErrs) != 2 || rowErrs[0].Row != 1 || rowErrs[1].Row != 3 {
+		t.Fatalf("Select() error=%v", err)
+	}
+	var scanErr *gocqlx.ScanError
+	if !errors.As(rowErrs[0].Err, &scanErr) || scanErr.Column != "name" {
+		t.Fatalf("unexpected row error %v", rowErrs[0].Err)
+	}
+	if diff := cmp.Diff([]Row{{Ck: 0, Name: "a"}, {Ck: 2, Name: "c"}}, rows); diff != "" {
+		t.Fatal(diff)
+	}
+
+	rows = nil
+	if err := session.Query(stmt, nil).Select(&rows); err == nil {
+		t.Fatal("Select() expected error")
+	}
+}
+
+func TestIterxStreamRowErrors(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.stream_row_errors_table (pk int, ck int, name text, PRIMARY KEY (pk, ck))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	for i, name := range []string{"a", "bad", "c", "bad"} {
+		if err := session.Query(`INSERT INTO stream_row_errors_table (pk, ck, name) VALUES (0, ?, ?)`, nil).Bind(i, name).Exec(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	type Row struct {
+		Ck   int
+		Name strictName
+	}
+	const stmt = `SELECT ck, name FROM stream_row_errors_table WHERE pk = 0`
+
+	t.Run("for each", func(t *testing.T) {
+		var (
+			row    Row
+			rows   []Row
+			errRow []int
+		)
+		err := session.Query(stmt, nil).Iter().ErrorPolicy(gocqlx.DeliverRowErrors).ForEach(&row, func(err error) bool {
+			if err != nil {
+				var rowErr *gocqlx.RowError
+				if !errors.As(err, &rowErr) {
+					t.Fatalf("ForEach() error=%v, expected RowError", err)
+				}
+				errRow = append(errRow, rowErr.Row)
+				return true
+			}
+			rows = append(rows, row)
+			return true
+		})
+		if err != nil {
+			t.Fatal("ForEach() error:", err)
+		}
+		if diff := cmp.Diff([]Row{{Ck: 0, Name: "a"}, {Ck: 2, Name: "c"}}, rows); diff != "" {
+			t.Fatal(diff)
+		}
+		if diff := cmp.Diff([]int{1, 3}, errRow); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("for each stop", func(t *testing.T) {
+		var (
+			row   Row
+			calls int
+		)
+		err := session.Query(stmt, nil).Iter().ErrorPolicy(gocqlx.DeliverRowErrors).ForEach(&row, func(err error) bool {
+			calls++
+			return err == nil
+		})
+		if err != nil {
+			t.Fatal("ForEach() error:", err)
+		}
+		if calls != 2 {
+			t.Fatalf("ForEach() calls=%d, expected 2", calls)
+		}
+	})
+
+	t.Run("for each default policy", func(t *testing.T) {
+		var row Row
+		err := session.Query(stmt, nil).Iter().ForEach(&row, func(err error) bool {
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			return true
+		})
+		var scanErr *gocqlx.ScanError
+		if !errors.As(err, &scanErr) {
+			t.Fatalf("ForEach() error=%v, expected ScanError", err)
+		}
+	})
+
+	t.Run("select chan", func(t *testing.T) {
+		var (
+			rows   []Row
+			errRow []int
+		)
+		for r := range session.Query(stmt, nil).Iter().ErrorPolicy(gocqlx.DeliverRowErrors).SelectChan(context.Background(), &Row{}) {
+			if r.Err != nil {
+				errRow = append(errRow, r.Row)
+				continue
+			}
+			rows = append(rows, *r.Value.(*Row))
+		}
+		if diff := cmp.Diff([]Row{{Ck: 0, Name: "a"}, {Ck: 2, Name: "c"}}, rows); diff != "" {
+			t.Fatal(diff)
+		}
+		if diff := cmp.Diff([]int{1, 3}, errRow); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("select chan cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := session.Query(stmt, nil).Iter().SelectChan(ctx, &Row{})
+		r := <-ch
+		if r.Err != nil || r.Value.(*Row).Ck != 0 {
+			t.Fatalf("SelectChan() first result %+v", r)
+		}
+		cancel()
+		for range ch {
+		}
+	})
+}
+
+func TestIterxReader(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.reader_table (pk int, ck int, name text, pair frozen<tuple<int, text>>, score double, PRIMARY KEY (pk, ck))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.ExecStmt(`INSERT INTO gocqlx_test.reader_table (pk, ck, name, pair, score) VALUES (0, 0, 'a', (1, 'x'), NaN)`); err != nil {
+		t.Fatal("insert:", err)
+	}
+	if err := session.ExecStmt(`INSERT INTO gocqlx_test.reader_table (pk, ck, pair) VALUES (0, 1, (2, 'y'))`); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	const stmt = `SELECT ck, name, pair, score FROM reader_table WHERE pk = 0`
+
+	r := session.Query(stmt, nil).Iter().Reader(gocqlx.JSONLines)
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal("ReadAll() error:", err)
+	}
+	if diff := cmp.Diff("{\"ck\":0,\"name\":\"a\",\"pair\":[1,\"x\"],\"score\":null}\n{\"ck\":1,\"name\":null,\"pair\":[2,\"y\"],\"score\":null}\n", string(b)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	r = session.Query(stmt, nil).Iter().Reader(gocqlx.CSV)
+	b, err = ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal("ReadAll() error:", err)
+	}
+	if diff := cmp.Diff("ck,name,pair,score\n0,a,\"[1,\"\"x\"\"]\",NaN\n1,,\"[2,\"\"y\"\"]\",\n", string(b)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	mask := func(column string, v interface{}) interface{} {
+		if column == "name" {
+			return "[REDACTED]"
+		}
+		return v
+	}
+	r = session.Query(stmt, nil).Iter().Mask(mask).Reader(gocqlx.CSV)
+	b, err = ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal("ReadAll() error:", err)
+	}
+	if diff := cmp.Diff("ck,name,pair,score\n0,[REDACTED],\"[1,\"\"x\"\"]\",NaN\n1,[REDACTED],\"[2,\"\"y\"\"]\",\n", string(b)); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestIterxPageTimeout(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.page_timeout_table (pk int, ck int, PRIMARY KEY (pk, ck))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := session.Query(`INSERT INTO page_timeout_table (pk, ck) VALUES (0, ?)`, nil).Bind(i).Exec(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	const stmt = `SELECT ck FROM page_timeout_table WHERE pk = 0`
+
+	t.Run("all pages", func(t *testing.T) {
+		var v []int
+		if err := session.Query(stmt, nil).PageSize(2).PageTimeout(time.Second).Select(&v); err != nil {
+			t.Fatal("Select() error:", err)
+		}
+		if diff := cmp.Diff([]int{0, 1, 2, 3, 4}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("single page", func(t *testing.T) {
+		var v []int
+		if err := session.Query(stmt, nil).PageSize(2).PageState(nil).PageTimeout(time.Second).Select(&v); err != nil {
+			t.Fatal("Select() error:", err)
+		}
+		if diff := cmp.Diff([]int{0, 1}, v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("query deadline", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var v []int
+		err := session.ContextQuery(ctx, stmt, nil).PageSize(2).PageTimeout(time.Second).Select(&v)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Select() error=%v, expected context.Canceled", err)
+		}
+	})
+}
+
+func TestIterxLimits(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.limits_table (pk int, ck int, PRIMARY KEY (pk, ck))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := session.Query(`INSERT INTO limits_table (pk, ck) VALUES (0, ?)`, nil).Bind(i).Exec(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	const stmt = `SELECT ck FROM limits_table WHERE pk = 0`
+
+	table := []struct {
+		Name  string
+		Query func() *gocqlx.Queryx
+		V     []int
+		Limit string
+	}{
+		{
+			Name:  "max rows",
+			Query: func() *gocqlx.Queryx { return session.Query(stmt, nil).MaxRows(3) },
+			V:     []int{0, 1, 2},
+			Limit: "rows",
+		},
+		{
+			Name:  "max rows not exceeded",
+			Query: func() *gocqlx.Queryx { return session.Query(stmt, nil).MaxRows(5) },
+			V:     []int{0, 1, 2, 3, 4},
+		},
+		{
+			Name:  "max pages",
+			Query: func() *gocqlx.Queryx { return session.Query(stmt, nil).PageSize(2).MaxPages(2) },
+			V:     []int{0, 1, 2, 3},
+			Limit: "pages",
+		},
+		{
+			Name:  "max pages with page timeout",
+			Query: func() *gocqlx.Queryx { return session.Query(stmt, nil).PageSize(2).PageTimeout(time.Second).MaxPages(2) },
+			V:     []int{0, 1, 2, 3},
+			Limit: "pages",
+		},
+		{
+			Name:  "max pages not exceeded",
+			Query: func() *gocqlx.Queryx { return session.Query(stmt, nil).PageSize(2).MaxPages(3) },
+			V:     []int{0, 1, 2, 3, 4},
+		},
+	}
+
+	for i := range table {
+		test := table[i]
+		t.Run(test.Name, func(t *testing.T) {
+			var v []int
+			err := test.Query().Select(&v)
+
+			var limitErr *gocqlx.LimitExceededError
+			if test.Limit == "" {
+				if err != nil {
+					t.Fatal("Select() error:", err)
+				}
+			} else if !errors.As(err, &limitErr) || limitErr.Limit != test.Limit {
+				t.Fatalf("Select() error=%v, expected %s limit error", err, test.Limit)
+			}
+			if diff := cmp.Diff(test.V, v); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestIterxSelectDistinctBy(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.distinct_table (pk int, ck int, category text, PRIMARY KEY (pk, ck))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	for i, c := range []string{"a", "b", "a", "c", "b"} {
+		if err := session.Query(`INSERT INTO distinct_table (pk, ck, category) VALUES (0, ?, ?)`, nil).Bind(i, c).Exec(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	type row struct {
+		Ck       int
+		Category string
+	}
+	var v []row
+	if err := session.Query(`SELECT ck, category FROM distinct_table WHERE pk = 0`, nil).PageSize(2).SelectDistinctBy(&v, "category"); err != nil {
+		t.Fatal("SelectDistinctBy() error:", err)
+	}
+	expected := []row{{0, "a"}, {1, "b"}, {3, "c"}}
+	if diff := cmp.Diff(expected, v); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if err := session.Query(`SELECT ck, category FROM distinct_table WHERE pk = 0`, nil).SelectDistinctBy(&v, "not_found"); err == nil {
+		t.Fatal("SelectDistinctBy() expected error")
+	}
+}
+
+func TestQueryxSelectPage(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.select_page (pk int, ck int, PRIMARY KEY (pk, ck))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := session.Query(`INSERT INTO select_page (pk, ck) VALUES (0, ?)`, nil).Bind(i).Exec(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	var (
+		state []byte
+		all   []int
+		pages int
+	)
+	for {
+		var v []int
+		q := session.Query(`SELECT ck FROM select_page WHERE pk = 0`, nil).PageSize(2)
+		if state != nil {
+			q.PageState(state)
+		}
+		p, err := q.SelectPage(&v)
+		if err != nil {
+			t.Fatal("SelectPage() error:", err)
+		}
+		pages++
+		if p.Rows != len(v) || p.Attempts == 0 {
+			t.Fatalf("unexpected page stats %+v", p)
+		}
+		all = append(all, v...)
+		if !p.More {
+			break
+		}
+		state = p.NextPageState
+	}
+
+	if diff := cmp.Diff([]int{0, 1, 2, 3, 4}, all); diff != "" {
+		t.Fatal(diff)
+	}
+	if pages != 3 {
+		t.Fatalf("fetched %d pages, expected 3", pages)
+	}
+}
+
+func TestQueryxUsingTimeout(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.CheckUsingTimeout(context.Background()); err != nil {
+		t.Skip(err)
+	}
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.using_timeout (pk int, ck int, PRIMARY KEY (pk, ck))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	insert := qb.Insert("gocqlx_test.using_timeout").Columns("pk", "ck").Timeout(time.Second)
+	if err := session.Query(insert.ToCql()).BindMap(qb.M{"pk": 0, "ck": 0}).ExecRelease(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	var v []int
+	stmt, names := qb.Select("gocqlx_test.using_timeout").Columns("ck").Where(qb.Eq("pk")).TimeoutNamed("timeout").ToCql()
+	if err := session.Query(stmt, names).BindMap(qb.M{"pk": 0, "timeout": gocql.Duration{Nanoseconds: int64(time.Second)}}).SelectRelease(&v); err != nil {
+		t.Fatal("select:", err)
+	}
+	if diff := cmp.Diff([]int{0}, v); diff != "" {
+		t.Fatal(diff)
+	}
+}
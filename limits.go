@@ -0,0 +1,41 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import "fmt"
+
+// LimitExceededError is returned when iteration over the query results
+// exceeds a limit set with Queryx.MaxRows or Queryx.MaxPages.
+type LimitExceededError struct {
+	// Limit is "rows" or "pages".
+	Limit string
+	Max   int
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("query exceeded limit of %d %s", e.Max, e.Limit)
+}
+
+// MaxRows limits the number of rows that can be read from the query results.
+// If there are more rows the iteration stops and LimitExceededError is
+// returned, Select returns the first n rows along with the error. Unlike
+// LIMIT in the statement it's a client side safety rail for queries that are
+// expected to return few rows. Zero means no limit.
+func (q *Queryx) MaxRows(n int) *Queryx {
+	q.maxRows = n
+	return q
+}
+
+// MaxPages limits the number of pages that can be fetched for the query.
+// If the iteration needs to fetch more pages it stops and
+// LimitExceededError is returned. Zero means no limit.
+//
+// With MaxPages pages are fetched by Iterx one by one, the next page is
+// fetched when the current page is consumed, so that no page over the limit
+// is prefetched.
+func (q *Queryx) MaxPages(n int) *Queryx {
+	q.maxPages = n
+	return q
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+var (
+	_ gocql.Marshaler   = locationValue{}
+	_ gocql.Unmarshaler = locationValue{}
+)
+
+// locationValue applies a time.Location to time.Time values of timestamp and
+// date columns. Scanned timestamps are converted to the location, scanned
+// dates are midnight in the location. Bound dates are taken from the date
+// in the location, bound timestamps are not changed as they denote an
+// instant.
+type locationValue struct {
+	v   interface{}
+	loc *time.Location
+}
+
+func (l locationValue) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+	t, ok := timeOf(l.v)
+	if !ok || t.IsZero() || info.Type() != gocql.TypeDate {
+		return gocql.Marshal(info, l.v)
+	}
+	y, m, d := t.In(l.loc).Date()
+	return gocql.Marshal(info, time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+}
+
+func (l locationValue) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	if err := gocql.Unmarshal(info, data, l.v); err != nil {
+		return err
+	}
+
+	var t *time.Time
+	switch v := l.v.(type) {
+	case *time.Time:
+		t = v
+	case **time.Time:
+		t = *v
+	}
+	if t == nil || t.IsZero() {
+		return nil
+	}
+
+	if info.Type() == gocql.TypeDate {
+		y, m, d := t.Date()
+		*t = time.Date(y, m, d, 0, 0, 0, 0, l.loc)
+	} else {
+		*t = t.In(l.loc)
+	}
+	return nil
+}
+
+func timeOf(v interface{}) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v != nil {
+			return *v, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// locationWrapValue wraps time values in locationValue if loc is set.
+func locationWrapValue(v interface{}, loc *time.Location) interface{} {
+	if loc == nil {
+		return v
+	}
+	switch v.(type) {
+	case time.Time, *time.Time, **time.Time:
+		return locationValue{v: v, loc: loc}
+	}
+	return v
+}
+
+// locationWrapSlice wraps time values in locationValue if loc is set.
+func locationWrapSlice(v []interface{}, loc *time.Location) []interface{} {
+	if loc == nil {
+		return v
+	}
+	for i := range v {
+		v[i] = locationWrapValue(v[i], loc)
+	}
+	return v
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+func TestLocationValue(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	timestampType := gocql.NewNativeType(4, gocql.TypeTimestamp, "")
+	dateType := gocql.NewNativeType(4, gocql.TypeDate, "")
+
+	t.Run("timestamp", func(t *testing.T) {
+		in := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		data, err := locationWrapValue(in, loc).(gocql.Marshaler).MarshalCQL(timestampType)
+		if err != nil {
+			t.Fatal("MarshalCQL() error:", err)
+		}
+
+		var out time.Time
+		if err := locationWrapValue(&out, loc).(gocql.Unmarshaler).UnmarshalCQL(timestampType, data); err != nil {
+			t.Fatal("UnmarshalCQL() error:", err)
+		}
+		if !out.Equal(in) || out.Location() != loc {
+			t.Fatalf("UnmarshalCQL()=%s, expected %s in %s", out, in, loc)
+		}
+	})
+
+	t.Run("date", func(t *testing.T) {
+		// 2020-01-02 in loc is 2020-01-02 in UTC.
+		in := time.Date(2020, 1, 1, 22, 0, 0, 0, loc)
+		data, err := locationWrapValue(&in, loc).(gocql.Marshaler).MarshalCQL(dateType)
+		if err != nil {
+			t.Fatal("MarshalCQL() error:", err)
+		}
+
+		var out *time.Time
+		if err := locationWrapValue(&out, loc).(gocql.Unmarshaler).UnmarshalCQL(dateType, data); err != nil {
+			t.Fatal("UnmarshalCQL() error:", err)
+		}
+		if expected := time.Date(2020, 1, 1, 0, 0, 0, 0, loc); out == nil || !out.Equal(expected) {
+			t.Fatalf("UnmarshalCQL()=%v, expected %s", out, expected)
+		}
+	})
+
+	t.Run("null", func(t *testing.T) {
+		var out *time.Time
+		if err := locationWrapValue(&out, loc).(gocql.Unmarshaler).UnmarshalCQL(timestampType, nil); err != nil {
+			t.Fatal("UnmarshalCQL() error:", err)
+		}
+		if out != nil {
+			t.Fatalf("UnmarshalCQL()=%v, expected nil", out)
+		}
+	})
+
+	t.Run("no location", func(t *testing.T) {
+		var out time.Time
+		if _, ok := locationWrapValue(&out, nil).(*time.Time); !ok {
+			t.Fatal("locationWrapValue() expected value not to be wrapped")
+		}
+	})
+}
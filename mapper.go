@@ -5,6 +5,11 @@
 package gocqlx
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
 	"github.com/scylladb/go-reflectx"
 )
 
@@ -15,3 +20,167 @@ import (
 //
 // A custom mapper can always be set per Sessionm, Query and Iter.
 var DefaultMapper = reflectx.NewMapperFunc("db", reflectx.CamelToSnakeASCII)
+
+// StructToMap returns a map of column names to values of fields of the struct
+// v. The column names are resolved using the mapper m the same way as when
+// binding or scanning a struct. If m is nil DefaultMapper is used.
+func StructToMap(m *reflectx.Mapper, v interface{}) (map[string]interface{}, error) {
+	if m == nil {
+		m = DefaultMapper
+	}
+
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, errors.New("expected a struct but got nil")
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct but got %s", value.Kind())
+	}
+
+	fields := columnFields(m.TypeMap(value.Type()))
+	r := make(map[string]interface{}, len(fields))
+	for _, fi := range fields {
+		r[fi.Name] = reflectx.FieldByIndexesReadOnly(value, fi.Index).Interface()
+	}
+	return r, nil
+}
+
+// MapToStruct sets fields of the struct pointed by dest to values from src.
+// Struct fields are matched with map keys using the mapper m, if m is nil
+// DefaultMapper is used. If a key cannot be mapped to any field or the value
+// is not assignable to the field an error is reported.
+func MapToStruct(m *reflectx.Mapper, src map[string]interface{}, dest interface{}) error {
+	if m == nil {
+		m = DefaultMapper
+	}
+
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr {
+		return fmt.Errorf("expected a pointer but got %T", dest)
+	}
+	if value.IsNil() {
+		return errors.New("expected a pointer but got nil")
+	}
+	value = reflect.Indirect(value)
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("expected a struct but got %s", value.Kind())
+	}
+
+	tm := m.TypeMap(value.Type())
+	for name, v := range src {
+		fi, ok := tm.Names[name]
+		if !ok {
+			return fmt.Errorf("missing destination name %q in %s", name, value.Type())
+		}
+		f := reflectx.FieldByIndexes(value, fi.Index)
+		if v == nil {
+			f.Set(reflect.Zero(f.Type()))
+			continue
+		}
+		vv := reflect.ValueOf(v)
+		if !vv.Type().AssignableTo(f.Type()) {
+			return fmt.Errorf("cannot assign %s to field %s of type %s", vv.Type(), fi.Path, f.Type())
+		}
+		f.Set(vv)
+	}
+	return nil
+}
+
+// Field describes a struct field mapped to a column.
+type Field struct {
+	// Column is the name of the column the field is mapped to.
+	Column string
+	// Name is the name of the struct field.
+	Name string
+	// Type is the type of the struct field.
+	Type reflect.Type
+	// Index is the traversal path of the field, it's the index sequence for
+	// reflect.Value.FieldByIndex.
+	Index []int
+	// Options are the tag options, i.e. for `db:"name,omitempty"` it's
+	// {"omitempty": ""}.
+	Options map[string]string
+	// PII is true if the field holds personally identifiable information, it
+	// is marked with the pii tag option i.e. `db:"email,pii"`.
+	PII bool
+	// Description is the column documentation taken from the doc tag
+	// i.e. `doc:"primary contact email"`.
+	Description string
+}
+
+// PIIOption is the tag option marking fields that hold personally
+// identifiable information.
+const PIIOption = "pii"
+
+// DescriptionTag is the struct tag holding column documentation.
+const DescriptionTag = "doc"
+
+// StructFields returns fields of the struct type t mapped to columns using
+// the mapper m. The fields are returned in declaration order, fields of
+// embedded structs are returned in place of the embedded struct. It allows
+// tools to reflect over models the same way gocqlx does. If m is nil
+// DefaultMapper is used.
+func StructFields(m *reflectx.Mapper, t reflect.Type) ([]Field, error) {
+	if m == nil {
+		m = DefaultMapper
+	}
+
+	t, err := baseType(t, reflect.Struct)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := columnFields(m.TypeMap(t))
+	sort.Slice(fields, func(i, j int) bool {
+		return lessIndex(fields[i].Index, fields[j].Index)
+	})
+
+	r := make([]Field, len(fields))
+	for i, fi := range fields {
+		_, pii := fi.Options[PIIOption]
+		r[i] = Field{
+			Column:      fi.Name,
+			Name:        fi.Field.Name,
+			Type:        fi.Field.Type,
+			Index:       fi.Index,
+			Options:     fi.Options,
+			PII:         pii,
+			Description: fi.Field.Tag.Get(DescriptionTag),
+		}
+	}
+	return r, nil
+}
+
+func lessIndex(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// columnFields returns fields of a struct that are mapped to columns, these
+// are fields of the struct and fields of the embedded structs.
+func columnFields(tm *reflectx.StructMap) []*reflectx.FieldInfo {
+	var fields []*reflectx.FieldInfo
+	for _, fi := range tm.Index {
+		if fi.Embedded || !isColumnField(fi, tm.Tree) {
+			continue
+		}
+		fields = append(fields, fi)
+	}
+	return fields
+}
+
+func isColumnField(fi, root *reflectx.FieldInfo) bool {
+	for p := fi.Parent; p != root; p = p.Parent {
+		if p == nil || !p.Embedded {
+			return false
+		}
+	}
+	return true
+}
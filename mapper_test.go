@@ -0,0 +1,134 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type mapperAddress struct {
+	UDT
+	Street string
+	City   string
+}
+
+type mapperAudit struct {
+	CreatedAt time.Time
+}
+
+type mapperPerson struct {
+	mapperAudit
+	ID      int
+	Name    string `db:"full_name"`
+	Address mapperAddress
+	Ignored string `db:"-"`
+}
+
+func TestStructToMap(t *testing.T) {
+	now := time.Now()
+	p := mapperPerson{
+		mapperAudit: mapperAudit{CreatedAt: now},
+		ID:          1,
+		Name:        "name",
+		Address:     mapperAddress{Street: "street", City: "city"},
+		Ignored:     "ignored",
+	}
+
+	golden := map[string]interface{}{
+		"created_at": now,
+		"id":         1,
+		"full_name":  "name",
+		"address":    mapperAddress{Street: "street", City: "city"},
+	}
+
+	m, err := StructToMap(nil, &p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(golden, m); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if _, err := StructToMap(nil, 1); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := StructToMap(nil, (*mapperPerson)(nil)); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestMapToStruct(t *testing.T) {
+	now := time.Now()
+	src := map[string]interface{}{
+		"created_at": now,
+		"id":         1,
+		"full_name":  "name",
+		"address":    mapperAddress{Street: "street", City: "city"},
+	}
+
+	var p mapperPerson
+	if err := MapToStruct(nil, src, &p); err != nil {
+		t.Fatal(err)
+	}
+
+	golden := mapperPerson{
+		mapperAudit: mapperAudit{CreatedAt: now},
+		ID:          1,
+		Name:        "name",
+		Address:     mapperAddress{Street: "street", City: "city"},
+	}
+	if !reflect.DeepEqual(golden, p) {
+		t.Fatalf("MapToStruct() = %+v, expected %+v", p, golden)
+	}
+
+	t.Run("missing", func(t *testing.T) {
+		if err := MapToStruct(nil, map[string]interface{}{"not_found": 1}, &p); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("not assignable", func(t *testing.T) {
+		if err := MapToStruct(nil, map[string]interface{}{"id": "1"}, &p); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("not a pointer", func(t *testing.T) {
+		if err := MapToStruct(nil, src, p); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestStructFields(t *testing.T) {
+	type person struct {
+		mapperPerson
+		Email string `db:"email,pii" doc:"contact email"`
+	}
+
+	fields, err := StructFields(nil, reflect.TypeOf(&person{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden := []Field{
+		{Column: "created_at", Name: "CreatedAt", Type: reflect.TypeOf(time.Time{}), Index: []int{0, 0, 0}, Options: map[string]string{}},
+		{Column: "id", Name: "ID", Type: reflect.TypeOf(0), Index: []int{0, 1}, Options: map[string]string{}},
+		{Column: "full_name", Name: "Name", Type: reflect.TypeOf(""), Index: []int{0, 2}, Options: map[string]string{}},
+		{Column: "address", Name: "Address", Type: reflect.TypeOf(mapperAddress{}), Index: []int{0, 3}, Options: map[string]string{}},
+		{Column: "email", Name: "Email", Type: reflect.TypeOf(""), Index: []int{1}, Options: map[string]string{"pii": ""}, PII: true, Description: "contact email"},
+	}
+	if diff := cmp.Diff(golden, fields, cmp.Comparer(func(a, b reflect.Type) bool { return a == b })); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if _, err := StructFields(nil, reflect.TypeOf(1)); err == nil {
+		t.Fatal("expected error")
+	}
+}
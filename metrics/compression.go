@@ -0,0 +1,78 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import "sync"
+
+// CompressionStats holds sizes of compressed values of a column.
+type CompressionStats struct {
+	// Count is the number of compressed values.
+	Count uint64
+	// Size is the total size of values before compression.
+	Size uint64
+	// CompressedSize is the total size of values after compression.
+	CompressedSize uint64
+}
+
+// Ratio returns the compression ratio, the size before compression divided
+// by the size after compression. It returns 0 if nothing was recorded.
+func (s CompressionStats) Ratio() float64 {
+	if s.CompressedSize == 0 {
+		return 0
+	}
+	return float64(s.Size) / float64(s.CompressedSize)
+}
+
+// Compression records sizes of compressed blob values per column, it allows
+// for verifying that compression pays for its CPU. Set it with
+// gocqlx.SetCompressionObserver to record values of struct fields tagged
+// with a compression option i.e. `db:"payload,gzip"`. It's safe for
+// concurrent use.
+type Compression struct {
+	mu    sync.Mutex
+	stats map[string]*CompressionStats
+}
+
+// NewCompression creates an empty Compression.
+func NewCompression() *Compression {
+	return &Compression{
+		stats: make(map[string]*CompressionStats),
+	}
+}
+
+// Observe records a value of column compressed from size to compressedSize
+// bytes.
+func (c *Compression) Observe(column string, size, compressedSize int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.stats[column]
+	if !ok {
+		s = &CompressionStats{}
+		c.stats[column] = s
+	}
+	s.Count++
+	s.Size += uint64(size)
+	s.CompressedSize += uint64(compressedSize)
+}
+
+// Snapshot returns a copy of recorded stats by column.
+func (c *Compression) Snapshot() map[string]CompressionStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := make(map[string]CompressionStats, len(c.stats))
+	for column, v := range c.stats {
+		s[column] = *v
+	}
+	return s
+}
+
+// Reset removes all recorded stats.
+func (c *Compression) Reset() {
+	c.mu.Lock()
+	c.stats = make(map[string]*CompressionStats)
+	c.mu.Unlock()
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompression(t *testing.T) {
+	c := NewCompression()
+	c.Observe("a", 100, 25)
+	c.Observe("a", 300, 75)
+	c.Observe("b", 10, 20)
+
+	golden := map[string]CompressionStats{
+		"a": {Count: 2, Size: 400, CompressedSize: 100},
+		"b": {Count: 1, Size: 10, CompressedSize: 20},
+	}
+	s := c.Snapshot()
+	if diff := cmp.Diff(golden, s); diff != "" {
+		t.Fatal(diff)
+	}
+	if r := s["a"].Ratio(); r != 4 {
+		t.Fatalf("Ratio()=%v, expected 4", r)
+	}
+	if r := s["b"].Ratio(); r != 0.5 {
+		t.Fatalf("Ratio()=%v, expected 0.5", r)
+	}
+	if r := (CompressionStats{}).Ratio(); r != 0 {
+		t.Fatalf("Ratio()=%v, expected 0", r)
+	}
+
+	c.Observe("a", 1, 1)
+	if s["a"].Count != 2 {
+		t.Fatal("snapshot modified")
+	}
+
+	c.Reset()
+	if len(c.Snapshot()) != 0 {
+		t.Fatal("expected no stats after reset")
+	}
+}
@@ -0,0 +1,9 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+// Package metrics provides optional query observers collecting statistics
+// per statement. Observers can be set on a query with Queryx.Observer or on
+// all queries with gocql.ClusterConfig.QueryObserver. Compression collects
+// sizes of compressed blob values per column.
+package metrics
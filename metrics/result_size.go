@@ -0,0 +1,116 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/gocql/gocql"
+)
+
+// DefaultRowsBuckets are the upper bounds of histogram buckets used by
+// ResultSize if no buckets are specified.
+var DefaultRowsBuckets = []int{0, 1, 10, 100, 1000, 10000, 100000}
+
+// Histogram is a snapshot of a distribution of observed values.
+type Histogram struct {
+	// Buckets are inclusive upper bounds of the buckets.
+	Buckets []int
+	// Counts holds the number of observations per bucket, the last element
+	// counts observations greater than the last bucket.
+	Counts []uint64
+	// Count is the total number of observations.
+	Count uint64
+	// Sum is the sum of observed values.
+	Sum uint64
+	// Max is the maximal observed value.
+	Max int
+}
+
+func (h *Histogram) observe(v int) {
+	i := sort.SearchInts(h.Buckets, v)
+	h.Counts[i]++
+	h.Count++
+	h.Sum += uint64(v)
+	if v > h.Max {
+		h.Max = v
+	}
+}
+
+func (h *Histogram) clone() Histogram {
+	c := *h
+	c.Counts = make([]uint64, len(h.Counts))
+	copy(c.Counts, h.Counts)
+	return c
+}
+
+// ResultSize is a gocql.QueryObserver that records the number of rows
+// returned by each statement. Rows are counted per fetched page, paged queries
+// are observed once for every page. Errored queries are not recorded.
+type ResultSize struct {
+	buckets []int
+
+	mu    sync.Mutex
+	stats map[string]*Histogram
+}
+
+var _ gocql.QueryObserver = &ResultSize{}
+
+// NewResultSize creates a ResultSize with the given bucket upper bounds,
+// if no buckets are given DefaultRowsBuckets are used.
+func NewResultSize(buckets ...int) *ResultSize {
+	if len(buckets) == 0 {
+		buckets = DefaultRowsBuckets
+	}
+	b := make([]int, len(buckets))
+	copy(b, buckets)
+	sort.Ints(b)
+
+	return &ResultSize{
+		buckets: b,
+		stats:   make(map[string]*Histogram),
+	}
+}
+
+// ObserveQuery implements gocql.QueryObserver.
+func (r *ResultSize) ObserveQuery(ctx context.Context, q gocql.ObservedQuery) {
+	if q.Err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, ok := r.stats[q.Statement]
+	if !ok {
+		h = &Histogram{
+			Buckets: r.buckets,
+			Counts:  make([]uint64, len(r.buckets)+1),
+		}
+		r.stats[q.Statement] = h
+	}
+	h.observe(q.Rows)
+}
+
+// Snapshot returns a copy of recorded histograms by statement.
+func (r *ResultSize) Snapshot() map[string]Histogram {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := make(map[string]Histogram, len(r.stats))
+	for stmt, h := range r.stats {
+		s[stmt] = h.clone()
+	}
+	return s
+}
+
+// Reset removes all recorded histograms.
+func (r *ResultSize) Reset() {
+	r.mu.Lock()
+	r.stats = make(map[string]*Histogram)
+	r.mu.Unlock()
+}
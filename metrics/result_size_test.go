@@ -0,0 +1,56 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResultSize(t *testing.T) {
+	r := NewResultSize(10, 1)
+	ctx := context.Background()
+
+	for _, rows := range []int{0, 1, 5, 10, 11, 100} {
+		r.ObserveQuery(ctx, gocql.ObservedQuery{Statement: "a", Rows: rows})
+	}
+	r.ObserveQuery(ctx, gocql.ObservedQuery{Statement: "b", Rows: 2})
+	r.ObserveQuery(ctx, gocql.ObservedQuery{Statement: "b", Rows: 2, Err: errors.New("error")})
+
+	golden := map[string]Histogram{
+		"a": {
+			Buckets: []int{1, 10},
+			Counts:  []uint64{2, 2, 2},
+			Count:   6,
+			Sum:     127,
+			Max:     100,
+		},
+		"b": {
+			Buckets: []int{1, 10},
+			Counts:  []uint64{0, 1, 0},
+			Count:   1,
+			Sum:     2,
+			Max:     2,
+		},
+	}
+	s := r.Snapshot()
+	if diff := cmp.Diff(golden, s); diff != "" {
+		t.Fatal(diff)
+	}
+
+	r.ObserveQuery(ctx, gocql.ObservedQuery{Statement: "b", Rows: 0})
+	if s["b"].Count != 1 {
+		t.Fatal("snapshot modified")
+	}
+
+	r.Reset()
+	if len(r.Snapshot()) != 0 {
+		t.Fatal("expected no stats after reset")
+	}
+}
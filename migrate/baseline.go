@@ -0,0 +1,97 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+)
+
+// Baseline marks migrations in dir up to and including migration version
+// as applied without executing them. Use it to adopt migrations on
+// a database with schema created by other tools. Migrations that are
+// already applied are not changed.
+func Baseline(ctx context.Context, session gocqlx.Session, dir, version string) error {
+	return BaselineFS(ctx, session, os.DirFS(dir), version)
+}
+
+// BaselineFS is like Baseline but it reads migration files from the root
+// directory of f.
+func BaselineFS(ctx context.Context, session gocqlx.Session, f fs.FS, version string) error {
+	dbm, err := List(ctx, session)
+	if err != nil {
+		return fmt.Errorf("failed to list migrations: %s", err)
+	}
+	fm, err := migrations(f)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, name := range fm {
+		if name == version {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("migration %q not found", version)
+	}
+
+	applied := make(map[string]bool, len(dbm))
+	for _, m := range dbm {
+		applied[m.Name] = true
+	}
+
+	stmt, names := qb.Insert("gocqlx_migrate").Columns(
+		"name",
+		"checksum",
+		"done",
+		"start_time",
+		"end_time",
+	).ToCql()
+	insert := session.ContextQuery(ctx, stmt, names)
+	defer insert.Release()
+
+	for _, name := range fm {
+		if name > version {
+			break
+		}
+		if applied[name] {
+			continue
+		}
+
+		now := time.Now()
+		info := Info{
+			Name:      name,
+			Done:      1,
+			StartTime: now,
+			EndTime:   now,
+		}
+		if _, ok := goMigrations[name]; !ok {
+			b, err := fs.ReadFile(f, name)
+			if err != nil {
+				return fmt.Errorf("failed to read migration %q: %s", name, err)
+			}
+			info.Checksum = checksum(b)
+			if b, err = render(name, b); err != nil {
+				return fmt.Errorf("failed to render migration %q: %s", name, err)
+			}
+			info.Done = len(statements(b))
+		}
+
+		if err := insert.BindStruct(info).Exec(); err != nil {
+			return fmt.Errorf("failed to baseline migration %q: %s", name, err)
+		}
+	}
+
+	return nil
+}
@@ -8,7 +8,7 @@ import (
 	"crypto/md5"
 	"encoding/hex"
 	"io"
-	"os"
+	"io/fs"
 )
 
 var encode = hex.EncodeToString
@@ -18,15 +18,15 @@ func checksum(b []byte) string {
 	return encode(v[:])
 }
 
-func fileChecksum(path string) (string, error) {
-	f, err := os.Open(path)
+func fileChecksum(f fs.FS, name string) (string, error) {
+	r, err := f.Open(name)
 	if err != nil {
-		return "", nil
+		return "", err
 	}
-	defer f.Close()
+	defer r.Close()
 
 	h := md5.New()
-	if _, err := io.Copy(h, f); err != nil {
+	if _, err := io.Copy(h, r); err != nil {
 		return "", err
 	}
 	v := h.Sum(nil)
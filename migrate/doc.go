@@ -7,5 +7,11 @@
 // There is no imposed naming schema, migration name is file name and the
 // migrations are processed in lexicographical order. Caller provides a
 // gocql.Session, the session must use a desired keyspace as migrate would try
-// to create migrations table.
+// to create migrations table. Migrations can be read from any fs.FS with
+// FromFS, i.e. from embed.FS to ship migrations within a binary, the package
+// requires Go 1.16 or later.
+//
+// Migrations can be rolled back with Down if they are paired with down
+// migrations i.e. 001_foo.up.cql is rolled back with 001_foo.down.cql. Down
+// migration files are not applied by Migrate.
 package migrate
@@ -0,0 +1,99 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+)
+
+const (
+	upSuffix   = ".up.cql"
+	downSuffix = ".down.cql"
+)
+
+// downFile returns name of the down migration file of migration name, name
+// must end with .up.cql.
+func downFile(name string) (string, bool) {
+	if !strings.HasSuffix(name, upSuffix) {
+		return "", false
+	}
+	return strings.TrimSuffix(name, upSuffix) + downSuffix, true
+}
+
+// Down rolls back the last n applied migrations in reverse order. Every
+// rolled back migration needs a down migration file in dir, a migration
+// 001_foo.up.cql is rolled back with 001_foo.down.cql. Statements of the
+// down file are executed and the migration is removed from the list of
+// applied migrations. If n is greater than the number of applied migrations
+// all migrations are rolled back.
+func Down(ctx context.Context, session gocqlx.Session, dir string, n int) error {
+	return DownFS(ctx, session, os.DirFS(dir), n)
+}
+
+// DownFS is like Down but it reads down migration files from the root
+// directory of f.
+func DownFS(ctx context.Context, session gocqlx.Session, f fs.FS, n int) error {
+	dbm, err := List(ctx, session)
+	if err != nil {
+		return fmt.Errorf("failed to list migrations: %s", err)
+	}
+	if n > len(dbm) {
+		n = len(dbm)
+	}
+
+	// check down files before rolling back anything
+	down := make([]string, n)
+	for i := range down {
+		name := dbm[len(dbm)-1-i].Name
+		d, ok := downFile(name)
+		if !ok {
+			return fmt.Errorf("migration %q is not an up migration", name)
+		}
+		down[i] = d
+		if _, err := fs.Stat(f, d); err != nil {
+			return fmt.Errorf("missing down migration for %q: %s", name, err)
+		}
+	}
+
+	for i, d := range down {
+		name := dbm[len(dbm)-1-i].Name
+		if err := applyDown(ctx, session, f, d, name); err != nil {
+			return fmt.Errorf("failed to roll back migration %q: %s", name, err)
+		}
+	}
+
+	if err = session.AwaitSchemaAgreement(ctx); err != nil {
+		return fmt.Errorf("awaiting schema agreement failed: %s", err)
+	}
+
+	return nil
+}
+
+func applyDown(ctx context.Context, session gocqlx.Session, f fs.FS, down, name string) error {
+	b, err := fs.ReadFile(f, down)
+	if err != nil {
+		return err
+	}
+	if b, err = render(down, b); err != nil {
+		return err
+	}
+
+	for i, stmt := range statements(b) {
+		q := session.ContextQuery(ctx, stmt, nil).RetryPolicy(nil)
+		if err := q.ExecRelease(); err != nil {
+			return fmt.Errorf("statement %d failed: %s", i+1, err)
+		}
+	}
+
+	stmt, names := qb.Delete("gocqlx_migrate").Where(qb.Eq("name")).ToCql()
+	return session.ContextQuery(ctx, stmt, names).Bind(name).ExecRelease()
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/scylladb/gocqlx/v2"
+)
+
+// DryRun writes to w statements of pending migrations read from the root
+// directory of f, Go migrations and calls of Callback, without executing
+// them. Applied migrations are verified as in FromFS, the migrations table
+// is created if it does not exist.
+func DryRun(ctx context.Context, session gocqlx.Session, f fs.FS, w io.Writer) error {
+	pm, err := pending(ctx, session, f)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range pm {
+		if err := dryRun(f, m, w); err != nil {
+			return fmt.Errorf("failed to read migration %q: %s", m.name, err)
+		}
+	}
+	return nil
+}
+
+func dryRun(f fs.FS, m pendingMigration, w io.Writer) error {
+	if _, ok := goMigrations[m.name]; ok {
+		if m.done > 0 {
+			return nil
+		}
+		fmt.Fprintf(w, "-- migration %s\n", m.name)
+		writeCallback(w, BeforeMigration)
+		fmt.Fprintf(w, "-- Go migration %s\n", m.name)
+		writeCallback(w, AfterMigration)
+		return nil
+	}
+
+	b, err := fs.ReadFile(f, m.name)
+	if err != nil {
+		return err
+	}
+	if b, err = render(m.name, b); err != nil {
+		return err
+	}
+	stmts := statements(b)
+	if len(stmts) <= m.done {
+		return nil
+	}
+
+	fmt.Fprintf(w, "-- migration %s\n", m.name)
+	if m.done == 0 {
+		writeCallback(w, BeforeMigration)
+	}
+	for _, stmt := range stmts[m.done:] {
+		stmt = strings.TrimSpace(stmt)
+		if !strings.HasSuffix(stmt, ";") {
+			stmt += ";"
+		}
+		fmt.Fprintln(w, stmt)
+	}
+	writeCallback(w, AfterMigration)
+	return nil
+}
+
+func writeCallback(w io.Writer, ev CallbackEvent) {
+	if Callback == nil {
+		return
+	}
+	switch ev {
+	case BeforeMigration:
+		fmt.Fprintln(w, "-- callback before migration")
+	case AfterMigration:
+		fmt.Fprintln(w, "-- callback after migration")
+	}
+}
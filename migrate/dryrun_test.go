@@ -0,0 +1,78 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/gocqlx/v2"
+)
+
+func TestDryRun(t *testing.T) {
+	f := fstest.MapFS{
+		"0.cql": &fstest.MapFile{Data: []byte("A;\nB;\n")},
+		"2.cql": &fstest.MapFile{Data: []byte("C")},
+	}
+	defer ResetGoMigrations()
+	Register("1_go", func(ctx context.Context, session gocqlx.Session) error { return nil })
+
+	table := []struct {
+		Name     string
+		M        pendingMigration
+		Callback bool
+		W        string
+	}{
+		{
+			Name: "file",
+			M:    pendingMigration{name: "0.cql"},
+			W:    "-- migration 0.cql\nA;\nB;\n",
+		},
+		{
+			Name: "partially applied file",
+			M:    pendingMigration{name: "0.cql", done: 1},
+			W:    "-- migration 0.cql\nB;\n",
+		},
+		{
+			Name: "applied file",
+			M:    pendingMigration{name: "0.cql", done: 2},
+		},
+		{
+			Name:     "file with callback",
+			M:        pendingMigration{name: "2.cql"},
+			Callback: true,
+			W:        "-- migration 2.cql\n-- callback before migration\nC;\n-- callback after migration\n",
+		},
+		{
+			Name: "Go migration",
+			M:    pendingMigration{name: "1_go"},
+			W:    "-- migration 1_go\n-- Go migration 1_go\n",
+		},
+		{
+			Name: "applied Go migration",
+			M:    pendingMigration{name: "1_go", done: 1},
+		},
+	}
+
+	for _, test := range table {
+		t.Run(test.Name, func(t *testing.T) {
+			if test.Callback {
+				Callback = func(ctx context.Context, session gocqlx.Session, ev CallbackEvent, name string) error { return nil }
+				defer func() { Callback = nil }()
+			}
+
+			var w bytes.Buffer
+			if err := dryRun(f, test.M, &w); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.W, w.String()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
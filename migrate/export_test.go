@@ -0,0 +1,10 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+// ResetGoMigrations removes all registered Go migrations.
+func ResetGoMigrations() {
+	goMigrations = make(map[string]MigrationFunc)
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+)
+
+// MigrationFunc is a Go migration, use it for data backfills and
+// transformations that can not be expressed in CQL.
+type MigrationFunc func(ctx context.Context, session gocqlx.Session) error
+
+var goMigrations = make(map[string]MigrationFunc)
+
+// Register registers Go migration fn under name. Go migrations are ordered
+// with migration files by name i.e. Go migration 002_backfill is applied
+// after 001_init.cql and before 003_index.cql. A Go migration is applied
+// once, it's recorded as done when fn returns no error. Register is meant to
+// be called from init functions, it panics if name is already registered.
+func Register(name string, fn MigrationFunc) {
+	if _, ok := goMigrations[name]; ok {
+		panic(fmt.Sprintf("migrate: Go migration %q already registered", name))
+	}
+	goMigrations[name] = fn
+}
+
+func applyGoMigration(ctx context.Context, session gocqlx.Session, name string, fn MigrationFunc, done int) error {
+	if done > 0 {
+		return nil
+	}
+
+	info := Info{
+		Name:      name,
+		StartTime: time.Now(),
+	}
+
+	if Callback != nil {
+		if err := Callback(ctx, session, BeforeMigration, name); err != nil {
+			return fmt.Errorf("before migration callback failed: %s", err)
+		}
+	}
+
+	if DefaultHooks.BeforeFile != nil {
+		if err := DefaultHooks.BeforeFile(ctx, session, name); err != nil {
+			return fmt.Errorf("before file hook failed: %s", err)
+		}
+	}
+	err := fn(ctx, session)
+	if DefaultHooks.AfterFile != nil {
+		DefaultHooks.AfterFile(ctx, session, name, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	info.Done = 1
+	info.EndTime = time.Now()
+	stmt, names := qb.Insert("gocqlx_migrate").Columns(
+		"name",
+		"checksum",
+		"done",
+		"start_time",
+		"end_time",
+	).ToCql()
+	if err := session.ContextQuery(ctx, stmt, names).BindStruct(info).ExecRelease(); err != nil {
+		return fmt.Errorf("failed to record migration: %s", err)
+	}
+
+	if Callback != nil {
+		if err := Callback(ctx, session, AfterMigration, name); err != nil {
+			return fmt.Errorf("after migration callback failed: %s", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/scylladb/gocqlx/v2"
+)
+
+// ErrSkipStatement can be returned by Hooks.BeforeStatement to skip
+// execution of the statement, the statement is recorded as done.
+var ErrSkipStatement = errors.New("skip statement")
+
+// Statement is a statement of a migration file.
+type Statement struct {
+	// File is the migration file name.
+	File string
+	// Index is the number of the statement in the file, starting from one.
+	Index int
+	// Text is the statement, rendered if TemplateData is set.
+	Text string
+}
+
+// Hooks are functions called while migrating, nil functions are not
+// called. Unlike Callback they get statements and results, use them for
+// logging, timing and conditional skips.
+type Hooks struct {
+	// BeforeFile is called before the first pending statement of a file or
+	// before a Go migration. If error is returned the migration is aborted.
+	BeforeFile func(ctx context.Context, session gocqlx.Session, name string) error
+	// AfterFile is called after the file or Go migration is applied or
+	// failed, err is the migration error.
+	AfterFile func(ctx context.Context, session gocqlx.Session, name string, err error)
+	// BeforeStatement is called before each statement. If ErrSkipStatement
+	// is returned the statement is skipped, if other error is returned
+	// the migration is aborted.
+	BeforeStatement func(ctx context.Context, session gocqlx.Session, stmt Statement) error
+	// AfterStatement is called after each executed statement with
+	// the execution time and error.
+	AfterStatement func(ctx context.Context, session gocqlx.Session, stmt Statement, d time.Duration, err error)
+}
+
+// DefaultHooks are called while migrating, see Hooks for details.
+var DefaultHooks Hooks
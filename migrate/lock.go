@@ -0,0 +1,214 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+)
+
+// DefaultLockTTL is the time after which a lock that is not renewed
+// expires, i.e. when the lock holder crashed. The lock is renewed every
+// third of DefaultLockTTL.
+var DefaultLockTTL = 30 * time.Second
+
+// DefaultLockRetry is the interval between attempts to acquire a lock held
+// by another process.
+var DefaultLockRetry = time.Second
+
+// ErrLockLost is the error of the context passed to a function run with
+// WithLock when the lock could not be renewed.
+var ErrLockLost = errors.New("migration lock lost")
+
+const (
+	lockSchema = `CREATE TABLE IF NOT EXISTS gocqlx_migrate_lock (
+	name text,
+	owner text,
+	PRIMARY KEY(name)
+)`
+	lockName = "migrate"
+)
+
+// Lock is an advisory lock held in the gocqlx_migrate_lock table. It's
+// acquired with a conditional insert with TTL and renewed periodically
+// until it's released.
+type Lock struct {
+	session gocqlx.Session
+	owner   string
+
+	mu     sync.Mutex
+	err    error
+	lost   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// AcquireLock waits until the migration lock is acquired or ctx is done.
+// The lock must be released with Release.
+func AcquireLock(ctx context.Context, session gocqlx.Session) (*Lock, error) {
+	if err := session.ContextQuery(ctx, lockSchema, nil).ExecRelease(); err != nil {
+		return nil, fmt.Errorf("failed to create lock table: %s", err)
+	}
+
+	owner, err := lockOwner()
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, names := qb.Insert("gocqlx_migrate_lock").
+		Columns("name", "owner").
+		Unique().
+		TTL(DefaultLockTTL).
+		ToCql()
+	for {
+		applied, err := session.ContextQuery(ctx, stmt, names).Bind(lockName, owner).ExecCASRelease()
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire lock: %s", err)
+		}
+		if applied {
+			break
+		}
+
+		t := time.NewTimer(DefaultLockRetry)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		}
+	}
+
+	hctx, cancel := context.WithCancel(context.Background())
+	l := &Lock{
+		session: session,
+		owner:   owner,
+		lost:    make(chan struct{}),
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	go l.heartbeat(hctx)
+
+	return l, nil
+}
+
+// heartbeat renews the lock TTL until ctx is canceled or renewal fails.
+func (l *Lock) heartbeat(ctx context.Context) {
+	defer close(l.done)
+
+	stmt, names := qb.Update("gocqlx_migrate_lock").
+		TTL(DefaultLockTTL).
+		Set("owner").
+		Where(qb.Eq("name")).
+		If(qb.Eq("owner")).
+		ToCql()
+
+	t := time.NewTicker(DefaultLockTTL / 3)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		applied, err := l.session.ContextQuery(ctx, stmt, names).Bind(l.owner, lockName, l.owner).ExecCASRelease()
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil && !applied {
+			err = ErrLockLost
+		}
+		if err != nil {
+			l.mu.Lock()
+			l.err = fmt.Errorf("failed to renew lock: %s", err)
+			l.mu.Unlock()
+			close(l.lost)
+			return
+		}
+	}
+}
+
+// Lost returns a channel that is closed when the lock could not be renewed,
+// see Err for the cause.
+func (l *Lock) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// Err returns the error of renewing the lock.
+func (l *Lock) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// Release stops renewing the lock and deletes it if it's still held.
+func (l *Lock) Release(ctx context.Context) error {
+	l.cancel()
+	<-l.done
+
+	stmt, names := qb.Delete("gocqlx_migrate_lock").
+		Where(qb.Eq("name")).
+		If(qb.Eq("owner")).
+		ToCql()
+	if _, err := l.session.ContextQuery(ctx, stmt, names).Bind(lockName, l.owner).ExecCASRelease(); err != nil {
+		return fmt.Errorf("failed to release lock: %s", err)
+	}
+	return nil
+}
+
+// WithLock acquires the migration lock, calls fn and releases the lock.
+// When many processes start simultaneously only one runs migrations at
+// a time, the rest wait for the lock. If the lock is lost the context
+// passed to fn is canceled.
+//
+//	err := migrate.WithLock(ctx, session, func(ctx context.Context) error {
+//		return migrate.Migrate(ctx, session, dir)
+//	})
+func WithLock(ctx context.Context, session gocqlx.Session, fn func(ctx context.Context) error) (err error) {
+	l, err := AcquireLock(ctx, session)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rerr := l.Release(ctx); err == nil {
+			err = rerr
+		}
+	}()
+
+	fctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-l.Lost():
+			cancel()
+		case <-fctx.Done():
+		}
+	}()
+
+	err = fn(fctx)
+	if lerr := l.Err(); lerr != nil && err != nil {
+		return lerr
+	}
+	return err
+}
+
+// lockOwner returns a unique lock owner identifier.
+func lockOwner() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	host, _ := os.Hostname()
+	return host + "-" + hex.EncodeToString(b), nil
+}
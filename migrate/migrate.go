@@ -10,9 +10,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
+	"io/fs"
+	"log"
 	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 	"time"
@@ -42,6 +42,42 @@ func (as awaitSchemaAgreement) ShouldAwait(stage awaitSchemaAgreement) bool {
 	return as == stage
 }
 
+type checksumMismatch int
+
+// Options for handling applied migration files that were modified.
+const (
+	// ChecksumMismatchFail aborts the migration.
+	ChecksumMismatchFail checksumMismatch = iota
+	// ChecksumMismatchWarn reports the mismatch with Warnf and continues.
+	ChecksumMismatchWarn
+)
+
+// DefaultChecksumMismatch controls what happens when an applied migration
+// file was modified after it was applied, the md5 checksum of the file
+// content is recorded for every applied migration. The default is to fail.
+var DefaultChecksumMismatch = ChecksumMismatchFail
+
+type outOfOrder int
+
+// Options for handling migrations older than the last applied migration,
+// i.e. migrations merged from another branch.
+const (
+	// OutOfOrderFail aborts the migration.
+	OutOfOrderFail outOfOrder = iota
+	// OutOfOrderWarn reports the migration with Warnf and skips it.
+	OutOfOrderWarn
+	// OutOfOrderApply applies the migration.
+	OutOfOrderApply
+)
+
+// DefaultOutOfOrder controls what happens when a migration that is not
+// applied sorts before the last applied migration. The default is to fail.
+var DefaultOutOfOrder = OutOfOrderFail
+
+// Warnf reports problems that do not abort migrations, by default it logs
+// with the standard logger.
+var Warnf = log.Printf
+
 const (
 	infoSchema = `CREATE TABLE IF NOT EXISTS gocqlx_migrate (
 	name text,
@@ -91,76 +127,196 @@ func ensureInfoTable(ctx context.Context, session gocqlx.Session) error {
 
 // Migrate reads the cql files from a directory and applies required migrations.
 func Migrate(ctx context.Context, session gocqlx.Session, dir string) error {
+	return FromFS(ctx, session, os.DirFS(dir))
+}
+
+// FromFS reads the cql files from the root directory of f and applies
+// required migrations. Use it with embed.FS to ship migrations within
+// a binary, use fs.Sub to read migrations from a subdirectory.
+func FromFS(ctx context.Context, session gocqlx.Session, f fs.FS) error {
+	pm, err := pending(ctx, session, f)
+	if err != nil {
+		return err
+	}
+
+	// apply migrations
+	for _, m := range pm {
+		if err := apply(ctx, session, f, m.name, m.done); err != nil {
+			return fmt.Errorf("failed to apply migration %q: %s", m.name, err)
+		}
+	}
+
+	if err = session.AwaitSchemaAgreement(ctx); err != nil {
+		return fmt.Errorf("awaiting schema agreement failed: %s", err)
+	}
+
+	return nil
+}
+
+// pendingMigration is a migration to apply, done is the number of already
+// applied statements.
+type pendingMigration struct {
+	name string
+	done int
+}
+
+// pending verifies applied migrations and returns migrations to apply,
+// the last applied migration is included as it may be partially applied.
+func pending(ctx context.Context, session gocqlx.Session, f fs.FS) ([]pendingMigration, error) {
 	// get database migrations
 	dbm, err := List(ctx, session)
 	if err != nil {
-		return fmt.Errorf("failed to list migrations: %s", err)
+		return nil, fmt.Errorf("failed to list migrations: %s", err)
 	}
 
-	// get file migrations
-	fm, err := filepath.Glob(filepath.Join(dir, "*.cql"))
+	// get file and Go migrations
+	fm, err := migrations(f)
 	if err != nil {
-		return fmt.Errorf("failed to list migrations in %q: %s", dir, err)
-	}
-	if len(fm) == 0 {
-		return fmt.Errorf("no migration files found in %q", dir)
+		return nil, err
 	}
-	sort.Strings(fm)
 
 	// verify migrations
 	if len(dbm) > len(fm) {
-		return fmt.Errorf("database is ahead of %q", dir)
+		return nil, errors.New("database is ahead of migration files")
 	}
 
-	for i := 0; i < len(dbm); i++ {
-		if dbm[i].Name != filepath.Base(fm[i]) {
-			fmt.Println(dbm[i].Name, filepath.Base(fm[i]), i)
-			return errors.New("inconsistent migrations")
+	files := make(map[string]bool, len(fm))
+	for _, name := range fm {
+		files[name] = true
+	}
+	applied := make(map[string]bool, len(dbm))
+	for _, m := range dbm {
+		if !files[m.Name] {
+			return nil, fmt.Errorf("inconsistent migrations: applied migration %q not found", m.Name)
+		}
+		applied[m.Name] = true
+
+		if _, ok := goMigrations[m.Name]; ok {
+			continue
 		}
-		c, err := fileChecksum(fm[i])
+		c, err := fileChecksum(f, m.Name)
 		if err != nil {
-			return fmt.Errorf("failed to calculate checksum for %q: %s", fm[i], err)
+			return nil, fmt.Errorf("failed to calculate checksum for %q: %s", m.Name, err)
 		}
-		if dbm[i].Checksum != c {
-			return fmt.Errorf("file %q was tempered with, expected md5 %s", fm[i], dbm[i].Checksum)
+		if m.Checksum != c {
+			err := fmt.Errorf("file %q was tempered with, expected md5 %s got %s", m.Name, m.Checksum, c)
+			if DefaultChecksumMismatch == ChecksumMismatchFail {
+				return nil, err
+			}
+			Warnf("%s", err)
 		}
 	}
 
-	// apply migrations
+	// the last applied migration may be applied partially
+	var (
+		pm   []pendingMigration
+		last string
+	)
 	if len(dbm) > 0 {
-		last := len(dbm) - 1
-		if err := applyMigration(ctx, session, fm[last], dbm[last].Done); err != nil {
-			return fmt.Errorf("failed to apply migration %q: %s", fm[last], err)
+		m := dbm[len(dbm)-1]
+		last = m.Name
+		pm = append(pm, pendingMigration{name: m.Name, done: m.Done})
+	}
+	for _, name := range fm {
+		if applied[name] {
+			continue
+		}
+		if name < last {
+			err := fmt.Errorf("migration %q is out of order, migration %q is already applied", name, last)
+			switch DefaultOutOfOrder {
+			case OutOfOrderFail:
+				return nil, err
+			case OutOfOrderWarn:
+				Warnf("%s, skipping", err)
+				continue
+			}
 		}
+		pm = append(pm, pendingMigration{name: name})
 	}
+	sort.SliceStable(pm, func(i, j int) bool {
+		return pm[i].name < pm[j].name
+	})
 
-	for i := len(dbm); i < len(fm); i++ {
-		if err := applyMigration(ctx, session, fm[i], 0); err != nil {
-			return fmt.Errorf("failed to apply migration %q: %s", fm[i], err)
-		}
+	return pm, nil
+}
+
+// migrationFiles returns sorted names of migration files in the root
+// directory of f, down migration files are skipped.
+func migrationFiles(f fs.FS) ([]string, error) {
+	all, err := fs.Glob(f, "*.cql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %s", err)
 	}
 
-	if err = session.AwaitSchemaAgreement(ctx); err != nil {
-		return fmt.Errorf("awaiting schema agreement failed: %s", err)
+	var fm []string
+	for _, f := range all {
+		if !strings.HasSuffix(f, downSuffix) {
+			fm = append(fm, f)
+		}
 	}
+	sort.Strings(fm)
 
-	return nil
+	return fm, nil
 }
 
-func applyMigration(ctx context.Context, session gocqlx.Session, path string, done int) error {
-	f, err := os.Open(path)
+// migrations returns sorted names of migration files in f and registered Go
+// migrations.
+func migrations(f fs.FS) ([]string, error) {
+	fm, err := migrationFiles(f)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	for _, name := range fm {
+		if _, ok := goMigrations[name]; ok {
+			return nil, fmt.Errorf("migration %q is both a file and a Go migration", name)
+		}
+	}
+	for name := range goMigrations {
+		fm = append(fm, name)
+	}
+	if len(fm) == 0 {
+		return nil, errors.New("no migrations found")
 	}
+	sort.Strings(fm)
+
+	return fm, nil
+}
 
-	b, err := ioutil.ReadAll(f)
-	f.Close()
+// apply applies file or Go migration name.
+func apply(ctx context.Context, session gocqlx.Session, f fs.FS, name string, done int) error {
+	if fn, ok := goMigrations[name]; ok {
+		return applyGoMigration(ctx, session, name, fn, done)
+	}
+	return applyMigration(ctx, session, f, name, done)
+}
+
+// statements splits b into CQL statements terminated with semicolons, the
+// last statement may have no semicolon.
+func statements(b []byte) []string {
+	var stmts []string
+	r := bytes.NewBuffer(b)
+	for {
+		stmt, err := r.ReadString(';')
+		if err == io.EOF {
+			// handle missing semicolon after last statement
+			if strings.TrimSpace(stmt) != "" {
+				stmts = append(stmts, stmt)
+			}
+			break
+		}
+		stmts = append(stmts, stmt)
+	}
+	return stmts
+}
+
+func applyMigration(ctx context.Context, session gocqlx.Session, f fs.FS, name string, done int) error {
+	b, err := fs.ReadFile(f, name)
 	if err != nil {
 		return err
 	}
 
 	info := Info{
-		Name:      filepath.Base(path),
+		Name:      name,
 		StartTime: time.Now(),
 		Checksum:  checksum(b),
 	}
@@ -182,23 +338,40 @@ func applyMigration(ctx context.Context, session gocqlx.Session, path string, do
 		}
 	}
 
-	i := 0
-	r := bytes.NewBuffer(b)
-	for {
-		stmt, err := r.ReadString(';')
-		if err == io.EOF {
-			if strings.TrimSpace(stmt) != "" {
-				// handle missing semicolon after last statement
-				err = nil
-			} else {
-				break
+	if b, err = render(name, b); err != nil {
+		return err
+	}
+	stmts := statements(b)
+	if len(stmts) > done {
+		if DefaultHooks.BeforeFile != nil {
+			if err := DefaultHooks.BeforeFile(ctx, session, name); err != nil {
+				return fmt.Errorf("before file hook failed: %s", err)
 			}
 		}
+		err := applyStatements(ctx, session, update, &info, stmts, done)
+		if DefaultHooks.AfterFile != nil {
+			DefaultHooks.AfterFile(ctx, session, name, err)
+		}
 		if err != nil {
 			return err
 		}
-		i++
+	}
+	if len(stmts) == 0 {
+		return fmt.Errorf("no migration statements found in %q", info.Name)
+	}
+
+	if Callback != nil && len(stmts) > done {
+		if err := Callback(ctx, session, AfterMigration, info.Name); err != nil {
+			return fmt.Errorf("after migration callback failed: %s", err)
+		}
+	}
 
+	return nil
+}
+
+func applyStatements(ctx context.Context, session gocqlx.Session, update *gocqlx.Queryx, info *Info, stmts []string, done int) error {
+	for j, stmt := range stmts {
+		i := j + 1
 		if i <= done {
 			continue
 		}
@@ -210,15 +383,31 @@ func applyMigration(ctx context.Context, session gocqlx.Session, path string, do
 		}
 
 		if DefaultAwaitSchemaAgreement.ShouldAwait(AwaitSchemaAgreementBeforeEachStatement) {
-			if err = session.AwaitSchemaAgreement(ctx); err != nil {
+			if err := session.AwaitSchemaAgreement(ctx); err != nil {
 				return fmt.Errorf("awaiting schema agreement failed: %s", err)
 			}
 		}
 
 		// execute
-		q := session.ContextQuery(ctx, stmt, nil).RetryPolicy(nil)
-		if err := q.ExecRelease(); err != nil {
-			return fmt.Errorf("statement %d failed: %s", i, err)
+		s := Statement{File: info.Name, Index: i, Text: stmt}
+		skip := false
+		if DefaultHooks.BeforeStatement != nil {
+			err := DefaultHooks.BeforeStatement(ctx, session, s)
+			if err == ErrSkipStatement {
+				skip = true
+			} else if err != nil {
+				return fmt.Errorf("before statement %d hook failed: %s", i, err)
+			}
+		}
+		if !skip {
+			start := time.Now()
+			err := session.ContextQuery(ctx, stmt, nil).RetryPolicy(nil).ExecRelease()
+			if DefaultHooks.AfterStatement != nil {
+				DefaultHooks.AfterStatement(ctx, session, s, time.Since(start), err)
+			}
+			if err != nil {
+				return fmt.Errorf("statement %d failed: %s", i, err)
+			}
 		}
 
 		// update info
@@ -228,15 +417,5 @@ func applyMigration(ctx context.Context, session gocqlx.Session, path string, do
 			return fmt.Errorf("migration statement %d failed: %s", i, err)
 		}
 	}
-	if i == 0 {
-		return fmt.Errorf("no migration statements found in %q", info.Name)
-	}
-
-	if Callback != nil && i > done {
-		if err := Callback(ctx, session, AfterMigration, info.Name); err != nil {
-			return fmt.Errorf("after migration callback failed: %s", err)
-		}
-	}
-
 	return nil
 }
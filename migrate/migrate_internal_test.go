@@ -0,0 +1,71 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStatements(t *testing.T) {
+	table := []struct {
+		B string
+		S []string
+	}{
+		{
+			B: "",
+		},
+		{
+			B: "A;\nB;\n",
+			S: []string{"A;", "\nB;"},
+		},
+		{
+			B: "A;\nB",
+			S: []string{"A;", "\nB"},
+		},
+	}
+
+	for _, test := range table {
+		if diff := cmp.Diff(test.S, statements([]byte(test.B))); diff != "" {
+			t.Errorf("statements(%q) %s", test.B, diff)
+		}
+	}
+}
+
+func TestMigrationFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gocqlx_migrate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"002_b.up.cql", "001_a.cql", "002_b.down.cql", "README"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), nil, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fm, err := migrationFiles(os.DirFS(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"001_a.cql", "002_b.up.cql"}
+	if diff := cmp.Diff(expected, fm); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestDownFile(t *testing.T) {
+	if f, ok := downFile("001_foo.up.cql"); !ok || f != "001_foo.down.cql" {
+		t.Fatalf("downFile()=%q, %v", f, ok)
+	}
+	if _, ok := downFile("001_foo.cql"); ok {
+		t.Fatal("expected no down file")
+	}
+}
@@ -13,8 +13,13 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"testing/fstest"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/scylladb/gocqlx/v2"
 	. "github.com/scylladb/gocqlx/v2/gocqlxtest"
 	"github.com/scylladb/gocqlx/v2/migrate"
@@ -98,6 +103,30 @@ func TestMigration(t *testing.T) {
 			t.Log(err)
 		}
 	})
+
+	t.Run("tempered with file warn", func(t *testing.T) {
+		dir := makeMigrationDir(t, 4)
+		defer os.Remove(dir)
+
+		temperFile(t, dir, "3.cql")
+
+		defer func(w func(string, ...interface{})) {
+			migrate.DefaultChecksumMismatch = migrate.ChecksumMismatchFail
+			migrate.Warnf = w
+		}(migrate.Warnf)
+		migrate.DefaultChecksumMismatch = migrate.ChecksumMismatchWarn
+		var warnings []string
+		migrate.Warnf = func(format string, v ...interface{}) {
+			warnings = append(warnings, fmt.Sprintf(format, v...))
+		}
+
+		if err := migrate.Migrate(ctx, session, dir); err != nil {
+			t.Fatal(err)
+		}
+		if len(warnings) != 1 || !strings.Contains(warnings[0], "tempered") {
+			t.Fatal("expected warning got", warnings)
+		}
+	})
 }
 
 func TestMigrationNoSemicolon(t *testing.T) {
@@ -195,6 +224,357 @@ func TestMigrationCallback(t *testing.T) {
 	})
 }
 
+func TestMigrationFromFS(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	recreateTables(t, session)
+
+	f := fstest.MapFS{
+		"0.cql":     &fstest.MapFile{Data: []byte(fmt.Sprintf(insertMigrate, 0) + ";")},
+		"1.cql":     &fstest.MapFile{Data: []byte(fmt.Sprintf(insertMigrate, 1) + ";")},
+		"README":    &fstest.MapFile{Data: []byte("not a migration")},
+		"sub/2.cql": &fstest.MapFile{Data: []byte("not applied;")},
+	}
+	if err := migrate.FromFS(context.Background(), session, f); err != nil {
+		t.Fatal(err)
+	}
+	if c := countMigrations(t, session); c != 2 {
+		t.Fatal("expected 2 migration got", c)
+	}
+}
+
+func TestWithLock(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	defer func(retry time.Duration) {
+		migrate.DefaultLockRetry = retry
+	}(migrate.DefaultLockRetry)
+	migrate.DefaultLockRetry = 10 * time.Millisecond
+
+	var (
+		wg      sync.WaitGroup
+		running int32
+		runs    int32
+	)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := migrate.WithLock(context.Background(), session, func(ctx context.Context) error {
+				if n := atomic.AddInt32(&running, 1); n != 1 {
+					t.Errorf("%d functions running with lock", n)
+				}
+				time.Sleep(50 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				atomic.AddInt32(&runs, 1)
+				return nil
+			})
+			if err != nil {
+				t.Error("WithLock() error:", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if runs != 3 {
+		t.Fatal("expected 3 runs got", runs)
+	}
+}
+
+func TestMigrationGo(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	recreateTables(t, session)
+
+	defer migrate.ResetGoMigrations()
+	calls := 0
+	migrate.Register("1_go", func(ctx context.Context, session gocqlx.Session) error {
+		calls++
+		return session.ContextQuery(ctx, fmt.Sprintf(insertMigrate, 100), nil).ExecRelease()
+	})
+
+	f := fstest.MapFS{
+		"0.cql": &fstest.MapFile{Data: []byte(fmt.Sprintf(insertMigrate, 0) + ";")},
+		"2.cql": &fstest.MapFile{Data: []byte(fmt.Sprintf(insertMigrate, 2) + ";")},
+	}
+	for i := 0; i < 2; i++ {
+		if err := migrate.FromFS(context.Background(), session, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 1 {
+		t.Fatal("expected 1 call got", calls)
+	}
+	if c := countMigrations(t, session); c != 3 {
+		t.Fatal("expected 3 migration got", c)
+	}
+
+	l, err := migrate.List(context.Background(), session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, m := range l {
+		names = append(names, m.Name)
+	}
+	if diff := cmp.Diff([]string{"0.cql", "1_go", "2.cql"}, names); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestMigrationDryRun(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	recreateTables(t, session)
+
+	f := fstest.MapFS{
+		"0.cql": &fstest.MapFile{Data: []byte(fmt.Sprintf(insertMigrate, 0) + ";")},
+	}
+	var w strings.Builder
+	if err := migrate.DryRun(context.Background(), session, f, &w); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(w.String(), "INSERT INTO gocqlx_test.migrate_table") {
+		t.Fatal("expected insert statement got", w.String())
+	}
+	if c := countMigrations(t, session); c != 0 {
+		t.Fatal("expected 0 migration got", c)
+	}
+}
+
+func TestMigrationStatus(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	recreateTables(t, session)
+
+	ctx := context.Background()
+
+	dir := makeMigrationDir(t, 2)
+	defer os.Remove(dir)
+	if err := migrate.Migrate(ctx, session, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	dir = makeMigrationDir(t, 3)
+	defer os.Remove(dir)
+	temperFile(t, dir, "0.cql")
+
+	s, err := migrate.Status(ctx, session, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s) != 3 {
+		t.Fatal("expected 3 migrations got", s)
+	}
+	if s[0].Pending || !s[0].Modified || s[0].Applied == nil {
+		t.Error("unexpected status", s[0])
+	}
+	if s[1].Pending || s[1].Modified || s[1].Applied == nil {
+		t.Error("unexpected status", s[1])
+	}
+	if !s[2].Pending || s[2].Applied != nil {
+		t.Error("unexpected status", s[2])
+	}
+}
+
+func TestMigrationOutOfOrder(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	recreateTables(t, session)
+
+	ctx := context.Background()
+
+	f := fstest.MapFS{
+		"0.cql": &fstest.MapFile{Data: []byte(fmt.Sprintf(insertMigrate, 0) + ";")},
+		"2.cql": &fstest.MapFile{Data: []byte(fmt.Sprintf(insertMigrate, 2) + ";")},
+	}
+	if err := migrate.FromFS(ctx, session, f); err != nil {
+		t.Fatal(err)
+	}
+	f["1.cql"] = &fstest.MapFile{Data: []byte(fmt.Sprintf(insertMigrate, 1) + ";")}
+
+	defer func(w func(string, ...interface{})) {
+		migrate.DefaultOutOfOrder = migrate.OutOfOrderFail
+		migrate.Warnf = w
+	}(migrate.Warnf)
+
+	t.Run("fail", func(t *testing.T) {
+		if err := migrate.FromFS(ctx, session, f); err == nil || !strings.Contains(err.Error(), "out of order") {
+			t.Fatal("expected error got", err)
+		}
+	})
+
+	t.Run("warn", func(t *testing.T) {
+		migrate.DefaultOutOfOrder = migrate.OutOfOrderWarn
+		var warnings []string
+		migrate.Warnf = func(format string, v ...interface{}) {
+			warnings = append(warnings, fmt.Sprintf(format, v...))
+		}
+
+		if err := migrate.FromFS(ctx, session, f); err != nil {
+			t.Fatal(err)
+		}
+		if len(warnings) != 1 || !strings.Contains(warnings[0], "out of order") {
+			t.Fatal("expected warning got", warnings)
+		}
+		if c := countMigrations(t, session); c != 2 {
+			t.Fatal("expected 2 migration got", c)
+		}
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		migrate.DefaultOutOfOrder = migrate.OutOfOrderApply
+
+		if err := migrate.FromFS(ctx, session, f); err != nil {
+			t.Fatal(err)
+		}
+		if c := countMigrations(t, session); c != 3 {
+			t.Fatal("expected 3 migration got", c)
+		}
+	})
+}
+
+func TestMigrationHooks(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	recreateTables(t, session)
+
+	var events []string
+	migrate.DefaultHooks = migrate.Hooks{
+		BeforeFile: func(ctx context.Context, session gocqlx.Session, name string) error {
+			events = append(events, "before file "+name)
+			return nil
+		},
+		AfterFile: func(ctx context.Context, session gocqlx.Session, name string, err error) {
+			events = append(events, fmt.Sprint("after file ", name, " ", err))
+		},
+		BeforeStatement: func(ctx context.Context, session gocqlx.Session, stmt migrate.Statement) error {
+			events = append(events, fmt.Sprint("before statement ", stmt.File, " ", stmt.Index))
+			if stmt.Index == 2 {
+				return migrate.ErrSkipStatement
+			}
+			return nil
+		},
+		AfterStatement: func(ctx context.Context, session gocqlx.Session, stmt migrate.Statement, d time.Duration, err error) {
+			events = append(events, fmt.Sprint("after statement ", stmt.File, " ", stmt.Index, " ", err))
+		},
+	}
+	defer func() {
+		migrate.DefaultHooks = migrate.Hooks{}
+	}()
+
+	f := fstest.MapFS{
+		"0.cql": &fstest.MapFile{Data: []byte(fmt.Sprintf(insertMigrate, 0) + ";" + fmt.Sprintf(insertMigrate, 1) + ";")},
+	}
+	if err := migrate.FromFS(context.Background(), session, f); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"before file 0.cql",
+		"before statement 0.cql 1",
+		"after statement 0.cql 1 <nil>",
+		"before statement 0.cql 2",
+		"after file 0.cql <nil>",
+	}
+	if diff := cmp.Diff(expected, events); diff != "" {
+		t.Fatal(diff)
+	}
+	if c := countMigrations(t, session); c != 1 {
+		t.Fatal("expected 1 migration got", c)
+	}
+}
+
+func TestMigrationBaseline(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	recreateTables(t, session)
+
+	ctx := context.Background()
+
+	dir := makeMigrationDir(t, 4)
+	defer os.Remove(dir)
+
+	if err := migrate.Baseline(ctx, session, dir, "not_found.cql"); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := migrate.Baseline(ctx, session, dir, "1.cql"); err != nil {
+		t.Fatal("Baseline() error:", err)
+	}
+	if err := migrate.Migrate(ctx, session, dir); err != nil {
+		t.Fatal(err)
+	}
+	if c := countMigrations(t, session); c != 2 {
+		t.Fatal("expected 2 migration got", c)
+	}
+	l, err := migrate.List(ctx, session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l) != 4 {
+		t.Fatal("expected 4 applied migrations got", len(l))
+	}
+}
+
+func TestMigrationDown(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	recreateTables(t, session)
+
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "gocqlx_migrate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"001_a.up.cql":   fmt.Sprintf(insertMigrate, 1) + ";",
+		"001_a.down.cql": "DELETE FROM gocqlx_test.migrate_table WHERE testint = 1;",
+		"002_b.up.cql":   fmt.Sprintf(insertMigrate, 2) + ";",
+		"002_b.down.cql": "DELETE FROM gocqlx_test.migrate_table WHERE testint = 2;",
+	}
+	for name, cql := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(cql), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := migrate.Migrate(ctx, session, dir); err != nil {
+		t.Fatal(err)
+	}
+	if c := countMigrations(t, session); c != 2 {
+		t.Fatal("expected 2 migration got", c)
+	}
+
+	if err := migrate.Down(ctx, session, dir, 1); err != nil {
+		t.Fatal("Down() error:", err)
+	}
+	if c := countMigrations(t, session); c != 1 {
+		t.Fatal("expected 1 migration got", c)
+	}
+	if l, err := migrate.List(ctx, session); err != nil || len(l) != 1 || l[0].Name != "001_a.up.cql" {
+		t.Fatal("List()", l, err)
+	}
+
+	// migrate up again
+	if err := migrate.Migrate(ctx, session, dir); err != nil {
+		t.Fatal(err)
+	}
+	if c := countMigrations(t, session); c != 2 {
+		t.Fatal("expected 2 migration got", c)
+	}
+
+	if err := migrate.Down(ctx, session, dir, 10); err != nil {
+		t.Fatal("Down() error:", err)
+	}
+	if c := countMigrations(t, session); c != 0 {
+		t.Fatal("expected 0 migration got", c)
+	}
+}
+
 func makeMigrationDir(tb testing.TB, n int) (dir string) {
 	tb.Helper()
 
@@ -0,0 +1,99 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+
+	"github.com/scylladb/gocqlx/v2"
+)
+
+// MigrationStatus describes state of a migration.
+type MigrationStatus struct {
+	Name string `json:"name"`
+	// Go is true for Go migrations.
+	Go bool `json:"go,omitempty"`
+	// Checksum is md5 checksum of the migration file, it's empty for Go
+	// migrations and migrations without a file.
+	Checksum string `json:"checksum,omitempty"`
+	// Pending is true if the migration is not applied or applied partially.
+	Pending bool `json:"pending"`
+	// Modified is true if the migration file was modified after it was
+	// applied.
+	Modified bool `json:"modified,omitempty"`
+	// Missing is true if an applied migration has no file or Go migration.
+	Missing bool `json:"missing,omitempty"`
+	// Applied holds information on the applied migration, it's nil for
+	// migrations that were not applied.
+	Applied *Info `json:"applied,omitempty"`
+}
+
+// Status returns status of migrations in dir and migrations applied on the
+// database ordered by name. Unlike Migrate it does not fail on
+// inconsistencies, they are reported. Use it to expose the status of
+// migrations or to assert that no migrations are pending.
+func Status(ctx context.Context, session gocqlx.Session, dir string) ([]MigrationStatus, error) {
+	return StatusFS(ctx, session, os.DirFS(dir))
+}
+
+// StatusFS is like Status but it reads migration files from the root
+// directory of f.
+func StatusFS(ctx context.Context, session gocqlx.Session, f fs.FS) ([]MigrationStatus, error) {
+	dbm, err := List(ctx, session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %s", err)
+	}
+	fm, err := migrations(f)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]*Info, len(dbm))
+	for _, m := range dbm {
+		applied[m.Name] = m
+	}
+
+	var out []MigrationStatus
+	for _, name := range fm {
+		s := MigrationStatus{
+			Name:    name,
+			Applied: applied[name],
+		}
+		delete(applied, name)
+
+		if _, ok := goMigrations[name]; ok {
+			s.Go = true
+			s.Pending = s.Applied == nil || s.Applied.Done == 0
+		} else {
+			b, err := fs.ReadFile(f, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read migration %q: %s", name, err)
+			}
+			s.Checksum = checksum(b)
+			if b, err = render(name, b); err != nil {
+				return nil, fmt.Errorf("failed to render migration %q: %s", name, err)
+			}
+			s.Pending = s.Applied == nil || s.Applied.Done < len(statements(b))
+			s.Modified = s.Applied != nil && s.Applied.Checksum != s.Checksum
+		}
+		out = append(out, s)
+	}
+	for name, info := range applied {
+		out = append(out, MigrationStatus{
+			Name:    name,
+			Missing: true,
+			Applied: info,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+
+	return out, nil
+}
@@ -0,0 +1,37 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"bytes"
+	"text/template"
+)
+
+// TemplateData, if not nil, enables rendering of migration files as
+// text/template templates executed with TemplateData. Use it to apply the
+// same migrations to clusters with different topology, i.e.
+//
+//	CREATE KEYSPACE IF NOT EXISTS {{.Keyspace}} WITH replication = {'class': 'NetworkTopologyStrategy', '{{.DC}}': {{.RF}}};
+//
+// Missing keys are errors. Checksums are calculated from file content before
+// rendering so that migrations rendered with different data are consistent.
+var TemplateData interface{}
+
+// render renders migration file content b if TemplateData is set.
+func render(name string, b []byte) ([]byte, error) {
+	if TemplateData == nil {
+		return b, nil
+	}
+
+	t, err := template.New(name).Option("missingkey=error").Parse(string(b))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, TemplateData); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
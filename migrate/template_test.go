@@ -0,0 +1,40 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	const cql = "CREATE KEYSPACE {{.Keyspace}} WITH replication = {'class': 'NetworkTopologyStrategy', '{{.DC}}': {{.RF}}};"
+
+	b, err := render("0.cql", []byte(cql))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != cql {
+		t.Fatal("expected no rendering got", string(b))
+	}
+
+	TemplateData = map[string]interface{}{
+		"Keyspace": "ks",
+		"DC":       "dc1",
+		"RF":       3,
+	}
+	defer func() { TemplateData = nil }()
+
+	b, err = render("0.cql", []byte(cql))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(b); s != "CREATE KEYSPACE ks WITH replication = {'class': 'NetworkTopologyStrategy', 'dc1': 3};" {
+		t.Fatal(s)
+	}
+
+	if _, err := render("0.cql", []byte("{{.Missing}}")); err == nil {
+		t.Fatal("expected error for missing key")
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ErrTimedOut is returned by MultiGet for keys that were not read before
+// the deadline.
+var ErrTimedOut = errors.New("timed out")
+
+// MultiGet runs the get statement for every key in parallel and scans the
+// results into the corresponding dest values. A key is bound with BindMap if
+// it's a map[string]interface{}, otherwise with BindStruct. It returns when
+// all the queries are done or when timeout elapses, whatever happens first,
+// returning results available at that time. The returned slice holds errors
+// for every key, ErrTimedOut for keys that were not read before the deadline
+// or nil on success. Dest values of failed keys are left unchanged.
+//
+// Dest values are never modified after MultiGet returns, queries still
+// running after the deadline scan into temporary values.
+func (s Session) MultiGet(ctx context.Context, stmt string, names []string, keys, dest []interface{}, timeout time.Duration) []error {
+	if len(keys) != len(dest) {
+		errs := make([]error, len(keys))
+		for i := range errs {
+			errs[i] = fmt.Errorf("expected %d dest values got %d", len(keys), len(dest))
+		}
+		return errs
+	}
+
+	return multiGet(ctx, dest, timeout, func(ctx context.Context, i int, v interface{}) error {
+		q := s.ContextQuery(ctx, stmt, names)
+		if m, ok := keys[i].(map[string]interface{}); ok {
+			q.BindMap(m)
+		} else {
+			q.BindStruct(keys[i])
+		}
+		return q.GetRelease(v)
+	})
+}
+
+type multiGetResult struct {
+	i   int
+	v   reflect.Value
+	err error
+}
+
+func multiGet(ctx context.Context, dest []interface{}, timeout time.Duration, get func(ctx context.Context, i int, v interface{}) error) []error {
+	errs := make([]error, len(dest))
+	for i := range errs {
+		errs[i] = ErrTimedOut
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Buffered so that queries finishing after the deadline do not block.
+	results := make(chan multiGetResult, len(dest))
+	for i := range dest {
+		t := reflect.TypeOf(dest[i])
+		if t == nil || t.Kind() != reflect.Ptr {
+			errs[i] = fmt.Errorf("expected a pointer but got %T", dest[i])
+			results <- multiGetResult{i: i, err: errs[i]}
+			continue
+		}
+		go func(i int, v reflect.Value) {
+			results <- multiGetResult{i: i, v: v, err: get(ctx, i, v.Interface())}
+		}(i, reflect.New(t.Elem()))
+	}
+
+	for range dest {
+		select {
+		case r := <-results:
+			if r.err != nil {
+				if ctx.Err() == nil || !errors.Is(r.err, ctx.Err()) {
+					errs[r.i] = r.err
+				}
+				continue
+			}
+			reflect.ValueOf(dest[r.i]).Elem().Set(r.v.Elem())
+			errs[r.i] = nil
+		case <-ctx.Done():
+			return errs
+		}
+	}
+	return errs
+}
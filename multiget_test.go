@@ -0,0 +1,67 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMultiGet(t *testing.T) {
+	errGet := errors.New("get")
+	release := make(chan struct{})
+	defer close(release)
+
+	get := func(ctx context.Context, i int, v interface{}) error {
+		switch i {
+		case 1:
+			return errGet
+		case 2:
+			select {
+			case <-release:
+			case <-ctx.Done():
+			}
+			*v.(*string) = "late"
+			return ctx.Err()
+		case 3:
+			<-release
+			*v.(*string) = "late"
+			return nil
+		}
+		*v.(*string) = "value"
+		return nil
+	}
+
+	dest := []interface{}{new(string), new(string), new(string), new(string), "not a pointer"}
+	errs := multiGet(context.Background(), dest, 10*time.Millisecond, get)
+
+	if len(errs) != len(dest) {
+		t.Fatalf("got %d errors expected %d", len(errs), len(dest))
+	}
+	if errs[0] != nil {
+		t.Fatal("unexpected error", errs[0])
+	}
+	if errs[1] != errGet {
+		t.Fatal("unexpected error", errs[1])
+	}
+	if errs[2] != ErrTimedOut || errs[3] != ErrTimedOut {
+		t.Fatal("expected timeout", errs[2], errs[3])
+	}
+	if errs[4] == nil {
+		t.Fatal("expected error")
+	}
+
+	var got []string
+	for _, d := range dest[:4] {
+		got = append(got, *d.(*string))
+	}
+	if diff := cmp.Diff([]string{"value", "", "", ""}, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import "time"
+
+// Page is a single page of query results with paging metadata, it's meant to
+// be returned by API layers as is.
+type Page struct {
+	// Items is the destination passed to SelectPage, a pointer to slice.
+	Items interface{} `json:"items"`
+	// NextPageState is the page state of the next page, pass it to
+	// Queryx.PageState to fetch the next page.
+	NextPageState []byte `json:"next_page_state,omitempty"`
+	// More is true if there are more pages.
+	More bool `json:"more"`
+
+	// Rows is the number of rows in the page.
+	Rows int `json:"-"`
+	// Attempts is the number of attempts made to execute the query.
+	Attempts int `json:"-"`
+	// Latency is the average latency of the attempts.
+	Latency time.Duration `json:"-"`
+}
+
+// SelectPage scans a single page of results into dest, which must be
+// a pointer to slice, like Select. The page starting at the page state set
+// with PageState is fetched, or the first page if page state is not set.
+// Use PageSize to set the number of rows in the page.
+func (q *Queryx) SelectPage(dest interface{}) (*Page, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	// Setting page state disables auto paging.
+	q.PageState(q.pageState)
+
+	iter := q.Iter()
+	if err := iter.Select(dest); err != nil {
+		return nil, err
+	}
+
+	p := &Page{
+		Items:    dest,
+		Rows:     iter.rows,
+		Attempts: q.Attempts(),
+		Latency:  time.Duration(q.Latency()),
+	}
+	if state := iter.PageState(); len(state) > 0 {
+		p.NextPageState = state
+		p.More = true
+	}
+	return p, nil
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// PageTimeout sets a timeout of fetching a single page of the query results.
+// The query context deadline, set with WithContext, bounds the whole
+// iteration and applies to every page fetch with or without PageTimeout.
+// PageTimeout additionally bounds every page fetch by d.
+//
+// With PageTimeout pages are fetched by Iterx one by one with a new context
+// derived from the query context, the next page is fetched when the current
+// page is consumed. If PageState is set only that single page is fetched.
+func (q *Queryx) PageTimeout(d time.Duration) *Queryx {
+	q.pageTimeout = d
+	return q
+}
+
+// pager fetches pages of a query one by one, every page with its own
+// timeout if timeout is set. Pages are not prefetched.
+type pager struct {
+	query   *gocql.Query
+	timeout time.Duration
+	single  bool
+	cancel  context.CancelFunc
+}
+
+// fetch fetches the page starting at state.
+func (p *pager) fetch(state []byte) *gocql.Iter {
+	return p.query.WithContext(p.pageContext()).PageState(state).Iter()
+}
+
+// pageContext returns a context for fetching the next page, it cancels the
+// context of the previous page.
+func (p *pager) pageContext() context.Context {
+	p.close()
+	var ctx context.Context
+	if p.timeout > 0 {
+		ctx, p.cancel = context.WithTimeout(p.query.Context(), p.timeout)
+	} else {
+		ctx, p.cancel = context.WithCancel(p.query.Context())
+	}
+	return ctx
+}
+
+func (p *pager) close() {
+	if p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+}
+
+// scanRow is like gocql.Iter.Scan but with a pager it fetches the next page
+// when the current page is consumed. It enforces MaxRows, MaxPages and stops
+// when the iterator context is done.
+func (iter *Iterx) scanRow(dest ...interface{}) bool {
+	if iter.ctx != nil {
+		if err := iter.ctx.Err(); err != nil {
+			iter.err = err
+			return false
+		}
+	}
+	for !iter.Iter.Scan(dest...) {
+		if iter.pager == nil || iter.pager.single {
+			return false
+		}
+		state := iter.Iter.PageState()
+		if len(state) == 0 || iter.Iter.Close() != nil || !iter.nextPage() {
+			return false
+		}
+		iter.Iter = iter.pager.fetch(state)
+	}
+
+	iter.rows++
+	if iter.maxRows > 0 && iter.rows > iter.maxRows {
+		iter.err = &LimitExceededError{Limit: "rows", Max: iter.maxRows}
+		return false
+	}
+	return true
+}
+
+// nextPage counts a page that is about to be fetched.
+func (iter *Iterx) nextPage() bool {
+	iter.pages++
+	if iter.maxPages > 0 && iter.pages >= iter.maxPages {
+		iter.err = &LimitExceededError{Limit: "pages", Max: iter.maxPages}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+func TestPagerPageContext(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	p := &pager{
+		query:   new(gocql.Query).WithContext(parent),
+		timeout: time.Minute,
+	}
+
+	first := p.pageContext()
+	deadline, ok := first.Deadline()
+	if !ok || time.Until(deadline) > time.Minute {
+		t.Fatalf("Deadline()=%s, expected page timeout", deadline)
+	}
+
+	second := p.pageContext()
+	if first.Err() != context.Canceled {
+		t.Fatal("previous page context not canceled")
+	}
+	if second.Err() != nil {
+		t.Fatal("page context canceled")
+	}
+
+	cancel()
+	if second.Err() != context.Canceled {
+		t.Fatal("page context not canceled with query context")
+	}
+
+	p.close()
+	if p.cancel != nil {
+		t.Fatal("close() expected cancel to be reset")
+	}
+}
+
+func TestPagerPageContextQueryDeadline(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	p := &pager{
+		query:   new(gocql.Query).WithContext(parent),
+		timeout: time.Hour,
+	}
+	defer p.close()
+
+	expected, _ := parent.Deadline()
+	if deadline, _ := p.pageContext().Deadline(); !deadline.Equal(expected) {
+		t.Fatalf("Deadline()=%s, expected query deadline %s", deadline, expected)
+	}
+}
+
+func TestPagerPageContextNoTimeout(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := &pager{
+		query: new(gocql.Query).WithContext(parent),
+	}
+	defer p.close()
+
+	ctx := p.pageContext()
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatal("Deadline() expected no deadline")
+	}
+	if ctx.Err() != nil {
+		t.Fatal("page context canceled")
+	}
+	cancel()
+	if ctx.Err() != context.Canceled {
+		t.Fatal("page context not canceled with query context")
+	}
+}
+
+func TestIterxWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	iter := (&Iterx{Iter: new(gocql.Iter)}).WithContext(ctx)
+	if iter.Scan() {
+		t.Fatal("Scan() expected false")
+	}
+	if err := iter.Close(); err != context.Canceled {
+		t.Fatalf("Close() error=%v, expected %v", err, context.Canceled)
+	}
+}
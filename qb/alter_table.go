@@ -0,0 +1,183 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+// ALTER TABLE reference:
+// https://cassandra.apache.org/doc/latest/cql/ddl.html#alter-table
+
+import (
+	"bytes"
+)
+
+// rename specifies a column rename in an ALTER statement.
+type rename struct {
+	from string
+	to   string
+}
+
+type renames []rename
+
+func (rs renames) writeCql(cql *bytes.Buffer) {
+	for i, r := range rs {
+		writeIdent(cql, r.from)
+		cql.WriteString(" TO ")
+		writeIdent(cql, r.to)
+		if i < len(rs)-1 {
+			cql.WriteString(" AND ")
+		}
+	}
+}
+
+// AlterTableBuilder builds CQL ALTER TABLE statements. A statement can either
+// add columns, drop columns, rename columns or change table options, use
+// ToCqls to build one statement per operation if they are mixed.
+type AlterTableBuilder struct {
+	table   string
+	add     []columnDef
+	drop    columns
+	rename  renames
+	options options
+}
+
+// AlterTable returns a new AlterTableBuilder with the given table name.
+func AlterTable(table string) *AlterTableBuilder {
+	return &AlterTableBuilder{
+		table: table,
+	}
+}
+
+// ToCql builds the query into a CQL string and named args. If operations are
+// mixed the statement is not valid, use ToCqls.
+func (b *AlterTableBuilder) ToCql() (stmt string, names []string) {
+	cql := bytes.Buffer{}
+
+	b.writeTable(&cql)
+	b.writeAdd(&cql)
+	b.writeDrop(&cql)
+	b.writeRename(&cql)
+	b.writeOptions(&cql)
+
+	stmt = cql.String()
+	return
+}
+
+// ToCqls builds the query into CQL strings, one statement for each of ADD,
+// DROP, RENAME and WITH operations in that order.
+func (b *AlterTableBuilder) ToCqls() (stmts []string) {
+	for _, w := range []func(cql *bytes.Buffer) bool{
+		b.writeAdd,
+		b.writeDrop,
+		b.writeRename,
+		b.writeOptions,
+	} {
+		cql := bytes.Buffer{}
+		b.writeTable(&cql)
+		if w(&cql) {
+			stmts = append(stmts, cql.String())
+		}
+	}
+	return
+}
+
+func (b *AlterTableBuilder) writeTable(cql *bytes.Buffer) {
+	cql.WriteString("ALTER TABLE ")
+	writeIdent(cql, b.table)
+	cql.WriteByte(' ')
+}
+
+func (b *AlterTableBuilder) writeAdd(cql *bytes.Buffer) bool {
+	if len(b.add) == 0 {
+		return false
+	}
+	cql.WriteString("ADD ")
+	if len(b.add) > 1 {
+		cql.WriteByte('(')
+	}
+	for i, c := range b.add {
+		c.writeCql(cql)
+		if i < len(b.add)-1 {
+			cql.WriteByte(',')
+		}
+	}
+	if len(b.add) > 1 {
+		cql.WriteByte(')')
+	}
+	cql.WriteByte(' ')
+	return true
+}
+
+func (b *AlterTableBuilder) writeDrop(cql *bytes.Buffer) bool {
+	if len(b.drop) == 0 {
+		return false
+	}
+	cql.WriteString("DROP ")
+	if len(b.drop) > 1 {
+		cql.WriteByte('(')
+	}
+	b.drop.writeCql(cql)
+	if len(b.drop) > 1 {
+		cql.WriteByte(')')
+	}
+	cql.WriteByte(' ')
+	return true
+}
+
+func (b *AlterTableBuilder) writeRename(cql *bytes.Buffer) bool {
+	if len(b.rename) == 0 {
+		return false
+	}
+	cql.WriteString("RENAME ")
+	b.rename.writeCql(cql)
+	cql.WriteByte(' ')
+	return true
+}
+
+func (b *AlterTableBuilder) writeOptions(cql *bytes.Buffer) bool {
+	if len(b.options) == 0 {
+		return false
+	}
+	cql.WriteString("WITH ")
+	b.options.writeCql(cql)
+	cql.WriteByte(' ')
+	return true
+}
+
+// Add adds an ADD column clause with a CQL type to the query.
+func (b *AlterTableBuilder) Add(column, cqlType string) *AlterTableBuilder {
+	b.add = append(b.add, columnDef{name: column, typ: cqlType})
+	return b
+}
+
+// AddStatic adds an ADD column clause for a STATIC column to the query.
+func (b *AlterTableBuilder) AddStatic(column, cqlType string) *AlterTableBuilder {
+	b.add = append(b.add, columnDef{name: column, typ: cqlType, static: true})
+	return b
+}
+
+// Drop adds DROP columns clause to the query.
+func (b *AlterTableBuilder) Drop(columns ...string) *AlterTableBuilder {
+	b.drop = append(b.drop, columns...)
+	return b
+}
+
+// Rename adds a RENAME column TO name clause to the query, only primary key
+// columns can be renamed.
+func (b *AlterTableBuilder) Rename(column, name string) *AlterTableBuilder {
+	b.rename = append(b.rename, rename{from: column, to: name})
+	return b
+}
+
+// With adds a table option to the WITH clause of the query. The value is
+// a CQL literal i.e. "'a comment'" or "{'class': 'LeveledCompactionStrategy'}".
+func (b *AlterTableBuilder) With(name, value string) *AlterTableBuilder {
+	b.options = append(b.options, option{name: name, value: value})
+	return b
+}
+
+// PerPartitionRateLimit adds a per_partition_rate_limit option to the query,
+// zero value leaves the limit unset.
+func (b *AlterTableBuilder) PerPartitionRateLimit(maxReadsPerSecond, maxWritesPerSecond int) *AlterTableBuilder {
+	return b.With("per_partition_rate_limit", perPartitionRateLimit(maxReadsPerSecond, maxWritesPerSecond))
+}
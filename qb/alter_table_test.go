@@ -0,0 +1,101 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAlterTableBuilder(t *testing.T) {
+	table := []struct {
+		B *AlterTableBuilder
+		N []string
+		S string
+	}{
+		// Add column
+		{
+			B: AlterTable("cycling.cyclist_name").Add("age", "int"),
+			S: "ALTER TABLE cycling.cyclist_name ADD age int ",
+		},
+		// Add multiple columns
+		{
+			B: AlterTable("cycling.cyclist_name").Add("age", "int").AddStatic("team", "text"),
+			S: "ALTER TABLE cycling.cyclist_name ADD (age int,team text STATIC) ",
+		},
+		// Drop column
+		{
+			B: AlterTable("cycling.cyclist_name").Drop("age"),
+			S: "ALTER TABLE cycling.cyclist_name DROP age ",
+		},
+		// Drop multiple columns
+		{
+			B: AlterTable("cycling.cyclist_name").Drop("age", "team"),
+			S: "ALTER TABLE cycling.cyclist_name DROP (age,team) ",
+		},
+		// Rename columns
+		{
+			B: AlterTable("cycling.cyclist_name").Rename("id", "cyclist_id").Rename("race", "race_id"),
+			S: "ALTER TABLE cycling.cyclist_name RENAME id TO cyclist_id AND race TO race_id ",
+		},
+		// Change options
+		{
+			B: AlterTable("cycling.cyclist_name").With("comment", "'cyclists'").With("gc_grace_seconds", "0"),
+			S: "ALTER TABLE cycling.cyclist_name WITH comment='cyclists' AND gc_grace_seconds=0 ",
+		},
+		// Change per partition rate limit
+		{
+			B: AlterTable("cycling.cyclist_name").PerPartitionRateLimit(0, 50),
+			S: "ALTER TABLE cycling.cyclist_name WITH per_partition_rate_limit={'max_writes_per_second': 50} ",
+		},
+	}
+
+	for _, test := range table {
+		stmt, names := test.B.ToCql()
+		if diff := cmp.Diff(test.S, stmt); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(test.N, names); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
+
+func TestAlterTableBuilderToCqls(t *testing.T) {
+	table := []struct {
+		B *AlterTableBuilder
+		S []string
+	}{
+		{
+			B: AlterTable("cycling.cyclist_name").Add("age", "int"),
+			S: []string{"ALTER TABLE cycling.cyclist_name ADD age int "},
+		},
+		{
+			B: AlterTable("cycling.cyclist_name").Drop("team").Add("age", "int"),
+			S: []string{
+				"ALTER TABLE cycling.cyclist_name ADD age int ",
+				"ALTER TABLE cycling.cyclist_name DROP team ",
+			},
+		},
+		{
+			B: AlterTable("cycling.cyclist_name").With("comment", "'cyclists'").Rename("id", "cyclist_id").AddStatic("team", "text"),
+			S: []string{
+				"ALTER TABLE cycling.cyclist_name ADD team text STATIC ",
+				"ALTER TABLE cycling.cyclist_name RENAME id TO cyclist_id ",
+				"ALTER TABLE cycling.cyclist_name WITH comment='cyclists' ",
+			},
+		},
+		{
+			B: AlterTable("cycling.cyclist_name"),
+		},
+	}
+
+	for _, test := range table {
+		if diff := cmp.Diff(test.S, test.B.ToCqls()); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+// ALTER TYPE reference:
+// https://cassandra.apache.org/doc/latest/cql/types.html#altering-a-udt
+
+import (
+	"bytes"
+)
+
+// AlterTypeBuilder builds CQL ALTER TYPE statements. A statement can either
+// add a field or rename fields, these operations cannot be mixed in a single
+// statement.
+type AlterTypeBuilder struct {
+	typ    string
+	add    *columnDef
+	rename renames
+}
+
+// AlterType returns a new AlterTypeBuilder with the given type name.
+func AlterType(typ string) *AlterTypeBuilder {
+	return &AlterTypeBuilder{
+		typ: typ,
+	}
+}
+
+// ToCql builds the query into a CQL string and named args.
+func (b *AlterTypeBuilder) ToCql() (stmt string, names []string) {
+	cql := bytes.Buffer{}
+
+	cql.WriteString("ALTER TYPE ")
+	writeIdent(&cql, b.typ)
+	cql.WriteByte(' ')
+
+	if b.add != nil {
+		cql.WriteString("ADD ")
+		b.add.writeCql(&cql)
+		cql.WriteByte(' ')
+	}
+
+	if len(b.rename) > 0 {
+		cql.WriteString("RENAME ")
+		b.rename.writeCql(&cql)
+		cql.WriteByte(' ')
+	}
+
+	stmt = cql.String()
+	return
+}
+
+// Add sets an ADD field clause with a CQL type on the query, only one field
+// can be added in a single statement.
+func (b *AlterTypeBuilder) Add(field, cqlType string) *AlterTypeBuilder {
+	b.add = &columnDef{name: field, typ: cqlType}
+	return b
+}
+
+// Rename adds a RENAME field TO name clause to the query.
+func (b *AlterTypeBuilder) Rename(field, name string) *AlterTypeBuilder {
+	b.rename = append(b.rename, rename{from: field, to: name})
+	return b
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAlterTypeBuilder(t *testing.T) {
+	table := []struct {
+		B *AlterTypeBuilder
+		N []string
+		S string
+	}{
+		// Add field
+		{
+			B: AlterType("cycling.address").Add("country", "text"),
+			S: "ALTER TYPE cycling.address ADD country text ",
+		},
+		// Rename field
+		{
+			B: AlterType("cycling.address").Rename("zip", "zip_code"),
+			S: "ALTER TYPE cycling.address RENAME zip TO zip_code ",
+		},
+		// Rename multiple fields
+		{
+			B: AlterType("cycling.address").Rename("zip", "zip_code").Rename("street", "street_name"),
+			S: "ALTER TYPE cycling.address RENAME zip TO zip_code AND street TO street_name ",
+		},
+	}
+
+	for _, test := range table {
+		stmt, names := test.B.ToCql()
+		if diff := cmp.Diff(test.S, stmt); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(test.N, names); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
@@ -29,6 +29,15 @@ func Batch() *BatchBuilder {
 	return &BatchBuilder{}
 }
 
+// Clone returns a copy of the builder. A base builder can be shared and
+// specialized with clones without affecting the base or other clones.
+func (b *BatchBuilder) Clone() *BatchBuilder {
+	c := *b
+	c.stmts = append([]string(nil), b.stmts...)
+	c.names = append([]string(nil), b.names...)
+	return &c
+}
+
 // ToCql builds the query into a CQL string and named args.
 func (b *BatchBuilder) ToCql() (stmt string, names []string) {
 	cql := bytes.Buffer{}
@@ -22,17 +22,25 @@ const (
 	cnt
 	cntKey
 	like
+	notNull
+	rawOp
 )
 
 // Cmp if a filtering comparator that is used in WHERE and IF clauses.
 type Cmp struct {
 	op     op
 	column string
+	key    value // The collection element key, optional.
 	value  value
 }
 
 func (c Cmp) writeCql(cql *bytes.Buffer) (names []string) {
-	cql.WriteString(c.column)
+	writeIdent(cql, c.column)
+	if c.key != nil {
+		cql.WriteByte('[')
+		names = append(names, c.key.writeCql(cql)...)
+		cql.WriteByte(']')
+	}
 	switch c.op {
 	case eq:
 		cql.WriteByte('=')
@@ -56,8 +64,10 @@ func (c Cmp) writeCql(cql *bytes.Buffer) (names []string) {
 		cql.WriteString(" CONTAINS KEY ")
 	case like:
 		cql.WriteString(" LIKE ")
+	case notNull:
+		cql.WriteString(" IS NOT NULL")
 	}
-	return c.value.writeCql(cql)
+	return append(names, c.value.writeCql(cql)...)
 }
 
 // Eq produces column=?.
@@ -108,6 +118,34 @@ func EqFunc(column string, fn *Func) Cmp {
 	}
 }
 
+// EqElement produces column[?]=?, it compares a map entry for a key i.e. on
+// a map column indexed by entries. The key parameter name is column_key.
+func EqElement(column string) Cmp {
+	return EqElementNamed(column, column+"_key", column)
+}
+
+// EqElementNamed produces column[?]=? with custom key and value parameter
+// names.
+func EqElementNamed(column, keyName, valueName string) Cmp {
+	return Cmp{
+		op:     eq,
+		column: column,
+		key:    param(keyName),
+		value:  param(valueName),
+	}
+}
+
+// EqElementLit produces column[key]=literal with literal key and value and
+// does not add parameters to the query.
+func EqElementLit(column, key, literal string) Cmp {
+	return Cmp{
+		op:     eq,
+		column: column,
+		key:    lit(key),
+		value:  lit(literal),
+	}
+}
+
 // Ne produces column!=?.
 func Ne(column string) Cmp {
 	return Cmp{
@@ -477,6 +515,106 @@ func LikeTuple(column string, count int) Cmp {
 	}
 }
 
+// TimeuuidRange produces column>maxTimeuuid(?) AND column<minTimeuuid(?)
+// selecting timeuuids generated after one point in time and before another,
+// the bound values shall be timestamps. Parameter names are column_from and
+// column_to.
+func TimeuuidRange(column string) []Cmp {
+	return TimeuuidRangeNamed(column, column+"_from", column+"_to")
+}
+
+// TimeuuidRangeNamed produces column>maxTimeuuid(?) AND
+// column<minTimeuuid(?) with custom parameter names.
+func TimeuuidRangeNamed(column, from, to string) []Cmp {
+	return []Cmp{
+		GtFunc(column, MaxTimeuuid(from)),
+		LtFunc(column, MinTimeuuid(to)),
+	}
+}
+
+// RawCmp produces a raw CQL relation i.e. "(a,b) > (:a,:b)", named
+// parameters in form of :name are replaced with ? and added to query names.
+// It allows for using CQL features not supported by the builders.
+func RawCmp(cql string) Cmp {
+	return Cmp{
+		op:    rawOp,
+		value: raw(cql),
+	}
+}
+
+// IsNotNull produces column IS NOT NULL, it's used in materialized view
+// definitions.
+func IsNotNull(column string) Cmp {
+	return Cmp{
+		op:     notNull,
+		column: column,
+		value:  lit(""),
+	}
+}
+
+// TupleEq produces (column1,column2,...)=(?,?,...).
+func TupleEq(columns []string) Cmp {
+	return tupleCmp(eq, columns, columns)
+}
+
+// TupleEqNamed produces (column1,column2,...)=(?,?,...) with custom parameter
+// names.
+func TupleEqNamed(columns, names []string) Cmp {
+	return tupleCmp(eq, columns, names)
+}
+
+// TupleLt produces (column1,column2,...)<(?,?,...).
+func TupleLt(columns []string) Cmp {
+	return tupleCmp(lt, columns, columns)
+}
+
+// TupleLtNamed produces (column1,column2,...)<(?,?,...) with custom parameter
+// names.
+func TupleLtNamed(columns, names []string) Cmp {
+	return tupleCmp(lt, columns, names)
+}
+
+// TupleLtOrEq produces (column1,column2,...)<=(?,?,...).
+func TupleLtOrEq(columns []string) Cmp {
+	return tupleCmp(leq, columns, columns)
+}
+
+// TupleLtOrEqNamed produces (column1,column2,...)<=(?,?,...) with custom
+// parameter names.
+func TupleLtOrEqNamed(columns, names []string) Cmp {
+	return tupleCmp(leq, columns, names)
+}
+
+// TupleGt produces (column1,column2,...)>(?,?,...).
+func TupleGt(columns []string) Cmp {
+	return tupleCmp(gt, columns, columns)
+}
+
+// TupleGtNamed produces (column1,column2,...)>(?,?,...) with custom parameter
+// names.
+func TupleGtNamed(columns, names []string) Cmp {
+	return tupleCmp(gt, columns, names)
+}
+
+// TupleGtOrEq produces (column1,column2,...)>=(?,?,...).
+func TupleGtOrEq(columns []string) Cmp {
+	return tupleCmp(geq, columns, columns)
+}
+
+// TupleGtOrEqNamed produces (column1,column2,...)>=(?,?,...) with custom
+// parameter names.
+func TupleGtOrEqNamed(columns, names []string) Cmp {
+	return tupleCmp(geq, columns, names)
+}
+
+func tupleCmp(op op, columns, names []string) Cmp {
+	return Cmp{
+		op:     op,
+		column: "(" + quoteIdents(columns) + ")",
+		value:  params(names),
+	}
+}
+
 type cmps []Cmp
 
 func (cs cmps) writeCql(cql *bytes.Buffer) (names []string) {
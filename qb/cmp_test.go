@@ -75,12 +75,12 @@ func TestCmp(t *testing.T) {
 		},
 		{
 			C: In("in"),
-			S: "in IN ?",
+			S: "\"in\" IN ?",
 			N: []string{"in"},
 		},
 		{
 			C: InTuple("in", 2),
-			S: "in IN (?,?)",
+			S: "\"in\" IN (?,?)",
 			N: []string{"in_0", "in_1"},
 		},
 		{
@@ -147,7 +147,7 @@ func TestCmp(t *testing.T) {
 		},
 		{
 			C: InNamed("in", "name"),
-			S: "in IN ?",
+			S: "\"in\" IN ?",
 			N: []string{"name"},
 		},
 		{
@@ -188,7 +188,7 @@ func TestCmp(t *testing.T) {
 		},
 		{
 			C: InLit("in", "litval"),
-			S: "in IN litval",
+			S: "\"in\" IN litval",
 		},
 		{
 			C: ContainsLit("cnt", "litval"),
@@ -215,6 +215,16 @@ func TestCmp(t *testing.T) {
 			C: EqFunc("eq", Now()),
 			S: "eq=now()",
 		},
+		{
+			C: LtFunc("lt", ToUnixTimestamp(MaxTimeuuid("arg0"))),
+			S: "lt<toUnixTimestamp(maxTimeuuid(?))",
+			N: []string{"arg0"},
+		},
+		{
+			C: EqFunc("eq", NestedFn("fn", Now(), Fn("f", "arg0"), UUID())),
+			S: "eq=fn(now(),f(?),uuid())",
+			N: []string{"arg0"},
+		},
 		{
 			C: NeFunc("ne", Fn("fn", "arg0", "arg1", "arg2")),
 			S: "ne!=fn(?,?,?)",
@@ -238,6 +248,96 @@ func TestCmp(t *testing.T) {
 			S: "eq>=maxTimeuuid(?)",
 			N: []string{"arg0"},
 		},
+
+		// Raw
+		{
+			C: RawCmp("(a,b) > (:a,:b)"),
+			S: "(a,b) > (?,?)",
+			N: []string{"a", "b"},
+		},
+		{
+			C: RawCmp("a=:a_1 AND b=':b' AND c=?"),
+			S: "a=? AND b=':b' AND c=?",
+			N: []string{"a_1"},
+		},
+		{
+			C: RawCmp("a = : AND b = :"),
+			S: "a = : AND b = :",
+		},
+
+		// Map entries
+		{
+			C: EqElement("m"),
+			S: "m[?]=?",
+			N: []string{"m_key", "m"},
+		},
+		{
+			C: EqElementNamed("m", "key", "value"),
+			S: "m[?]=?",
+			N: []string{"key", "value"},
+		},
+		{
+			C: EqElementLit("m", "'a'", "1"),
+			S: "m['a']=1",
+		},
+
+		// IS NOT NULL
+		{
+			C: IsNotNull("a"),
+			S: "a IS NOT NULL",
+		},
+
+		// Multi-column relations
+		{
+			C: TupleEq([]string{"a", "b"}),
+			S: "(a,b)=(?,?)",
+			N: []string{"a", "b"},
+		},
+		{
+			C: TupleEqNamed([]string{"a", "b"}, []string{"c", "d"}),
+			S: "(a,b)=(?,?)",
+			N: []string{"c", "d"},
+		},
+		{
+			C: TupleLt([]string{"a", "b"}),
+			S: "(a,b)<(?,?)",
+			N: []string{"a", "b"},
+		},
+		{
+			C: TupleLtNamed([]string{"a", "b"}, []string{"c", "d"}),
+			S: "(a,b)<(?,?)",
+			N: []string{"c", "d"},
+		},
+		{
+			C: TupleLtOrEq([]string{"a", "b"}),
+			S: "(a,b)<=(?,?)",
+			N: []string{"a", "b"},
+		},
+		{
+			C: TupleLtOrEqNamed([]string{"a", "b"}, []string{"c", "d"}),
+			S: "(a,b)<=(?,?)",
+			N: []string{"c", "d"},
+		},
+		{
+			C: TupleGt([]string{"a", "b"}),
+			S: "(a,b)>(?,?)",
+			N: []string{"a", "b"},
+		},
+		{
+			C: TupleGtNamed([]string{"a", "b"}, []string{"c", "d"}),
+			S: "(a,b)>(?,?)",
+			N: []string{"c", "d"},
+		},
+		{
+			C: TupleGtOrEq([]string{"a", "b", "c"}),
+			S: "(a,b,c)>=(?,?,?)",
+			N: []string{"a", "b", "c"},
+		},
+		{
+			C: TupleGtOrEqNamed([]string{"a", "b"}, []string{"c", "d"}),
+			S: "(a,b)>=(?,?)",
+			N: []string{"c", "d"},
+		},
 	}
 
 	buf := bytes.Buffer{}
@@ -0,0 +1,142 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+// CREATE INDEX reference:
+// https://cassandra.apache.org/doc/latest/cql/indexes.html#create-index
+
+import (
+	"bytes"
+)
+
+// Index classes for use with CreateIndexBuilder.Using.
+const (
+	// SAI is the storage attached index class.
+	SAI = "StorageAttachedIndex"
+	// SASI is the SSTable attached secondary index class.
+	SASI = "org.apache.cassandra.index.sasi.SASIIndex"
+)
+
+// CreateIndexBuilder builds CQL CREATE INDEX statements.
+type CreateIndexBuilder struct {
+	name        string
+	table       string
+	target      string
+	custom      bool
+	ifNotExists bool
+	using       string
+	options     options
+}
+
+// CreateIndex returns a new CreateIndexBuilder with the given index name, if
+// name is empty the index name is generated by the database.
+func CreateIndex(name string) *CreateIndexBuilder {
+	return &CreateIndexBuilder{
+		name: name,
+	}
+}
+
+// ToCql builds the query into a CQL string and named args.
+func (b *CreateIndexBuilder) ToCql() (stmt string, names []string) {
+	cql := bytes.Buffer{}
+
+	cql.WriteString("CREATE ")
+	if b.custom {
+		cql.WriteString("CUSTOM ")
+	}
+	cql.WriteString("INDEX ")
+	if b.ifNotExists {
+		cql.WriteString("IF NOT EXISTS ")
+	}
+	if b.name != "" {
+		writeIdent(&cql, b.name)
+		cql.WriteByte(' ')
+	}
+	cql.WriteString("ON ")
+	writeIdent(&cql, b.table)
+	cql.WriteString(" (")
+	cql.WriteString(b.target)
+	cql.WriteString(") ")
+
+	if b.using != "" {
+		cql.WriteString("USING '")
+		cql.WriteString(b.using)
+		cql.WriteString("' ")
+	}
+
+	if len(b.options) > 0 {
+		cql.WriteString("WITH OPTIONS = {")
+		for i, o := range b.options {
+			cql.WriteByte('\'')
+			cql.WriteString(o.name)
+			cql.WriteString("': '")
+			cql.WriteString(o.value)
+			cql.WriteByte('\'')
+			if i < len(b.options)-1 {
+				cql.WriteString(", ")
+			}
+		}
+		cql.WriteString("} ")
+	}
+
+	stmt = cql.String()
+	return
+}
+
+// On sets the indexed table and column.
+func (b *CreateIndexBuilder) On(table, column string) *CreateIndexBuilder {
+	b.table = table
+	b.target = quoteIdent(column)
+	return b
+}
+
+// OnKeys sets the indexed table and map column, the index is created on
+// the map keys.
+func (b *CreateIndexBuilder) OnKeys(table, column string) *CreateIndexBuilder {
+	return b.On(table, "KEYS("+quoteIdent(column)+")")
+}
+
+// OnValues sets the indexed table and collection column, the index is
+// created on the collection values.
+func (b *CreateIndexBuilder) OnValues(table, column string) *CreateIndexBuilder {
+	return b.On(table, "VALUES("+quoteIdent(column)+")")
+}
+
+// OnEntries sets the indexed table and map column, the index is created on
+// the map entries.
+func (b *CreateIndexBuilder) OnEntries(table, column string) *CreateIndexBuilder {
+	return b.On(table, "ENTRIES("+quoteIdent(column)+")")
+}
+
+// OnFull sets the indexed table and frozen collection column, the index is
+// created on the full collection value.
+func (b *CreateIndexBuilder) OnFull(table, column string) *CreateIndexBuilder {
+	return b.On(table, "FULL("+quoteIdent(column)+")")
+}
+
+// IfNotExists sets a IF NOT EXISTS clause on the query.
+func (b *CreateIndexBuilder) IfNotExists() *CreateIndexBuilder {
+	b.ifNotExists = true
+	return b
+}
+
+// Custom sets the CUSTOM INDEX clause on the query, it's required by some
+// index classes i.e. SASI.
+func (b *CreateIndexBuilder) Custom() *CreateIndexBuilder {
+	b.custom = true
+	return b
+}
+
+// Using sets a USING 'class' clause on the query, see SAI and SASI.
+func (b *CreateIndexBuilder) Using(class string) *CreateIndexBuilder {
+	b.using = class
+	return b
+}
+
+// Option adds an index option to the WITH OPTIONS clause of the query.
+func (b *CreateIndexBuilder) Option(name, value string) *CreateIndexBuilder {
+	b.options = append(b.options, option{name: name, value: value})
+	return b
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCreateIndexBuilder(t *testing.T) {
+	table := []struct {
+		B *CreateIndexBuilder
+		N []string
+		S string
+	}{
+		// Basic test for create index
+		{
+			B: CreateIndex("rank_idx").On("cycling.rank", "rank"),
+			S: "CREATE INDEX rank_idx ON cycling.rank (rank) ",
+		},
+		// Generated name
+		{
+			B: CreateIndex("").On("cycling.rank", "rank"),
+			S: "CREATE INDEX ON cycling.rank (rank) ",
+		},
+		// Add IF NOT EXISTS
+		{
+			B: CreateIndex("rank_idx").IfNotExists().On("cycling.rank", "rank"),
+			S: "CREATE INDEX IF NOT EXISTS rank_idx ON cycling.rank (rank) ",
+		},
+		// Map keys
+		{
+			B: CreateIndex("teams_idx").OnKeys("cycling.cyclist", "teams"),
+			S: "CREATE INDEX teams_idx ON cycling.cyclist (KEYS(teams)) ",
+		},
+		// Collection values
+		{
+			B: CreateIndex("teams_idx").OnValues("cycling.cyclist", "teams"),
+			S: "CREATE INDEX teams_idx ON cycling.cyclist (VALUES(teams)) ",
+		},
+		// Map entries
+		{
+			B: CreateIndex("teams_idx").OnEntries("cycling.cyclist", "teams"),
+			S: "CREATE INDEX teams_idx ON cycling.cyclist (ENTRIES(teams)) ",
+		},
+		// Frozen collection
+		{
+			B: CreateIndex("races_idx").OnFull("cycling.cyclist", "races"),
+			S: "CREATE INDEX races_idx ON cycling.cyclist (FULL(races)) ",
+		},
+		// SAI
+		{
+			B: CreateIndex("name_idx").On("cycling.cyclist", "name").Using(SAI),
+			S: "CREATE INDEX name_idx ON cycling.cyclist (name) USING 'StorageAttachedIndex' ",
+		},
+		// SASI with options
+		{
+			B: CreateIndex("name_idx").Custom().On("cycling.cyclist", "name").Using(SASI).
+				Option("mode", "CONTAINS").Option("case_sensitive", "false"),
+			S: "CREATE CUSTOM INDEX name_idx ON cycling.cyclist (name) USING 'org.apache.cassandra.index.sasi.SASIIndex' " +
+				"WITH OPTIONS = {'mode': 'CONTAINS', 'case_sensitive': 'false'} ",
+		},
+	}
+
+	for _, test := range table {
+		stmt, names := test.B.ToCql()
+		if diff := cmp.Diff(test.S, stmt); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(test.N, names); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
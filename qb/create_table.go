@@ -0,0 +1,175 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+// CREATE TABLE reference:
+// https://cassandra.apache.org/doc/latest/cql/ddl.html#create-table
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// columnDef is a column definition in a CREATE TABLE statement.
+type columnDef struct {
+	name   string
+	typ    string
+	static bool
+}
+
+func (c columnDef) writeCql(cql *bytes.Buffer) {
+	writeIdent(cql, c.name)
+	cql.WriteByte(' ')
+	cql.WriteString(c.typ)
+	if c.static {
+		cql.WriteString(" STATIC")
+	}
+}
+
+// CreateTableBuilder builds CQL CREATE TABLE statements.
+type CreateTableBuilder struct {
+	table       string
+	ifNotExists bool
+	columns     []columnDef
+	partKey     columns
+	sortKey     columns
+	order       columns
+	options     options
+}
+
+// CreateTable returns a new CreateTableBuilder with the given table name.
+func CreateTable(table string) *CreateTableBuilder {
+	return &CreateTableBuilder{
+		table: table,
+	}
+}
+
+// ToCql builds the query into a CQL string and named args.
+func (b *CreateTableBuilder) ToCql() (stmt string, names []string) {
+	cql := bytes.Buffer{}
+
+	cql.WriteString("CREATE TABLE ")
+	if b.ifNotExists {
+		cql.WriteString("IF NOT EXISTS ")
+	}
+	writeIdent(&cql, b.table)
+	cql.WriteString(" (")
+	for _, c := range b.columns {
+		c.writeCql(&cql)
+		cql.WriteByte(',')
+	}
+	cql.WriteString("PRIMARY KEY (")
+	writePrimaryKey(&cql, b.partKey, b.sortKey)
+	cql.WriteString(")) ")
+
+	writeTableOptions(&cql, b.order, b.options)
+
+	stmt = cql.String()
+	return
+}
+
+// writeTableOptions writes WITH clause with clustering order and options.
+func writeTableOptions(cql *bytes.Buffer, order columns, opts options) {
+	if len(order) == 0 && len(opts) == 0 {
+		return
+	}
+
+	cql.WriteString("WITH ")
+	if len(order) > 0 {
+		cql.WriteString("CLUSTERING ORDER BY (")
+		order.writeCql(cql)
+		cql.WriteByte(')')
+		if len(opts) > 0 {
+			cql.WriteString(" AND ")
+		}
+	}
+	opts.writeCql(cql)
+	cql.WriteByte(' ')
+}
+
+// writePrimaryKey writes primary key columns, the partition key is enclosed
+// in parentheses if it's composite.
+func writePrimaryKey(cql *bytes.Buffer, partKey, sortKey columns) {
+	if len(partKey) > 1 {
+		cql.WriteByte('(')
+		partKey.writeCql(cql)
+		cql.WriteByte(')')
+	} else {
+		partKey.writeCql(cql)
+	}
+	if len(sortKey) > 0 {
+		cql.WriteByte(',')
+		sortKey.writeCql(cql)
+	}
+}
+
+// IfNotExists sets a IF NOT EXISTS clause on the query.
+func (b *CreateTableBuilder) IfNotExists() *CreateTableBuilder {
+	b.ifNotExists = true
+	return b
+}
+
+// Column adds a column definition with a CQL type i.e. "text" or
+// "map<text, int>" to the query.
+func (b *CreateTableBuilder) Column(column, cqlType string) *CreateTableBuilder {
+	b.columns = append(b.columns, columnDef{name: column, typ: cqlType})
+	return b
+}
+
+// StaticColumn adds a STATIC column definition to the query.
+func (b *CreateTableBuilder) StaticColumn(column, cqlType string) *CreateTableBuilder {
+	b.columns = append(b.columns, columnDef{name: column, typ: cqlType, static: true})
+	return b
+}
+
+// PartitionKey sets partition key columns of the table.
+func (b *CreateTableBuilder) PartitionKey(columns ...string) *CreateTableBuilder {
+	b.partKey = columns
+	return b
+}
+
+// ClusteringKey sets clustering columns of the table.
+func (b *CreateTableBuilder) ClusteringKey(columns ...string) *CreateTableBuilder {
+	b.sortKey = columns
+	return b
+}
+
+// ClusteringOrder adds a column to the CLUSTERING ORDER BY option of the query.
+func (b *CreateTableBuilder) ClusteringOrder(column string, o Order) *CreateTableBuilder {
+	b.order = append(b.order, quoteIdent(column)+" "+o.String())
+	return b
+}
+
+// With adds a table option to the WITH clause of the query. The value is
+// a CQL literal i.e. "'a comment'" or "{'class': 'LeveledCompactionStrategy'}".
+func (b *CreateTableBuilder) With(name, value string) *CreateTableBuilder {
+	b.options = append(b.options, option{name: name, value: value})
+	return b
+}
+
+// PerPartitionRateLimit adds a per_partition_rate_limit option to the query,
+// zero value leaves the limit unset.
+//
+// Per partition rate limit is a feature specific to ScyllaDB, use
+// gocqlx.Session.HasScyllaFeature to check if the cluster supports it.
+func (b *CreateTableBuilder) PerPartitionRateLimit(maxReadsPerSecond, maxWritesPerSecond int) *CreateTableBuilder {
+	return b.With("per_partition_rate_limit", perPartitionRateLimit(maxReadsPerSecond, maxWritesPerSecond))
+}
+
+func perPartitionRateLimit(maxReadsPerSecond, maxWritesPerSecond int) string {
+	cql := bytes.Buffer{}
+	cql.WriteByte('{')
+	if maxReadsPerSecond > 0 {
+		fmt.Fprintf(&cql, "'max_reads_per_second': %d", maxReadsPerSecond)
+		if maxWritesPerSecond > 0 {
+			cql.WriteString(", ")
+		}
+	}
+	if maxWritesPerSecond > 0 {
+		fmt.Fprintf(&cql, "'max_writes_per_second': %d", maxWritesPerSecond)
+	}
+	cql.WriteByte('}')
+	return cql.String()
+}
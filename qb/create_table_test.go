@@ -0,0 +1,87 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCreateTableBuilder(t *testing.T) {
+	table := []struct {
+		B *CreateTableBuilder
+		N []string
+		S string
+	}{
+		// Basic test for create table
+		{
+			B: CreateTable("cycling.cyclist_name").Column("id", "uuid").Column("firstname", "text").PartitionKey("id"),
+			S: "CREATE TABLE cycling.cyclist_name (id uuid,firstname text,PRIMARY KEY (id)) ",
+		},
+		// Add IF NOT EXISTS
+		{
+			B: CreateTable("cycling.cyclist_name").IfNotExists().Column("id", "uuid").PartitionKey("id"),
+			S: "CREATE TABLE IF NOT EXISTS cycling.cyclist_name (id uuid,PRIMARY KEY (id)) ",
+		},
+		// Add vector column
+		{
+			B: CreateTable("cycling.comments_vs").Column("id", "uuid").Column("comment_vector", VectorType("float", 5)).PartitionKey("id"),
+			S: "CREATE TABLE cycling.comments_vs (id uuid,comment_vector vector<float, 5>,PRIMARY KEY (id)) ",
+		},
+		// Add clustering key
+		{
+			B: CreateTable("cycling.rank").Column("race", "text").Column("rank", "int").Column("name", "text").
+				PartitionKey("race").ClusteringKey("rank", "name"),
+			S: "CREATE TABLE cycling.rank (race text,rank int,name text,PRIMARY KEY (race,rank,name)) ",
+		},
+		// Add composite partition key
+		{
+			B: CreateTable("cycling.rank").Column("race", "text").Column("year", "int").Column("rank", "int").
+				PartitionKey("race", "year").ClusteringKey("rank"),
+			S: "CREATE TABLE cycling.rank (race text,year int,rank int,PRIMARY KEY ((race,year),rank)) ",
+		},
+		// Add static column
+		{
+			B: CreateTable("cycling.rank").Column("race", "text").StaticColumn("sponsor", "text").Column("rank", "int").
+				PartitionKey("race").ClusteringKey("rank"),
+			S: "CREATE TABLE cycling.rank (race text,sponsor text STATIC,rank int,PRIMARY KEY (race,rank)) ",
+		},
+		// Add CLUSTERING ORDER
+		{
+			B: CreateTable("cycling.rank").Column("race", "text").Column("rank", "int").Column("name", "text").
+				PartitionKey("race").ClusteringKey("rank", "name").ClusteringOrder("rank", DESC).ClusteringOrder("name", ASC),
+			S: "CREATE TABLE cycling.rank (race text,rank int,name text,PRIMARY KEY (race,rank,name)) WITH CLUSTERING ORDER BY (rank DESC,name ASC) ",
+		},
+		// Add options
+		{
+			B: CreateTable("cycling.cyclist_name").Column("id", "uuid").PartitionKey("id").
+				With("comment", "'cyclists'").With("gc_grace_seconds", "0"),
+			S: "CREATE TABLE cycling.cyclist_name (id uuid,PRIMARY KEY (id)) WITH comment='cyclists' AND gc_grace_seconds=0 ",
+		},
+		// Add CLUSTERING ORDER and options
+		{
+			B: CreateTable("cycling.rank").Column("race", "text").Column("rank", "int").
+				PartitionKey("race").ClusteringKey("rank").ClusteringOrder("rank", DESC).With("comment", "'rank'"),
+			S: "CREATE TABLE cycling.rank (race text,rank int,PRIMARY KEY (race,rank)) WITH CLUSTERING ORDER BY (rank DESC) AND comment='rank' ",
+		},
+		// Add per partition rate limit
+		{
+			B: CreateTable("cycling.cyclist_name").Column("id", "uuid").PartitionKey("id").PerPartitionRateLimit(100, 50),
+			S: "CREATE TABLE cycling.cyclist_name (id uuid,PRIMARY KEY (id)) " +
+				"WITH per_partition_rate_limit={'max_reads_per_second': 100, 'max_writes_per_second': 50} ",
+		},
+	}
+
+	for _, test := range table {
+		stmt, names := test.B.ToCql()
+		if diff := cmp.Diff(test.S, stmt); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(test.N, names); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
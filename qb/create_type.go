@@ -0,0 +1,60 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+// CREATE TYPE reference:
+// https://cassandra.apache.org/doc/latest/cql/types.html#creating-a-udt
+
+import (
+	"bytes"
+)
+
+// CreateTypeBuilder builds CQL CREATE TYPE statements for user defined types.
+type CreateTypeBuilder struct {
+	typ         string
+	ifNotExists bool
+	fields      []columnDef
+}
+
+// CreateType returns a new CreateTypeBuilder with the given type name.
+func CreateType(typ string) *CreateTypeBuilder {
+	return &CreateTypeBuilder{
+		typ: typ,
+	}
+}
+
+// ToCql builds the query into a CQL string and named args.
+func (b *CreateTypeBuilder) ToCql() (stmt string, names []string) {
+	cql := bytes.Buffer{}
+
+	cql.WriteString("CREATE TYPE ")
+	if b.ifNotExists {
+		cql.WriteString("IF NOT EXISTS ")
+	}
+	writeIdent(&cql, b.typ)
+	cql.WriteString(" (")
+	for i, f := range b.fields {
+		f.writeCql(&cql)
+		if i < len(b.fields)-1 {
+			cql.WriteByte(',')
+		}
+	}
+	cql.WriteString(") ")
+
+	stmt = cql.String()
+	return
+}
+
+// IfNotExists sets a IF NOT EXISTS clause on the query.
+func (b *CreateTypeBuilder) IfNotExists() *CreateTypeBuilder {
+	b.ifNotExists = true
+	return b
+}
+
+// Field adds a field with a CQL type to the type definition.
+func (b *CreateTypeBuilder) Field(name, cqlType string) *CreateTypeBuilder {
+	b.fields = append(b.fields, columnDef{name: name, typ: cqlType})
+	return b
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCreateTypeBuilder(t *testing.T) {
+	table := []struct {
+		B *CreateTypeBuilder
+		N []string
+		S string
+	}{
+		// Basic test for create type
+		{
+			B: CreateType("cycling.address").Field("street", "text").Field("city", "text").Field("zip", "int"),
+			S: "CREATE TYPE cycling.address (street text,city text,zip int) ",
+		},
+		// Add IF NOT EXISTS
+		{
+			B: CreateType("cycling.address").IfNotExists().Field("phones", "frozen<list<text>>"),
+			S: "CREATE TYPE IF NOT EXISTS cycling.address (phones frozen<list<text>>) ",
+		},
+	}
+
+	for _, test := range table {
+		stmt, names := test.B.ToCql()
+		if diff := cmp.Diff(test.S, stmt); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(test.N, names); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
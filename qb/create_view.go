@@ -0,0 +1,126 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+// CREATE MATERIALIZED VIEW reference:
+// https://cassandra.apache.org/doc/latest/cql/mvs.html#create-materialized-view
+
+import (
+	"bytes"
+)
+
+// CreateViewBuilder builds CQL CREATE MATERIALIZED VIEW statements.
+type CreateViewBuilder struct {
+	view        string
+	ifNotExists bool
+	table       string
+	columns     columns
+	where       where
+	partKey     columns
+	sortKey     columns
+	order       columns
+	options     options
+}
+
+// CreateView returns a new CreateViewBuilder with the given view name.
+func CreateView(view string) *CreateViewBuilder {
+	return &CreateViewBuilder{
+		view: view,
+	}
+}
+
+// ToCql builds the query into a CQL string and named args.
+//
+// If no WHERE expressions were added, the view is restricted with
+// column IS NOT NULL for every primary key column of the view.
+func (b *CreateViewBuilder) ToCql() (stmt string, names []string) {
+	cql := bytes.Buffer{}
+
+	cql.WriteString("CREATE MATERIALIZED VIEW ")
+	if b.ifNotExists {
+		cql.WriteString("IF NOT EXISTS ")
+	}
+	writeIdent(&cql, b.view)
+	cql.WriteString(" AS SELECT ")
+	if len(b.columns) == 0 {
+		cql.WriteByte('*')
+	} else {
+		b.columns.writeCql(&cql)
+	}
+	cql.WriteString(" FROM ")
+	writeIdent(&cql, b.table)
+	cql.WriteByte(' ')
+
+	w := b.where
+	if len(w) == 0 {
+		for _, c := range b.partKey {
+			w = append(w, IsNotNull(c))
+		}
+		for _, c := range b.sortKey {
+			w = append(w, IsNotNull(c))
+		}
+	}
+	names = w.writeCql(&cql)
+
+	cql.WriteString("PRIMARY KEY (")
+	writePrimaryKey(&cql, b.partKey, b.sortKey)
+	cql.WriteString(") ")
+
+	writeTableOptions(&cql, b.order, b.options)
+
+	stmt = cql.String()
+	return
+}
+
+// IfNotExists sets a IF NOT EXISTS clause on the query.
+func (b *CreateViewBuilder) IfNotExists() *CreateViewBuilder {
+	b.ifNotExists = true
+	return b
+}
+
+// From sets the base table of the view.
+func (b *CreateViewBuilder) From(table string) *CreateViewBuilder {
+	b.table = table
+	return b
+}
+
+// Columns adds columns selected from the base table, by default all columns
+// are selected.
+func (b *CreateViewBuilder) Columns(columns ...string) *CreateViewBuilder {
+	b.columns = append(b.columns, columns...)
+	return b
+}
+
+// Where adds an expression to the WHERE clause of the query. Expressions are
+// ANDed together in the generated CQL.
+func (b *CreateViewBuilder) Where(w ...Cmp) *CreateViewBuilder {
+	b.where = append(b.where, w...)
+	return b
+}
+
+// PartitionKey sets partition key columns of the view.
+func (b *CreateViewBuilder) PartitionKey(columns ...string) *CreateViewBuilder {
+	b.partKey = columns
+	return b
+}
+
+// ClusteringKey sets clustering columns of the view.
+func (b *CreateViewBuilder) ClusteringKey(columns ...string) *CreateViewBuilder {
+	b.sortKey = columns
+	return b
+}
+
+// ClusteringOrder adds a column to the CLUSTERING ORDER BY option of the query.
+func (b *CreateViewBuilder) ClusteringOrder(column string, o Order) *CreateViewBuilder {
+	b.order = append(b.order, quoteIdent(column)+" "+o.String())
+	return b
+}
+
+// With adds a view option to the WITH clause of the query. The value is
+// a CQL literal i.e. "'a comment'".
+func (b *CreateViewBuilder) With(name, value string) *CreateViewBuilder {
+	b.options = append(b.options, option{name: name, value: value})
+	return b
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCreateViewBuilder(t *testing.T) {
+	table := []struct {
+		B *CreateViewBuilder
+		N []string
+		S string
+	}{
+		// Basic test for create view
+		{
+			B: CreateView("cycling.cyclist_by_age").From("cycling.cyclist").PartitionKey("age").ClusteringKey("id"),
+			S: "CREATE MATERIALIZED VIEW cycling.cyclist_by_age AS SELECT * FROM cycling.cyclist " +
+				"WHERE age IS NOT NULL AND id IS NOT NULL PRIMARY KEY (age,id) ",
+		},
+		// Add IF NOT EXISTS and columns
+		{
+			B: CreateView("cycling.cyclist_by_age").IfNotExists().From("cycling.cyclist").Columns("age", "id", "name").
+				PartitionKey("age").ClusteringKey("id"),
+			S: "CREATE MATERIALIZED VIEW IF NOT EXISTS cycling.cyclist_by_age AS SELECT age,id,name FROM cycling.cyclist " +
+				"WHERE age IS NOT NULL AND id IS NOT NULL PRIMARY KEY (age,id) ",
+		},
+		// Add WHERE
+		{
+			B: CreateView("cycling.cyclist_by_age").From("cycling.cyclist").
+				Where(IsNotNull("age"), IsNotNull("id"), EqLit("country", "'PL'")).PartitionKey("age").ClusteringKey("id"),
+			S: "CREATE MATERIALIZED VIEW cycling.cyclist_by_age AS SELECT * FROM cycling.cyclist " +
+				"WHERE age IS NOT NULL AND id IS NOT NULL AND country='PL' PRIMARY KEY (age,id) ",
+		},
+		// Add composite partition key, clustering order and options
+		{
+			B: CreateView("cycling.cyclist_by_age").From("cycling.cyclist").PartitionKey("age", "country").ClusteringKey("id").
+				ClusteringOrder("id", DESC).With("comment", "'by age'"),
+			S: "CREATE MATERIALIZED VIEW cycling.cyclist_by_age AS SELECT * FROM cycling.cyclist " +
+				"WHERE age IS NOT NULL AND country IS NOT NULL AND id IS NOT NULL PRIMARY KEY ((age,country),id) " +
+				"WITH CLUSTERING ORDER BY (id DESC) AND comment='by age' ",
+		},
+	}
+
+	for _, test := range table {
+		stmt, names := test.B.ToCql()
+		if diff := cmp.Diff(test.S, stmt); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(test.N, names); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
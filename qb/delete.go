@@ -12,10 +12,26 @@ import (
 	"time"
 )
 
+// selector specifies a column or a collection element to delete.
+type selector struct {
+	column string
+	key    value // The collection element key or index, optional.
+}
+
+func (s selector) writeCql(cql *bytes.Buffer) (names []string) {
+	writeIdent(cql, s.column)
+	if s.key != nil {
+		cql.WriteByte('[')
+		names = s.key.writeCql(cql)
+		cql.WriteByte(']')
+	}
+	return
+}
+
 // DeleteBuilder builds CQL DELETE statements.
 type DeleteBuilder struct {
 	table   string
-	columns columns
+	columns []selector
 	using   using
 	where   where
 	_if     _if
@@ -29,17 +45,32 @@ func Delete(table string) *DeleteBuilder {
 	}
 }
 
+// Clone returns a copy of the builder. A base builder can be shared and
+// specialized with clones without affecting the base or other clones.
+func (b *DeleteBuilder) Clone() *DeleteBuilder {
+	c := *b
+	c.columns = append([]selector(nil), b.columns...)
+	c.where = append(where(nil), b.where...)
+	c._if = append(_if(nil), b._if...)
+	return &c
+}
+
 // ToCql builds the query into a CQL string and named args.
 func (b *DeleteBuilder) ToCql() (stmt string, names []string) {
 	cql := bytes.Buffer{}
 
 	cql.WriteString("DELETE ")
+	for i, s := range b.columns {
+		if i > 0 {
+			cql.WriteByte(',')
+		}
+		names = append(names, s.writeCql(&cql)...)
+	}
 	if len(b.columns) > 0 {
-		b.columns.writeCql(&cql)
 		cql.WriteByte(' ')
 	}
 	cql.WriteString("FROM ")
-	cql.WriteString(b.table)
+	writeIdent(&cql, b.table)
 	cql.WriteByte(' ')
 
 	names = append(names, b.using.writeCql(&cql)...)
@@ -62,7 +93,28 @@ func (b *DeleteBuilder) From(table string) *DeleteBuilder {
 
 // Columns adds delete columns to the query.
 func (b *DeleteBuilder) Columns(columns ...string) *DeleteBuilder {
-	b.columns = append(b.columns, columns...)
+	for _, c := range columns {
+		b.columns = append(b.columns, selector{column: c})
+	}
+	return b
+}
+
+// Element adds deletion of column[?] to the query, it deletes a map entry for
+// a key or a list element at an index. The key parameter name is column_key.
+func (b *DeleteBuilder) Element(column string) *DeleteBuilder {
+	return b.ElementNamed(column, column+"_key")
+}
+
+// ElementNamed adds deletion of column[?] to the query with a custom key
+// parameter name.
+func (b *DeleteBuilder) ElementNamed(column, keyName string) *DeleteBuilder {
+	b.columns = append(b.columns, selector{column: column, key: param(keyName)})
+	return b
+}
+
+// ElementLit adds deletion of column[literal] to the query.
+func (b *DeleteBuilder) ElementLit(column, literal string) *DeleteBuilder {
+	b.columns = append(b.columns, selector{column: column, key: lit(literal)})
 	return b
 }
 
@@ -98,3 +150,20 @@ func (b *DeleteBuilder) Existing() *DeleteBuilder {
 	b.exists = true
 	return b
 }
+
+// Timeout adds USING TIMEOUT clause to the query.
+//
+// USING TIMEOUT is a feature specific to ScyllaDB, it sets a server side
+// timeout of the query. Use gocqlx.Session.CheckUsingTimeout to validate
+// that the cluster supports it.
+func (b *DeleteBuilder) Timeout(d time.Duration) *DeleteBuilder {
+	b.using.Timeout(d)
+	return b
+}
+
+// TimeoutNamed adds USING TIMEOUT clause to the query with a custom parameter
+// name, the bound value shall be a gocql.Duration.
+func (b *DeleteBuilder) TimeoutNamed(name string) *DeleteBuilder {
+	b.using.TimeoutNamed(name)
+	return b
+}
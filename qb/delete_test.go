@@ -37,6 +37,23 @@ func TestDeleteBuilder(t *testing.T) {
 			S: "DELETE stars FROM cycling.cyclist_name WHERE id=? ",
 			N: []string{"expr"},
 		},
+		// Add columns and collection elements
+		{
+			B: Delete("cycling.cyclist_name").Columns("stars").Element("tags").Columns("name").Where(w),
+			S: "DELETE stars,tags[?],name FROM cycling.cyclist_name WHERE id=? ",
+			N: []string{"tags_key", "expr"},
+		},
+		{
+			B: Delete("cycling.cyclist_name").ElementNamed("tags", "k").ElementLit("teams", "2").Where(w),
+			S: "DELETE tags[?],teams[2] FROM cycling.cyclist_name WHERE id=? ",
+			N: []string{"k", "expr"},
+		},
+		// Add range delete over clustering key
+		{
+			B: Delete("cycling.cyclist_name").Where(w, GtOrEq("race_year"), LtNamed("race_year", "race_year_end")),
+			S: "DELETE FROM cycling.cyclist_name WHERE id=? AND race_year>=? AND race_year<? ",
+			N: []string{"expr", "race_year", "race_year_end"},
+		},
 		// Add WHERE
 		{
 			B: Delete("cycling.cyclist_name").Where(w, Gt("firstname")),
@@ -67,6 +84,12 @@ func TestDeleteBuilder(t *testing.T) {
 			S: "DELETE FROM cycling.cyclist_name WHERE id=? IF firstname>? ",
 			N: []string{"expr", "firstname"},
 		},
+		// Add IF with multiple conditions
+		{
+			B: Delete("cycling.cyclist_name").Where(w).If(Eq("version"), InNamed("state", "states")),
+			S: "DELETE FROM cycling.cyclist_name WHERE id=? IF version=? AND state IN ? ",
+			N: []string{"expr", "version", "states"},
+		},
 		// Add TIMESTAMP
 		{
 			B: Delete("cycling.cyclist_name").Where(w).Timestamp(time.Date(2005, 05, 05, 0, 0, 0, 0, time.UTC)),
@@ -84,6 +107,12 @@ func TestDeleteBuilder(t *testing.T) {
 			S: "DELETE FROM cycling.cyclist_name WHERE id=? IF EXISTS ",
 			N: []string{"expr"},
 		},
+		// Add USING TIMEOUT
+		{
+			B: Delete("cycling.cyclist_name").Where(w).Timeout(time.Second),
+			S: "DELETE FROM cycling.cyclist_name USING TIMEOUT 1s WHERE id=? ",
+			N: []string{"expr"},
+		},
 	}
 
 	for _, test := range table {
@@ -96,3 +125,17 @@ func TestDeleteBuilder(t *testing.T) {
 		}
 	}
 }
+
+func TestDeleteBuilderClone(t *testing.T) {
+	base := Delete("cycling.cyclist_name").Where(Eq("id"))
+	b := base.Clone().Columns("name").Where(Eq("race")).Existing()
+
+	stmt, _ := b.ToCql()
+	if diff := cmp.Diff("DELETE name FROM cycling.cyclist_name WHERE id=? AND race=? IF EXISTS ", stmt); diff != "" {
+		t.Error(diff)
+	}
+	stmt, _ = base.ToCql()
+	if diff := cmp.Diff("DELETE FROM cycling.cyclist_name WHERE id=? ", stmt); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -4,4 +4,10 @@
 
 // Package qb provides CQL query builders. The builders create CQL statement
 // and a list of named parameters that can later be bound using gocqlx.
+//
+// Statements always use positional '?' markers, named markers in raw
+// fragments i.e. :name are rewritten to '?' as well. The returned names are
+// in the order of the markers, so the statement can be used with tooling and
+// drivers that do not accept named markers, binding values in the order of
+// the names, while gocqlx binds them by name with BindStruct or BindMap.
 package qb
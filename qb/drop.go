@@ -0,0 +1,120 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+// DROP reference:
+// https://cassandra.apache.org/doc/latest/cql/ddl.html#drop-table
+
+import (
+	"bytes"
+	"time"
+)
+
+// DropBuilder builds CQL DROP statements.
+type DropBuilder struct {
+	kind     string
+	name     string
+	ifExists bool
+}
+
+// DropTable returns a new DropBuilder building a DROP TABLE statement.
+func DropTable(table string) *DropBuilder {
+	return &DropBuilder{
+		kind: "TABLE",
+		name: table,
+	}
+}
+
+// DropIndex returns a new DropBuilder building a DROP INDEX statement.
+func DropIndex(index string) *DropBuilder {
+	return &DropBuilder{
+		kind: "INDEX",
+		name: index,
+	}
+}
+
+// DropType returns a new DropBuilder building a DROP TYPE statement.
+func DropType(typ string) *DropBuilder {
+	return &DropBuilder{
+		kind: "TYPE",
+		name: typ,
+	}
+}
+
+// DropView returns a new DropBuilder building a DROP MATERIALIZED VIEW
+// statement.
+func DropView(view string) *DropBuilder {
+	return &DropBuilder{
+		kind: "MATERIALIZED VIEW",
+		name: view,
+	}
+}
+
+// DropKeyspace returns a new DropBuilder building a DROP KEYSPACE statement.
+func DropKeyspace(keyspace string) *DropBuilder {
+	return &DropBuilder{
+		kind: "KEYSPACE",
+		name: keyspace,
+	}
+}
+
+// ToCql builds the query into a CQL string and named args.
+func (b *DropBuilder) ToCql() (stmt string, names []string) {
+	cql := bytes.Buffer{}
+
+	cql.WriteString("DROP ")
+	cql.WriteString(b.kind)
+	cql.WriteByte(' ')
+	if b.ifExists {
+		cql.WriteString("IF EXISTS ")
+	}
+	writeIdent(&cql, b.name)
+	cql.WriteByte(' ')
+
+	stmt = cql.String()
+	return
+}
+
+// IfExists sets a IF EXISTS clause on the query.
+func (b *DropBuilder) IfExists() *DropBuilder {
+	b.ifExists = true
+	return b
+}
+
+// TruncateBuilder builds CQL TRUNCATE statements.
+type TruncateBuilder struct {
+	table string
+	using using
+}
+
+// Truncate returns a new TruncateBuilder with the given table name.
+func Truncate(table string) *TruncateBuilder {
+	return &TruncateBuilder{
+		table: table,
+	}
+}
+
+// ToCql builds the query into a CQL string and named args.
+func (b *TruncateBuilder) ToCql() (stmt string, names []string) {
+	cql := bytes.Buffer{}
+
+	cql.WriteString("TRUNCATE TABLE ")
+	writeIdent(&cql, b.table)
+	cql.WriteByte(' ')
+
+	names = b.using.writeCql(&cql)
+
+	stmt = cql.String()
+	return
+}
+
+// Timeout adds USING TIMEOUT clause to the query.
+//
+// USING TIMEOUT is a feature specific to ScyllaDB, use
+// gocqlx.Session.CheckUsingTimeout to validate that the cluster supports it.
+func (b *TruncateBuilder) Timeout(d time.Duration) *TruncateBuilder {
+	b.using.Timeout(d)
+	return b
+}
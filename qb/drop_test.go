@@ -0,0 +1,71 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDropBuilder(t *testing.T) {
+	table := []struct {
+		B *DropBuilder
+		S string
+	}{
+		{
+			B: DropTable("cycling.cyclist_name"),
+			S: "DROP TABLE cycling.cyclist_name ",
+		},
+		{
+			B: DropTable("cycling.cyclist_name").IfExists(),
+			S: "DROP TABLE IF EXISTS cycling.cyclist_name ",
+		},
+		{
+			B: DropIndex("cycling.cyclist_name_idx").IfExists(),
+			S: "DROP INDEX IF EXISTS cycling.cyclist_name_idx ",
+		},
+		{
+			B: DropType("cycling.address"),
+			S: "DROP TYPE cycling.address ",
+		},
+		{
+			B: DropView("cycling.cyclist_by_age").IfExists(),
+			S: "DROP MATERIALIZED VIEW IF EXISTS cycling.cyclist_by_age ",
+		},
+		{
+			B: DropKeyspace("cycling"),
+			S: "DROP KEYSPACE cycling ",
+		},
+	}
+
+	for _, test := range table {
+		stmt, names := test.B.ToCql()
+		if diff := cmp.Diff(test.S, stmt); diff != "" {
+			t.Error(diff)
+		}
+		if len(names) != 0 {
+			t.Error("unexpected names", names)
+		}
+	}
+}
+
+func TestTruncateBuilder(t *testing.T) {
+	stmt, names := Truncate("cycling.cyclist_name").ToCql()
+	if diff := cmp.Diff("TRUNCATE TABLE cycling.cyclist_name ", stmt); diff != "" {
+		t.Error(diff)
+	}
+	if len(names) != 0 {
+		t.Error("unexpected names", names)
+	}
+}
+
+func TestTruncateBuilderTimeout(t *testing.T) {
+	stmt, _ := Truncate("cycling.cyclist_name").Timeout(time.Minute).ToCql()
+	if diff := cmp.Diff("TRUNCATE TABLE cycling.cyclist_name USING TIMEOUT 1m ", stmt); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -16,11 +16,20 @@ type Func struct {
 	Name string
 	// name of the function parameters
 	ParamNames []string
+
+	// function calls used as parameters, they precede ParamNames
+	args []*Func
 }
 
 func (f *Func) writeCql(cql *bytes.Buffer) (names []string) {
 	cql.WriteString(f.Name)
 	cql.WriteByte('(')
+	for i, a := range f.args {
+		names = append(names, a.writeCql(cql)...)
+		if i < len(f.args)-1 || len(f.ParamNames) > 0 {
+			cql.WriteByte(',')
+		}
+	}
 	placeholders(cql, len(f.ParamNames))
 	cql.WriteByte(')')
 	names = append(names, f.ParamNames...)
@@ -35,6 +44,15 @@ func Fn(name string, paramNames ...string) *Func {
 	}
 }
 
+// NestedFn creates Func taking results of other function calls as
+// parameters i.e. toTimestamp(now()).
+func NestedFn(name string, args ...*Func) *Func {
+	return &Func{
+		Name: name,
+		args: args,
+	}
+}
+
 // MinTimeuuid produces minTimeuuid(?).
 func MinTimeuuid(name string) *Func {
 	return Fn("minTimeuuid", name)
@@ -49,3 +67,43 @@ func MaxTimeuuid(name string) *Func {
 func Now() *Func {
 	return Fn("now")
 }
+
+// UUID produces uuid().
+func UUID() *Func {
+	return Fn("uuid")
+}
+
+// CurrentTimestamp produces currentTimestamp().
+func CurrentTimestamp() *Func {
+	return Fn("currentTimestamp")
+}
+
+// CurrentDate produces currentDate().
+func CurrentDate() *Func {
+	return Fn("currentDate")
+}
+
+// CurrentTime produces currentTime().
+func CurrentTime() *Func {
+	return Fn("currentTime")
+}
+
+// CurrentTimeUUID produces currentTimeUUID().
+func CurrentTimeUUID() *Func {
+	return Fn("currentTimeUUID")
+}
+
+// ToTimestamp produces toTimestamp(fn), i.e. toTimestamp(now()).
+func ToTimestamp(fn *Func) *Func {
+	return NestedFn("toTimestamp", fn)
+}
+
+// ToDate produces toDate(fn), i.e. toDate(now()).
+func ToDate(fn *Func) *Func {
+	return NestedFn("toDate", fn)
+}
+
+// ToUnixTimestamp produces toUnixTimestamp(fn), i.e. toUnixTimestamp(now()).
+func ToUnixTimestamp(fn *Func) *Func {
+	return NestedFn("toUnixTimestamp", fn)
+}
@@ -34,6 +34,14 @@ func Insert(table string) *InsertBuilder {
 	}
 }
 
+// Clone returns a copy of the builder. A base builder can be shared and
+// specialized with clones without affecting the base or other clones.
+func (b *InsertBuilder) Clone() *InsertBuilder {
+	c := *b
+	c.columns = append([]initializer(nil), b.columns...)
+	return &c
+}
+
 // ToCql builds the query into a CQL string and named args.
 func (b *InsertBuilder) ToCql() (stmt string, names []string) {
 	cql := bytes.Buffer{}
@@ -41,7 +49,7 @@ func (b *InsertBuilder) ToCql() (stmt string, names []string) {
 	cql.WriteString("INSERT ")
 
 	cql.WriteString("INTO ")
-	cql.WriteString(b.table)
+	writeIdent(&cql, b.table)
 	cql.WriteByte(' ')
 
 	if b.json {
@@ -53,7 +61,7 @@ func (b *InsertBuilder) ToCql() (stmt string, names []string) {
 
 	cql.WriteByte('(')
 	for i, c := range b.columns {
-		cql.WriteString(c.column)
+		writeIdent(&cql, c.column)
 		if i < len(b.columns)-1 {
 			cql.WriteByte(',')
 		}
@@ -170,3 +178,20 @@ func (b *InsertBuilder) TimestampNamed(name string) *InsertBuilder {
 	b.using.TimestampNamed(name)
 	return b
 }
+
+// Timeout adds USING TIMEOUT clause to the query.
+//
+// USING TIMEOUT is a feature specific to ScyllaDB, it sets a server side
+// timeout of the query. Use gocqlx.Session.CheckUsingTimeout to validate
+// that the cluster supports it.
+func (b *InsertBuilder) Timeout(d time.Duration) *InsertBuilder {
+	b.using.Timeout(d)
+	return b
+}
+
+// TimeoutNamed adds USING TIMEOUT clause to the query with a custom parameter
+// name, the bound value shall be a gocql.Duration.
+func (b *InsertBuilder) TimeoutNamed(name string) *InsertBuilder {
+	b.using.TimeoutNamed(name)
+	return b
+}
@@ -76,6 +76,11 @@ func TestInsertBuilder(t *testing.T) {
 			S: "INSERT INTO cycling.cyclist_name (id,user_uuid,firstname) VALUES (?,?,?) USING TIMESTAMP ? ",
 			N: []string{"id", "user_uuid", "firstname", "ts"},
 		},
+		{
+			B: Insert("cycling.cyclist_name").Columns("id", "user_uuid", "firstname").TimestampNamed("_ts").TTL(time.Second),
+			S: "INSERT INTO cycling.cyclist_name (id,user_uuid,firstname) VALUES (?,?,?) USING TTL 1 AND TIMESTAMP ? ",
+			N: []string{"id", "user_uuid", "firstname", "_ts"},
+		},
 		// Add TupleColumn
 		{
 			B: Insert("cycling.cyclist_name").TupleColumn("id", 2),
@@ -104,6 +109,17 @@ func TestInsertBuilder(t *testing.T) {
 			S: "INSERT INTO cycling.cyclist_name (id,user_uuid) VALUES (now(),?) ",
 			N: []string{"user_uuid"},
 		},
+		{
+			B: Insert("cycling.cyclist_name").FuncColumn("id", UUID()).FuncColumn("created_at", ToTimestamp(Now())),
+			S: "INSERT INTO cycling.cyclist_name (id,created_at) VALUES (uuid(),toTimestamp(now())) ",
+			N: nil,
+		},
+		// Add USING TIMEOUT
+		{
+			B: Insert("cycling.cyclist_name").Columns("id").Timeout(time.Second),
+			S: "INSERT INTO cycling.cyclist_name (id) VALUES (?) USING TIMEOUT 1s ",
+			N: []string{"id"},
+		},
 	}
 
 	for _, test := range table {
@@ -116,3 +132,17 @@ func TestInsertBuilder(t *testing.T) {
 		}
 	}
 }
+
+func TestInsertBuilderClone(t *testing.T) {
+	base := Insert("cycling.cyclist_name").Columns("id")
+	b := base.Clone().Columns("name").Unique()
+
+	stmt, _ := b.ToCql()
+	if diff := cmp.Diff("INSERT INTO cycling.cyclist_name (id,name) VALUES (?,?) IF NOT EXISTS ", stmt); diff != "" {
+		t.Error(diff)
+	}
+	stmt, _ = base.ToCql()
+	if diff := cmp.Diff("INSERT INTO cycling.cyclist_name (id) VALUES (?) ", stmt); diff != "" {
+		t.Error(diff)
+	}
+}
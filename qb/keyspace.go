@@ -0,0 +1,156 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+// CREATE KEYSPACE reference:
+// https://cassandra.apache.org/doc/latest/cql/ddl.html#create-keyspace
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// Replication is a keyspace replication strategy.
+type Replication struct {
+	class   string
+	factors map[string]int
+}
+
+// SimpleStrategy returns SimpleStrategy replication with the given replication
+// factor.
+func SimpleStrategy(replicationFactor int) Replication {
+	return Replication{
+		class:   "SimpleStrategy",
+		factors: map[string]int{"replication_factor": replicationFactor},
+	}
+}
+
+// NetworkTopologyStrategy returns NetworkTopologyStrategy replication with
+// replication factors per datacenter.
+func NetworkTopologyStrategy(dcFactors map[string]int) Replication {
+	return Replication{
+		class:   "NetworkTopologyStrategy",
+		factors: dcFactors,
+	}
+}
+
+// String returns CQL map literal of the replication.
+func (r Replication) String() string {
+	cql := bytes.Buffer{}
+	r.writeCql(&cql)
+	return cql.String()
+}
+
+func (r Replication) writeCql(cql *bytes.Buffer) {
+	keys := make([]string, 0, len(r.factors))
+	for k := range r.factors {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	cql.WriteString("{'class': '")
+	cql.WriteString(r.class)
+	cql.WriteByte('\'')
+	for _, k := range keys {
+		fmt.Fprintf(cql, ", '%s': %d", k, r.factors[k])
+	}
+	cql.WriteByte('}')
+}
+
+// KeyspaceBuilder builds CQL CREATE KEYSPACE and ALTER KEYSPACE statements.
+type KeyspaceBuilder struct {
+	keyspace    string
+	alter       bool
+	ifNotExists bool
+	replication *Replication
+	options     options
+}
+
+// CreateKeyspace returns a new KeyspaceBuilder building a CREATE KEYSPACE
+// statement for the given keyspace.
+func CreateKeyspace(keyspace string) *KeyspaceBuilder {
+	return &KeyspaceBuilder{
+		keyspace: keyspace,
+	}
+}
+
+// AlterKeyspace returns a new KeyspaceBuilder building an ALTER KEYSPACE
+// statement for the given keyspace.
+func AlterKeyspace(keyspace string) *KeyspaceBuilder {
+	return &KeyspaceBuilder{
+		keyspace: keyspace,
+		alter:    true,
+	}
+}
+
+// ToCql builds the query into a CQL string and named args.
+func (b *KeyspaceBuilder) ToCql() (stmt string, names []string) {
+	cql := bytes.Buffer{}
+
+	if b.alter {
+		cql.WriteString("ALTER KEYSPACE ")
+	} else {
+		cql.WriteString("CREATE KEYSPACE ")
+	}
+	if b.ifNotExists {
+		cql.WriteString("IF NOT EXISTS ")
+	}
+	writeIdent(&cql, b.keyspace)
+	cql.WriteByte(' ')
+
+	opts := b.options
+	if b.replication != nil {
+		opts = append(options{{name: "replication", value: b.replication.String()}}, opts...)
+	}
+	if len(opts) > 0 {
+		cql.WriteString("WITH ")
+		opts.writeCql(&cql)
+		cql.WriteByte(' ')
+	}
+
+	stmt = cql.String()
+	return
+}
+
+// IfNotExists sets a IF NOT EXISTS clause on the query.
+func (b *KeyspaceBuilder) IfNotExists() *KeyspaceBuilder {
+	b.ifNotExists = true
+	return b
+}
+
+// Replication sets the replication option of the keyspace.
+func (b *KeyspaceBuilder) Replication(r Replication) *KeyspaceBuilder {
+	b.replication = &r
+	return b
+}
+
+// DurableWrites sets the durable_writes option of the keyspace.
+func (b *KeyspaceBuilder) DurableWrites(durableWrites bool) *KeyspaceBuilder {
+	return b.With("durable_writes", fmt.Sprint(durableWrites))
+}
+
+// Tablets sets the tablets option of the keyspace enabling or disabling
+// tablets based replication.
+//
+// Tablets are a feature specific to ScyllaDB.
+func (b *KeyspaceBuilder) Tablets(enabled bool) *KeyspaceBuilder {
+	return b.With("tablets", fmt.Sprintf("{'enabled': %t}", enabled))
+}
+
+// TabletsInitial sets the tablets option of the keyspace enabling tablets
+// with the given initial number of tablets per table.
+//
+// Tablets are a feature specific to ScyllaDB.
+func (b *KeyspaceBuilder) TabletsInitial(initial int) *KeyspaceBuilder {
+	return b.With("tablets", fmt.Sprintf("{'initial': %d}", initial))
+}
+
+// With adds a keyspace option to the WITH clause of the query. The value is
+// a CQL literal.
+func (b *KeyspaceBuilder) With(name, value string) *KeyspaceBuilder {
+	b.options = append(b.options, option{name: name, value: value})
+	return b
+}
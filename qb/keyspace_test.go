@@ -0,0 +1,57 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestKeyspaceBuilder(t *testing.T) {
+	table := []struct {
+		B *KeyspaceBuilder
+		N []string
+		S string
+	}{
+		// Basic test for create keyspace
+		{
+			B: CreateKeyspace("cycling").Replication(SimpleStrategy(3)),
+			S: "CREATE KEYSPACE cycling WITH replication={'class': 'SimpleStrategy', 'replication_factor': 3} ",
+		},
+		// Add IF NOT EXISTS and NetworkTopologyStrategy
+		{
+			B: CreateKeyspace("cycling").IfNotExists().Replication(NetworkTopologyStrategy(map[string]int{"dc2": 2, "dc1": 3})),
+			S: "CREATE KEYSPACE IF NOT EXISTS cycling WITH replication={'class': 'NetworkTopologyStrategy', 'dc1': 3, 'dc2': 2} ",
+		},
+		// Add durable writes and tablets
+		{
+			B: CreateKeyspace("cycling").Replication(SimpleStrategy(1)).DurableWrites(false).Tablets(false),
+			S: "CREATE KEYSPACE cycling WITH replication={'class': 'SimpleStrategy', 'replication_factor': 1} " +
+				"AND durable_writes=false AND tablets={'enabled': false} ",
+		},
+		// Add initial tablets
+		{
+			B: CreateKeyspace("cycling").TabletsInitial(8).Replication(SimpleStrategy(1)),
+			S: "CREATE KEYSPACE cycling WITH replication={'class': 'SimpleStrategy', 'replication_factor': 1} " +
+				"AND tablets={'initial': 8} ",
+		},
+		// Alter keyspace
+		{
+			B: AlterKeyspace("cycling").DurableWrites(true),
+			S: "ALTER KEYSPACE cycling WITH durable_writes=true ",
+		},
+	}
+
+	for _, test := range table {
+		stmt, names := test.B.ToCql()
+		if diff := cmp.Diff(test.S, stmt); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(test.N, names); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+// PRUNE MATERIALIZED VIEW reference:
+// https://docs.scylladb.com/stable/cql/mv.html#prune-materialized-view
+
+import (
+	"bytes"
+	"time"
+)
+
+// PruneViewBuilder builds CQL PRUNE MATERIALIZED VIEW statements that remove
+// view rows that have no corresponding base table row.
+//
+// PRUNE MATERIALIZED VIEW is a feature specific to ScyllaDB.
+type PruneViewBuilder struct {
+	view  string
+	where where
+	using using
+}
+
+// PruneView returns a new PruneViewBuilder with the given view name.
+func PruneView(view string) *PruneViewBuilder {
+	return &PruneViewBuilder{
+		view: view,
+	}
+}
+
+// ToCql builds the query into a CQL string and named args.
+func (b *PruneViewBuilder) ToCql() (stmt string, names []string) {
+	cql := bytes.Buffer{}
+
+	cql.WriteString("PRUNE MATERIALIZED VIEW ")
+	writeIdent(&cql, b.view)
+	cql.WriteByte(' ')
+
+	names = append(names, b.where.writeCql(&cql)...)
+	names = append(names, b.using.writeCql(&cql)...)
+
+	stmt = cql.String()
+	return
+}
+
+// Where adds an expression to the WHERE clause of the query. Expressions are
+// ANDed together in the generated CQL.
+func (b *PruneViewBuilder) Where(w ...Cmp) *PruneViewBuilder {
+	b.where = append(b.where, w...)
+	return b
+}
+
+// Timeout adds USING TIMEOUT clause to the query.
+func (b *PruneViewBuilder) Timeout(d time.Duration) *PruneViewBuilder {
+	b.using.Timeout(d)
+	return b
+}
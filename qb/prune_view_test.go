@@ -0,0 +1,48 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPruneViewBuilder(t *testing.T) {
+	table := []struct {
+		B *PruneViewBuilder
+		N []string
+		S string
+	}{
+		// Basic test for prune view
+		{
+			B: PruneView("cycling.cyclist_by_age"),
+			S: "PRUNE MATERIALIZED VIEW cycling.cyclist_by_age ",
+		},
+		// Add WHERE
+		{
+			B: PruneView("cycling.cyclist_by_age").Where(Eq("age")),
+			S: "PRUNE MATERIALIZED VIEW cycling.cyclist_by_age WHERE age=? ",
+			N: []string{"age"},
+		},
+		// Add USING TIMEOUT
+		{
+			B: PruneView("cycling.cyclist_by_age").Where(Token("age").GtValueNamed("start"), Token("age").LtOrEqValueNamed("end")).Timeout(time.Minute),
+			S: "PRUNE MATERIALIZED VIEW cycling.cyclist_by_age WHERE token(age)>? AND token(age)<=? USING TIMEOUT 1m ",
+			N: []string{"start", "end"},
+		},
+	}
+
+	for _, test := range table {
+		stmt, names := test.B.ToCql()
+		if diff := cmp.Diff(test.S, stmt); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(test.N, names); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
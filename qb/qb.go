@@ -4,6 +4,11 @@
 
 package qb
 
+import (
+	"sort"
+	"strings"
+)
+
 // Builder is interface implemented by all the builders.
 type Builder interface {
 	// ToCql builds the query into a CQL string and named args.
@@ -12,3 +17,23 @@ type Builder interface {
 
 // M is a map.
 type M map[string]interface{}
+
+// Names returns keys of the map in sorted order. It can be used to build
+// statements with columns or parameters taken from a map, sorting guarantees
+// that the resulting statement and names are stable across runs.
+func (m M) Names() []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UDTField returns a reference to a field of a UDT column i.e. address.city.
+// It can be used as a column in comparators and result columns, parameter
+// names of comparators are the same dotted path which BindStruct resolves to
+// a field of the nested struct.
+func UDTField(column string, fields ...string) string {
+	return strings.Join(append([]string{column}, fields...), ".")
+}
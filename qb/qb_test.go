@@ -0,0 +1,46 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMNames(t *testing.T) {
+	m := M{
+		"c": 3,
+		"a": 1,
+		"b": 2,
+	}
+
+	for i := 0; i < 10; i++ {
+		if diff := cmp.Diff([]string{"a", "b", "c"}, m.Names()); diff != "" {
+			t.Fatal(diff)
+		}
+	}
+
+	stmt, names := Insert("table").Columns(m.Names()...).ToCql()
+	if diff := cmp.Diff("INSERT INTO \"table\" (a,b,c) VALUES (?,?,?) ", stmt); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"a", "b", "c"}, names); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestPositionalMarkers(t *testing.T) {
+	stmt, names := Select("cycling.cyclist_name").
+		Where(Eq("id"), GtOrEqNamed("ts", "from"), LtFunc("ts", Fn("now")), RawCmp("(a,b) > (:a,:b)")).
+		Limit(10).
+		ToCql()
+	if diff := cmp.Diff("SELECT * FROM cycling.cyclist_name WHERE id=? AND ts>=? AND ts<now() AND (a,b) > (?,?) LIMIT 10 ", stmt); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"id", "from", "a", "b"}, names); diff != "" {
+		t.Error(diff)
+	}
+}
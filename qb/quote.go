@@ -0,0 +1,118 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"bytes"
+	"strings"
+)
+
+// reservedWords are CQL keywords that can not be used as unquoted
+// identifiers, see
+// https://cassandra.apache.org/doc/latest/cql/appendices.html#appendix-a-cql-keywords
+var reservedWords = map[string]struct{}{
+	"add": {}, "allow": {}, "alter": {}, "and": {}, "apply": {}, "asc": {},
+	"authorize": {}, "batch": {}, "begin": {}, "by": {}, "columnfamily": {},
+	"create": {}, "delete": {}, "desc": {}, "describe": {}, "drop": {},
+	"entries": {}, "execute": {}, "from": {}, "full": {}, "grant": {}, "if": {},
+	"in": {}, "index": {}, "infinity": {}, "insert": {}, "into": {},
+	"keyspace": {}, "limit": {}, "materialized": {}, "modify": {}, "nan": {},
+	"norecursive": {}, "not": {}, "null": {}, "of": {}, "on": {}, "or": {},
+	"order": {}, "primary": {}, "rename": {}, "replace": {}, "revoke": {},
+	"schema": {}, "select": {}, "set": {}, "table": {}, "to": {}, "token": {},
+	"truncate": {}, "unlogged": {}, "update": {}, "use": {}, "using": {},
+	"view": {}, "where": {}, "with": {},
+}
+
+// Quote quotes identifier so that it is case-sensitive and may contain
+// special characters i.e. spaces. Each part of a dot separated path i.e.
+// keyspace.table or address.city is quoted unless it is a lower-case
+// identifier that is not a reserved word, parts that are already quoted are
+// kept as is.
+//
+// Builders quote reserved words automatically, use Quote for mixed-case names
+// that must not be folded to lower-case. Bind names derived from quoted
+// columns are unquoted i.e. Eq(Quote("userId")) binds userId.
+func Quote(identifier string) string {
+	parts := strings.Split(identifier, ".")
+	for i, p := range parts {
+		if isQuoted(p) || (isPlainIdent(p) && p == strings.ToLower(p) && !isReserved(p)) {
+			continue
+		}
+		parts[i] = `"` + strings.Replace(p, `"`, `""`, -1) + `"`
+	}
+	return strings.Join(parts, ".")
+}
+
+// quoteIdent quotes reserved words in s if s is an identifier or a dot
+// separated path of identifiers i.e. keyspace.table or address.city.
+// Anything else, including expressions and already quoted identifiers, is
+// returned unchanged.
+func quoteIdent(s string) string {
+	parts := strings.Split(s, ".")
+	quote := false
+	for _, p := range parts {
+		if isQuoted(p) {
+			continue
+		}
+		if !isPlainIdent(p) {
+			return s
+		}
+		if isReserved(p) {
+			quote = true
+		}
+	}
+	if !quote {
+		return s
+	}
+
+	for i, p := range parts {
+		if !isQuoted(p) && isReserved(p) {
+			parts[i] = `"` + p + `"`
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// unquote removes double quotes from name so that quoted columns bind by
+// their plain names.
+func unquote(name string) string {
+	return strings.Replace(name, `"`, "", -1)
+}
+
+func isReserved(s string) bool {
+	_, ok := reservedWords[strings.ToLower(s)]
+	return ok
+}
+
+func isPlainIdent(s string) bool {
+	if s == "" || !isIdentStart(s[0]) {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if !isIdent(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isQuoted(s string) bool {
+	return len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"'
+}
+
+// writeIdent writes s quoted as needed, see quoteIdent.
+func writeIdent(cql *bytes.Buffer, s string) {
+	cql.WriteString(quoteIdent(s))
+}
+
+// quoteIdents returns identifiers quoted as needed joined with commas.
+func quoteIdents(identifiers []string) string {
+	q := make([]string, len(identifiers))
+	for i, s := range identifiers {
+		q[i] = quoteIdent(s)
+	}
+	return strings.Join(q, ",")
+}
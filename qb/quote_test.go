@@ -0,0 +1,135 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestQuoteIdent(t *testing.T) {
+	table := []struct {
+		I string
+		S string
+	}{
+		{I: "name", S: "name"},
+		{I: "user_id2", S: "user_id2"},
+		{I: "order", S: `"order"`},
+		{I: "TOKEN", S: `"TOKEN"`},
+		{I: "firstName", S: "firstName"},
+		{I: "ks.table", S: `ks."table"`},
+		{I: "Ks.users", S: "Ks.users"},
+		{I: "address.city", S: "address.city"},
+		{I: `"order"`, S: `"order"`},
+		{I: `"Ks".order`, S: `"Ks"."order"`},
+		{I: "count(*)", S: "count(*)"},
+		{I: "order AS o", S: "order AS o"},
+		{I: "*", S: "*"},
+		{I: "", S: ""},
+	}
+
+	for _, test := range table {
+		if diff := cmp.Diff(test.S, quoteIdent(test.I)); diff != "" {
+			t.Error(test.I, diff)
+		}
+	}
+}
+
+func TestQuote(t *testing.T) {
+	table := []struct {
+		I string
+		S string
+	}{
+		{I: "name", S: "name"},
+		{I: "order", S: `"order"`},
+		{I: "firstName", S: `"firstName"`},
+		{I: "Ks.users", S: `"Ks".users`},
+		{I: "ks.Table", S: `ks."Table"`},
+		{I: `"userId"`, S: `"userId"`},
+		{I: "my col", S: `"my col"`},
+		{I: `my "col"`, S: `"my ""col"""`},
+	}
+
+	for _, test := range table {
+		if diff := cmp.Diff(test.S, Quote(test.I)); diff != "" {
+			t.Error(test.I, diff)
+		}
+	}
+}
+
+func TestBuildersQuoteIdentifiers(t *testing.T) {
+	table := []struct {
+		B Builder
+		N []string
+		S string
+	}{
+		{
+			B: Select("ks.table").Columns("id", "order", As("token", "t")).Where(Eq("order"), Token("id").Gt()).OrderBy("order", DESC),
+			S: `SELECT id,"order","token" AS t FROM ks."table" WHERE "order"=? AND token(id)>token(?) ORDER BY "order" DESC `,
+			N: []string{"order", "id"},
+		},
+		{
+			B: Select("ks.users").Columns(Writetime(Quote("Name")), Count("order")).Where(TupleGt([]string{"id", "order"})),
+			S: `SELECT writetime("Name") AS writetime_Name,count("order") FROM ks.users WHERE (id,"order")>(?,?) `,
+			N: []string{"id", "order"},
+		},
+		{
+			B: Select("ks.Users").Columns("userId", "order"),
+			S: `SELECT userId,"order" FROM ks.Users `,
+		},
+		{
+			B: Select(Quote("ks.Users")).Columns("id", Quote("userId")).Where(Eq(Quote("userId")), In("token")),
+			S: `SELECT id,"userId" FROM ks."Users" WHERE "userId"=? AND "token" IN ? `,
+			N: []string{"userId", "token"},
+		},
+		{
+			B: Insert("ks.table").Columns("id", "order"),
+			S: `INSERT INTO ks."table" (id,"order") VALUES (?,?) `,
+			N: []string{"id", "order"},
+		},
+		{
+			B: Update("ks.table").Set("order").Add("limit").Where(Eq("id")),
+			S: `UPDATE ks."table" SET "order"=?,"limit"="limit"+? WHERE id=? `,
+			N: []string{"order", "limit", "id"},
+		},
+		{
+			B: Update("users").Set(Quote("firstName")).Where(InTuple(Quote("userId"), 2)),
+			S: `UPDATE users SET "firstName"=? WHERE "userId" IN (?,?) `,
+			N: []string{"firstName", "userId_0", "userId_1"},
+		},
+		{
+			B: Delete("ks.table").Columns("order").Element(Quote("Tags")).Where(Eq("id")),
+			S: `DELETE "order","Tags"[?] FROM ks."table" WHERE id=? `,
+			N: []string{"Tags_key", "id"},
+		},
+		{
+			B: CreateTable("ks.table").Column("id", "int").Column("order", "int").PartitionKey("id").ClusteringKey("order").ClusteringOrder("order", DESC),
+			S: `CREATE TABLE ks."table" (id int,"order" int,PRIMARY KEY (id,"order")) WITH CLUSTERING ORDER BY ("order" DESC) `,
+		},
+		{
+			B: CreateIndex("").OnKeys("ks.table", Quote("Map")),
+			S: `CREATE INDEX ON ks."table" (KEYS("Map")) `,
+		},
+		{
+			B: AlterTable("ks.table").Rename("from", "to"),
+			S: `ALTER TABLE ks."table" RENAME "from" TO "to" `,
+		},
+		{
+			B: DropTable("ks.table"),
+			S: `DROP TABLE ks."table" `,
+		},
+	}
+
+	for _, test := range table {
+		stmt, names := test.B.ToCql()
+		if diff := cmp.Diff(test.S, stmt); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(test.N, names); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
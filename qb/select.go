@@ -10,6 +10,7 @@ package qb
 import (
 	"bytes"
 	"fmt"
+	"time"
 )
 
 // Order specifies sorting order.
@@ -33,15 +34,18 @@ func (o Order) String() string {
 type SelectBuilder struct {
 	table             string
 	columns           columns
+	rawColumns        []raw
 	distinct          columns
 	where             where
 	groupBy           columns
 	orderBy           columns
+	ann               *annOrder
 	limit             uint
 	limitPerPartition uint
 	allowFiltering    bool
 	bypassCache       bool
 	json              bool
+	using             using
 }
 
 // Select returns a new SelectBuilder with the given table name.
@@ -71,16 +75,22 @@ func (b *SelectBuilder) ToCql() (stmt string, names []string) {
 			cql.WriteByte(',')
 			b.columns.writeCql(&cql)
 		}
-	case len(b.columns) == 0:
+	case len(b.columns) == 0 && len(b.rawColumns) == 0:
 		cql.WriteByte('*')
 	default:
 		b.columns.writeCql(&cql)
 	}
+	for i, r := range b.rawColumns {
+		if i > 0 || len(b.columns) > 0 || len(b.groupBy) > 0 || len(b.distinct) > 0 {
+			cql.WriteByte(',')
+		}
+		names = append(names, r.writeCql(&cql)...)
+	}
 	cql.WriteString(" FROM ")
-	cql.WriteString(b.table)
+	writeIdent(&cql, b.table)
 	cql.WriteByte(' ')
 
-	names = b.where.writeCql(&cql)
+	names = append(names, b.where.writeCql(&cql)...)
 
 	if len(b.groupBy) > 0 {
 		cql.WriteString("GROUP BY ")
@@ -88,9 +98,15 @@ func (b *SelectBuilder) ToCql() (stmt string, names []string) {
 		cql.WriteByte(' ')
 	}
 
-	if len(b.orderBy) > 0 {
+	if len(b.orderBy) > 0 || b.ann != nil {
 		cql.WriteString("ORDER BY ")
 		b.orderBy.writeCql(&cql)
+		if b.ann != nil {
+			if len(b.orderBy) > 0 {
+				cql.WriteByte(',')
+			}
+			names = append(names, b.ann.writeCql(&cql)...)
+		}
 		cql.WriteByte(' ')
 	}
 
@@ -114,6 +130,8 @@ func (b *SelectBuilder) ToCql() (stmt string, names []string) {
 		cql.WriteString("BYPASS CACHE ")
 	}
 
+	names = append(names, b.using.writeCql(&cql)...)
+
 	stmt = cql.String()
 	return
 }
@@ -124,6 +142,23 @@ func (b *SelectBuilder) From(table string) *SelectBuilder {
 	return b
 }
 
+// Clone returns a copy of the builder. A base builder can be shared and
+// specialized with clones without affecting the base or other clones.
+func (b *SelectBuilder) Clone() *SelectBuilder {
+	c := *b
+	c.columns = append(columns(nil), b.columns...)
+	c.rawColumns = append([]raw(nil), b.rawColumns...)
+	c.distinct = append(columns(nil), b.distinct...)
+	c.where = append(where(nil), b.where...)
+	c.groupBy = append(columns(nil), b.groupBy...)
+	c.orderBy = append(columns(nil), b.orderBy...)
+	if b.ann != nil {
+		ann := *b.ann
+		c.ann = &ann
+	}
+	return &c
+}
+
 // Json sets the clause of the query.
 func (b *SelectBuilder) Json() *SelectBuilder {
 	b.json = true
@@ -140,9 +175,69 @@ func (b *SelectBuilder) Columns(columns ...string) *SelectBuilder {
 	return b
 }
 
+// RawColumns adds raw CQL result columns i.e. "blobAsText(:prefix) AS p" to
+// the query, named parameters in form of :name are replaced with ? and added
+// to query names.
+func (b *SelectBuilder) RawColumns(cql ...string) *SelectBuilder {
+	for _, c := range cql {
+		b.rawColumns = append(b.rawColumns, raw(c))
+	}
+	return b
+}
+
 // As is a helper for adding a column AS name result column to the query.
 func As(column, name string) string {
-	return column + " AS " + name
+	return quoteIdent(column) + " AS " + quoteIdent(name)
+}
+
+// Writetime is a helper for adding a writetime(column) AS writetime_column
+// result column to the query.
+func Writetime(column string) string {
+	return As("writetime("+quoteIdent(column)+")", "writetime_"+unquote(column))
+}
+
+// TTLOf is a helper for adding a ttl(column) AS ttl_column result column to
+// the query.
+func TTLOf(column string) string {
+	return As("ttl("+quoteIdent(column)+")", "ttl_"+unquote(column))
+}
+
+// Cast is a helper for adding a CAST(column AS cqlType) result column to
+// the query. The result column is named after the column so it maps onto
+// the same struct field.
+func Cast(column, cqlType string) string {
+	return As("CAST("+quoteIdent(column)+" AS "+cqlType+")", column)
+}
+
+// Count is a helper for adding a count(column) result column to the query,
+// use As to name the result column i.e. As(Count("id"), "total").
+func Count(column string) string {
+	return "count(" + quoteIdent(column) + ")"
+}
+
+// CountAll is a helper for adding a count(*) result column to the query.
+func CountAll() string {
+	return Count("*")
+}
+
+// Min is a helper for adding a min(column) result column to the query.
+func Min(column string) string {
+	return "min(" + quoteIdent(column) + ")"
+}
+
+// Max is a helper for adding a max(column) result column to the query.
+func Max(column string) string {
+	return "max(" + quoteIdent(column) + ")"
+}
+
+// Sum is a helper for adding a sum(column) result column to the query.
+func Sum(column string) string {
+	return "sum(" + quoteIdent(column) + ")"
+}
+
+// Avg is a helper for adding an avg(column) result column to the query.
+func Avg(column string) string {
+	return "avg(" + quoteIdent(column) + ")"
 }
 
 // Distinct sets DISTINCT clause on the query.
@@ -177,9 +272,11 @@ func (b *SelectBuilder) GroupBy(columns ...string) *SelectBuilder {
 	return b
 }
 
-// OrderBy sets ORDER BY clause on the query.
+// OrderBy sets ORDER BY clause on the query. Call it once per clustering
+// column to order by a compound clustering key, each column gets its own
+// direction i.e. OrderBy("a", ASC).OrderBy("b", DESC).
 func (b *SelectBuilder) OrderBy(column string, o Order) *SelectBuilder {
-	b.orderBy = append(b.orderBy, column+" "+o.String())
+	b.orderBy = append(b.orderBy, quoteIdent(column)+" "+o.String())
 	return b
 }
 
@@ -246,6 +343,40 @@ func (b *SelectBuilder) Sum(column string) *SelectBuilder {
 	return b
 }
 
+// Writetime produces 'writetime(column) AS writetime_column' for every
+// column.
+func (b *SelectBuilder) Writetime(columns ...string) *SelectBuilder {
+	for _, c := range columns {
+		b.Columns(Writetime(c))
+	}
+	return b
+}
+
+// TTL produces 'ttl(column) AS ttl_column' for every column.
+func (b *SelectBuilder) TTL(columns ...string) *SelectBuilder {
+	for _, c := range columns {
+		b.Columns(TTLOf(c))
+	}
+	return b
+}
+
 func (b *SelectBuilder) fn(name, column string) {
-	b.Columns(name + "(" + column + ")")
+	b.Columns(name + "(" + quoteIdent(column) + ")")
+}
+
+// Timeout adds USING TIMEOUT clause to the query.
+//
+// USING TIMEOUT is a feature specific to ScyllaDB, it sets a server side
+// timeout of the query. Use gocqlx.Session.CheckUsingTimeout to validate
+// that the cluster supports it.
+func (b *SelectBuilder) Timeout(d time.Duration) *SelectBuilder {
+	b.using.Timeout(d)
+	return b
+}
+
+// TimeoutNamed adds USING TIMEOUT clause to the query with a custom parameter
+// name, the bound value shall be a gocql.Duration.
+func (b *SelectBuilder) TimeoutNamed(name string) *SelectBuilder {
+	b.using.TimeoutNamed(name)
+	return b
 }
@@ -5,7 +5,10 @@
 package qb
 
 import (
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -77,6 +80,29 @@ func TestSelectBuilder(t *testing.T) {
 			S: "SELECT * FROM cycling.cyclist_name WHERE id=(?,?) AND firstname>(?,?) ",
 			N: []string{"id_0", "id_1", "firstname_0", "firstname_1"},
 		},
+		// Add WHERE with map entry
+		{
+			B: Select("cycling.cyclist_name").Where(EqElement("teams")),
+			S: "SELECT * FROM cycling.cyclist_name WHERE teams[?]=? ",
+			N: []string{"teams_key", "teams"},
+		},
+		// Add UDT field
+		{
+			B: Select("cycling.cyclist_name").Columns("id", UDTField("address", "city")).Where(Eq(UDTField("address", "country", "code"))),
+			S: "SELECT id,address.city FROM cycling.cyclist_name WHERE address.country.code=? ",
+			N: []string{"address.country.code"},
+		},
+		// Add WHERE with timeuuid range
+		{
+			B: Select("cycling.cyclist_name").Where(w).Where(TimeuuidRange("event_id")...),
+			S: "SELECT * FROM cycling.cyclist_name WHERE id=? AND event_id>maxTimeuuid(?) AND event_id<minTimeuuid(?) ",
+			N: []string{"expr", "event_id_from", "event_id_to"},
+		},
+		{
+			B: Select("cycling.cyclist_name").Where(append(TimeuuidRangeNamed("event_id", "since", "until"), w)...),
+			S: "SELECT * FROM cycling.cyclist_name WHERE event_id>maxTimeuuid(?) AND event_id<minTimeuuid(?) AND id=? ",
+			N: []string{"since", "until", "expr"},
+		},
 		// Add GROUP BY
 		{
 			B: Select("cycling.cyclist_name").Columns("MAX(stars) as max_stars").GroupBy("id"),
@@ -110,6 +136,12 @@ func TestSelectBuilder(t *testing.T) {
 			S: "SELECT * FROM cycling.cyclist_name WHERE id=? ORDER BY firstname ASC,lastname DESC ",
 			N: []string{"expr"},
 		},
+		// Add ORDER BY compound clustering key with LIMIT
+		{
+			B: Select("cycling.cyclist_name").Where(w).OrderBy("race_year", DESC).OrderBy("race_month", DESC).OrderBy("race_day", ASC).Limit(5),
+			S: "SELECT * FROM cycling.cyclist_name WHERE id=? ORDER BY race_year DESC,race_month DESC,race_day ASC LIMIT 5 ",
+			N: []string{"expr"},
+		},
 		// Add LIMIT
 		{
 			B: Select("cycling.cyclist_name").Where(w).Limit(10),
@@ -140,6 +172,60 @@ func TestSelectBuilder(t *testing.T) {
 			S: "SELECT * FROM cycling.cyclist_name WHERE id=? BYPASS CACHE ",
 			N: []string{"expr"},
 		},
+		// Add BYPASS CACHE to a full scan
+		{
+			B: Select("cycling.cyclist_name").Limit(10).AllowFiltering().BypassCache(),
+			S: "SELECT * FROM cycling.cyclist_name LIMIT 10 ALLOW FILTERING BYPASS CACHE ",
+		},
+		// Add USING TIMEOUT
+		{
+			B: Select("cycling.cyclist_name").Where(w).BypassCache().Timeout(time.Second),
+			S: "SELECT * FROM cycling.cyclist_name WHERE id=? BYPASS CACHE USING TIMEOUT 1s ",
+			N: []string{"expr"},
+		},
+		{
+			B: Select("cycling.cyclist_name").Where(w).TimeoutNamed("timeout"),
+			S: "SELECT * FROM cycling.cyclist_name WHERE id=? USING TIMEOUT ? ",
+			N: []string{"expr", "timeout"},
+		},
+		// Add WRITETIME and TTL
+		{
+			B: Select("cycling.cyclist_name").Columns("id", "name").Writetime("name").TTL("name").Where(w),
+			S: "SELECT id,name,writetime(name) AS writetime_name,ttl(name) AS ttl_name FROM cycling.cyclist_name WHERE id=? ",
+			N: []string{"expr"},
+		},
+		{
+			B: Select("cycling.cyclist_name").Columns(Writetime("name"), TTLOf("stars")),
+			S: "SELECT writetime(name) AS writetime_name,ttl(stars) AS ttl_stars FROM cycling.cyclist_name ",
+		},
+		// Add CAST
+		{
+			B: Select("cycling.cyclist_name").Columns("id", Cast("ts", "text")),
+			S: "SELECT id,CAST(ts AS text) AS ts FROM cycling.cyclist_name ",
+		},
+		// Add aggregate helpers
+		{
+			B: Select("cycling.cyclist_name").Columns(As(CountAll(), "total"), As(Min("stars"), "min_stars"),
+				As(Max("stars"), "max_stars"), As(Sum("stars"), "sum_stars"), As(Avg("stars"), "avg_stars")).Where(w),
+			S: "SELECT count(*) AS total,min(stars) AS min_stars,max(stars) AS max_stars,sum(stars) AS sum_stars," +
+				"avg(stars) AS avg_stars FROM cycling.cyclist_name WHERE id=? ",
+			N: []string{"expr"},
+		},
+		{
+			B: Select("cycling.cyclist_name").Columns(As(Count("stars"), "stars")).GroupBy("id"),
+			S: "SELECT id,count(stars) AS stars FROM cycling.cyclist_name GROUP BY id ",
+		},
+		// Add raw columns
+		{
+			B: Select("cycling.cyclist_name").Columns("id").RawColumns("blobAsText(:prefix) AS p").Where(w),
+			S: "SELECT id,blobAsText(?) AS p FROM cycling.cyclist_name WHERE id=? ",
+			N: []string{"prefix", "expr"},
+		},
+		{
+			B: Select("cycling.cyclist_name").RawColumns("a", "b").Where(RawCmp("id=:id")),
+			S: "SELECT a,b FROM cycling.cyclist_name WHERE id=? ",
+			N: []string{"id"},
+		},
 		// Add COUNT all
 		{
 			B: Select("cycling.cyclist_name").CountAll().Where(Gt("stars")),
@@ -171,6 +257,39 @@ func TestSelectBuilder(t *testing.T) {
 			B: Select("cycling.cyclist_name").Max("stars"),
 			S: "SELECT max(stars) FROM cycling.cyclist_name ",
 		},
+		// Add ORDER BY ANN OF
+		{
+			B: Select("cycling.comments_vs").Columns("comment").OrderByANN("comment_vector").Limit(3),
+			S: "SELECT comment FROM cycling.comments_vs ORDER BY comment_vector ANN OF ? LIMIT 3 ",
+			N: []string{"comment_vector"},
+		},
+		// Add ORDER BY ANN OF with a custom name
+		{
+			B: Select("cycling.comments_vs").Where(w).OrderByANNNamed("comment_vector", "vector").Limit(3),
+			S: "SELECT * FROM cycling.comments_vs WHERE id=? ORDER BY comment_vector ANN OF ? LIMIT 3 ",
+			N: []string{"expr", "vector"},
+		},
+		// Add ORDER BY ANN OF literal
+		{
+			B: Select("cycling.comments_vs").OrderByANNLit("comment_vector", "[0.1, 0.2]").Limit(3),
+			S: "SELECT * FROM cycling.comments_vs ORDER BY comment_vector ANN OF [0.1, 0.2] LIMIT 3 ",
+		},
+		// Add similarity functions with ANN OF
+		{
+			B: Select("cycling.comments_vs").Columns("comment").
+				SimilarityCosine("comment_vector", "vector").
+				SimilarityDotProduct("comment_vector", "vector").
+				OrderByANNNamed("comment_vector", "vector").
+				Limit(3),
+			S: "SELECT comment,similarity_cosine(comment_vector,?) AS similarity_cosine_comment_vector,similarity_dot_product(comment_vector,?) AS similarity_dot_product_comment_vector FROM cycling.comments_vs ORDER BY comment_vector ANN OF ? LIMIT 3 ",
+			N: []string{"vector", "vector", "vector"},
+		},
+		// Add similarity_euclidean
+		{
+			B: Select("cycling.comments_vs").SimilarityEuclidean("comment_vector", "vector"),
+			S: "SELECT similarity_euclidean(comment_vector,?) AS similarity_euclidean_comment_vector FROM cycling.comments_vs ",
+			N: []string{"vector"},
+		},
 	}
 
 	for _, test := range table {
@@ -183,3 +302,49 @@ func TestSelectBuilder(t *testing.T) {
 		}
 	}
 }
+
+func TestSelectBuilderClone(t *testing.T) {
+	base := Select("cycling.cyclist_name").Columns("id", "name").Where(Eq("id"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			b := base.Clone().Columns("stars").Where(Gt("stars")).Limit(uint(i + 1))
+			stmt, names := b.ToCql()
+			expected := fmt.Sprintf("SELECT id,name,stars FROM cycling.cyclist_name WHERE id=? AND stars>? LIMIT %d ", i+1)
+			if diff := cmp.Diff(expected, stmt); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff([]string{"id", "stars"}, names); diff != "" {
+				t.Error(diff)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	stmt, names := base.ToCql()
+	if diff := cmp.Diff("SELECT id,name FROM cycling.cyclist_name WHERE id=? ", stmt); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"id"}, names); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestSelectBuilderCloneANN(t *testing.T) {
+	base := Select("cycling.comments").Columns("id").OrderByANN("embedding").Limit(3)
+	b := base.Clone()
+	if b.ann == base.ann {
+		t.Fatal("Clone() shares ANN ordering")
+	}
+
+	stmt, names := b.ToCql()
+	if diff := cmp.Diff("SELECT id FROM cycling.comments ORDER BY embedding ANN OF ? LIMIT 3 ", stmt); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"embedding"}, names); diff != "" {
+		t.Error(diff)
+	}
+}
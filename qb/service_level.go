@@ -0,0 +1,158 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+// Service levels reference:
+// https://docs.scylladb.com/stable/features/workload-prioritization.html
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+// Workload types of a service level.
+const (
+	WorkloadInteractive = "interactive"
+	WorkloadBatch       = "batch"
+)
+
+// ServiceLevelBuilder builds CQL CREATE SERVICE_LEVEL and ALTER SERVICE_LEVEL
+// statements.
+//
+// Service levels are a feature specific to ScyllaDB, use
+// gocqlx.Session.HasScyllaFeature to check if the cluster supports them.
+type ServiceLevelBuilder struct {
+	name         string
+	alter        bool
+	ifNotExists  bool
+	shares       int
+	timeout      time.Duration
+	workloadType string
+}
+
+// CreateServiceLevel returns a new ServiceLevelBuilder building a CREATE
+// SERVICE_LEVEL statement for the given service level.
+func CreateServiceLevel(name string) *ServiceLevelBuilder {
+	return &ServiceLevelBuilder{
+		name: name,
+	}
+}
+
+// AlterServiceLevel returns a new ServiceLevelBuilder building an ALTER
+// SERVICE_LEVEL statement for the given service level.
+func AlterServiceLevel(name string) *ServiceLevelBuilder {
+	return &ServiceLevelBuilder{
+		name:  name,
+		alter: true,
+	}
+}
+
+// ToCql builds the query into a CQL string and named args.
+func (b *ServiceLevelBuilder) ToCql() (stmt string, names []string) {
+	cql := bytes.Buffer{}
+
+	if b.alter {
+		cql.WriteString("ALTER SERVICE_LEVEL ")
+	} else {
+		cql.WriteString("CREATE SERVICE_LEVEL ")
+	}
+	if b.ifNotExists {
+		cql.WriteString("IF NOT EXISTS ")
+	}
+	cql.WriteString(b.name)
+	cql.WriteByte(' ')
+
+	var opts options
+	if b.shares > 0 {
+		opts = append(opts, option{name: "shares", value: fmt.Sprint(b.shares)})
+	}
+	if b.timeout > 0 {
+		d := bytes.Buffer{}
+		writeDuration(&d, b.timeout)
+		opts = append(opts, option{name: "timeout", value: d.String()})
+	}
+	if b.workloadType != "" {
+		opts = append(opts, option{name: "workload_type", value: "'" + b.workloadType + "'"})
+	}
+	if len(opts) > 0 {
+		cql.WriteString("WITH ")
+		opts.writeCql(&cql)
+		cql.WriteByte(' ')
+	}
+
+	stmt = cql.String()
+	return
+}
+
+// IfNotExists sets a IF NOT EXISTS clause on the query.
+func (b *ServiceLevelBuilder) IfNotExists() *ServiceLevelBuilder {
+	b.ifNotExists = true
+	return b
+}
+
+// Shares sets the shares option, the share of resources the service level
+// gets relative to other service levels. Shares are only available in
+// ScyllaDB Enterprise.
+func (b *ServiceLevelBuilder) Shares(shares int) *ServiceLevelBuilder {
+	b.shares = shares
+	return b
+}
+
+// Timeout sets the timeout option, the default timeout of queries run by roles
+// attached to the service level.
+func (b *ServiceLevelBuilder) Timeout(timeout time.Duration) *ServiceLevelBuilder {
+	b.timeout = timeout
+	return b
+}
+
+// WorkloadType sets the workload_type option, use WorkloadInteractive or
+// WorkloadBatch.
+func (b *ServiceLevelBuilder) WorkloadType(workloadType string) *ServiceLevelBuilder {
+	b.workloadType = workloadType
+	return b
+}
+
+// AttachServiceLevelBuilder builds CQL ATTACH SERVICE_LEVEL and DETACH
+// SERVICE_LEVEL statements.
+type AttachServiceLevelBuilder struct {
+	name string
+	role string
+}
+
+// AttachServiceLevel returns a new AttachServiceLevelBuilder attaching the
+// service level to a role.
+func AttachServiceLevel(name, role string) *AttachServiceLevelBuilder {
+	return &AttachServiceLevelBuilder{
+		name: name,
+		role: role,
+	}
+}
+
+// DetachServiceLevel returns a new AttachServiceLevelBuilder detaching
+// the service level from a role.
+func DetachServiceLevel(role string) *AttachServiceLevelBuilder {
+	return &AttachServiceLevelBuilder{
+		role: role,
+	}
+}
+
+// ToCql builds the query into a CQL string and named args.
+func (b *AttachServiceLevelBuilder) ToCql() (stmt string, names []string) {
+	cql := bytes.Buffer{}
+
+	if b.name != "" {
+		cql.WriteString("ATTACH SERVICE_LEVEL ")
+		cql.WriteString(b.name)
+		cql.WriteString(" TO ")
+	} else {
+		cql.WriteString("DETACH SERVICE_LEVEL FROM ")
+	}
+	cql.WriteString(b.role)
+	cql.WriteByte(' ')
+
+	stmt = cql.String()
+	return
+}
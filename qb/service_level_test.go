@@ -0,0 +1,55 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestServiceLevelBuilder(t *testing.T) {
+	table := []struct {
+		B Builder
+		S string
+	}{
+		// Basic test for create service level
+		{
+			B: CreateServiceLevel("sl"),
+			S: "CREATE SERVICE_LEVEL sl ",
+		},
+		// Add IF NOT EXISTS and options
+		{
+			B: CreateServiceLevel("sl").IfNotExists().Shares(500).Timeout(10 * time.Millisecond).WorkloadType(WorkloadBatch),
+			S: "CREATE SERVICE_LEVEL IF NOT EXISTS sl WITH shares=500 AND timeout=10ms AND workload_type='batch' ",
+		},
+		// Alter service level
+		{
+			B: AlterServiceLevel("sl").Timeout(2 * time.Second),
+			S: "ALTER SERVICE_LEVEL sl WITH timeout=2s ",
+		},
+		// Attach service level
+		{
+			B: AttachServiceLevel("sl", "analytics"),
+			S: "ATTACH SERVICE_LEVEL sl TO analytics ",
+		},
+		// Detach service level
+		{
+			B: DetachServiceLevel("analytics"),
+			S: "DETACH SERVICE_LEVEL FROM analytics ",
+		},
+	}
+
+	for _, test := range table {
+		stmt, names := test.B.ToCql()
+		if diff := cmp.Diff(test.S, stmt); diff != "" {
+			t.Error(diff)
+		}
+		if len(names) != 0 {
+			t.Error("unexpected names", names)
+		}
+	}
+}
@@ -6,7 +6,6 @@ package qb
 
 import (
 	"fmt"
-	"strings"
 )
 
 // TokenBuilder helps implement pagination using token function.
@@ -117,6 +116,46 @@ func (t TokenBuilder) GtOrEqValueNamed(name string) Cmp {
 	return t.valueCmp(geq, name)
 }
 
+// TokenGt produces token(column)>token(?).
+func TokenGt(columns ...string) Cmp {
+	return Token(columns...).Gt()
+}
+
+// TokenGtValue produces token(column)>?.
+func TokenGtValue(columns ...string) Cmp {
+	return Token(columns...).GtValue()
+}
+
+// TokenGtOrEq produces token(column)>=token(?).
+func TokenGtOrEq(columns ...string) Cmp {
+	return Token(columns...).GtOrEq()
+}
+
+// TokenGtOrEqValue produces token(column)>=?.
+func TokenGtOrEqValue(columns ...string) Cmp {
+	return Token(columns...).GtOrEqValue()
+}
+
+// TokenLt produces token(column)<token(?).
+func TokenLt(columns ...string) Cmp {
+	return Token(columns...).Lt()
+}
+
+// TokenLtValue produces token(column)<?.
+func TokenLtValue(columns ...string) Cmp {
+	return Token(columns...).LtValue()
+}
+
+// TokenLtOrEq produces token(column)<=token(?).
+func TokenLtOrEq(columns ...string) Cmp {
+	return Token(columns...).LtOrEq()
+}
+
+// TokenLtOrEqValue produces token(column)<=?.
+func TokenLtOrEqValue(columns ...string) Cmp {
+	return Token(columns...).LtOrEqValue()
+}
+
 func (t TokenBuilder) cmp(op op, names []string) Cmp {
 	s := names
 	if s == nil {
@@ -124,7 +163,7 @@ func (t TokenBuilder) cmp(op op, names []string) Cmp {
 	}
 	return Cmp{
 		op:     op,
-		column: fmt.Sprint("token(", strings.Join(t, ","), ")"),
+		column: fmt.Sprint("token(", quoteIdents(t), ")"),
 		value:  Fn("token", s...),
 	}
 }
@@ -135,7 +174,7 @@ func (t TokenBuilder) valueCmp(op op, name string) Cmp {
 	}
 	return Cmp{
 		op:     op,
-		column: fmt.Sprint("token(", strings.Join(t, ","), ")"),
+		column: fmt.Sprint("token(", quoteIdents(t), ")"),
 		value:  param(name),
 	}
 }
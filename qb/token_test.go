@@ -120,6 +120,48 @@ func TestToken(t *testing.T) {
 			S: "token(a,b)>=?",
 			N: []string{"c"},
 		},
+
+		// Range comparators
+		{
+			C: TokenGt("a", "b"),
+			S: "token(a,b)>token(?,?)",
+			N: []string{"a", "b"},
+		},
+		{
+			C: TokenGtValue("a", "b"),
+			S: "token(a,b)>?",
+			N: []string{"token"},
+		},
+		{
+			C: TokenGtOrEq("a", "b"),
+			S: "token(a,b)>=token(?,?)",
+			N: []string{"a", "b"},
+		},
+		{
+			C: TokenGtOrEqValue("a", "b"),
+			S: "token(a,b)>=?",
+			N: []string{"token"},
+		},
+		{
+			C: TokenLt("a", "b"),
+			S: "token(a,b)<token(?,?)",
+			N: []string{"a", "b"},
+		},
+		{
+			C: TokenLtValue("a", "b"),
+			S: "token(a,b)<?",
+			N: []string{"token"},
+		},
+		{
+			C: TokenLtOrEq("a", "b"),
+			S: "token(a,b)<=token(?,?)",
+			N: []string{"a", "b"},
+		},
+		{
+			C: TokenLtOrEqValue("a", "b"),
+			S: "token(a,b)<=?",
+			N: []string{"token"},
+		},
 	}
 
 	buf := bytes.Buffer{}
@@ -15,15 +15,27 @@ import (
 // assignment specifies an assignment in a set operation.
 type assignment struct {
 	column      string
+	key         value // The collection element key or index, optional.
 	value       value
 	valuePrefix string // Tbe value prefix to use for add/remove operations.
+	valueSuffix string // The value suffix to use for prepend operations.
 }
 
 func (a assignment) writeCql(cql *bytes.Buffer) (names []string) {
-	cql.WriteString(a.column)
+	if a.column == "" {
+		return a.value.writeCql(cql)
+	}
+	writeIdent(cql, a.column)
+	if a.key != nil {
+		cql.WriteByte('[')
+		names = append(names, a.key.writeCql(cql)...)
+		cql.WriteByte(']')
+	}
 	cql.WriteByte('=')
 	cql.WriteString(a.valuePrefix)
-	return a.value.writeCql(cql)
+	names = append(names, a.value.writeCql(cql)...)
+	cql.WriteString(a.valueSuffix)
+	return
 }
 
 // UpdateBuilder builds CQL UPDATE statements.
@@ -43,12 +55,22 @@ func Update(table string) *UpdateBuilder {
 	}
 }
 
+// Clone returns a copy of the builder. A base builder can be shared and
+// specialized with clones without affecting the base or other clones.
+func (b *UpdateBuilder) Clone() *UpdateBuilder {
+	c := *b
+	c.assignments = append([]assignment(nil), b.assignments...)
+	c.where = append(where(nil), b.where...)
+	c._if = append(_if(nil), b._if...)
+	return &c
+}
+
 // ToCql builds the query into a CQL string and named args.
 func (b *UpdateBuilder) ToCql() (stmt string, names []string) {
 	cql := bytes.Buffer{}
 
 	cql.WriteString("UPDATE ")
-	cql.WriteString(b.table)
+	writeIdent(&cql, b.table)
 	cql.WriteByte(' ')
 
 	names = append(names, b.using.writeCql(&cql)...)
@@ -131,6 +153,16 @@ func (b *UpdateBuilder) SetLit(column, literal string) *UpdateBuilder {
 	return b
 }
 
+// SetRaw adds a raw CQL assignment i.e. "tags=tags+{:tag}" to the SET clause
+// of the query, named parameters in form of :name are replaced with ? and
+// added to query names.
+func (b *UpdateBuilder) SetRaw(cql string) *UpdateBuilder {
+	b.assignments = append(b.assignments, assignment{
+		value: raw(cql),
+	})
+	return b
+}
+
 // SetFunc adds SET column=someFunc(?...) clause to the query.
 func (b *UpdateBuilder) SetFunc(column string, fn *Func) *UpdateBuilder {
 	b.assignments = append(b.assignments, assignment{column: column, value: fn})
@@ -149,7 +181,27 @@ func (b *UpdateBuilder) SetTuple(column string, count int) *UpdateBuilder {
 	return b
 }
 
-// Add adds SET column=column+? clauses to the query.
+// SetElement adds SET column[?]=? clause to the query, it sets a map value
+// for a key or a list element at an index. The key parameter name is
+// column_key.
+func (b *UpdateBuilder) SetElement(column string) *UpdateBuilder {
+	return b.SetElementNamed(column, column+"_key", column)
+}
+
+// SetElementNamed adds SET column[?]=? clause to the query with custom key and
+// value parameter names.
+func (b *UpdateBuilder) SetElementNamed(column, keyName, valueName string) *UpdateBuilder {
+	b.assignments = append(b.assignments, assignment{
+		column: column,
+		key:    param(keyName),
+		value:  param(valueName),
+	})
+	return b
+}
+
+// Add adds SET column=column+? clauses to the query. It can be used to
+// increment a counter, append to a list, add elements to a set or put entries
+// to a map.
 func (b *UpdateBuilder) Add(column string) *UpdateBuilder {
 	return b.addValue(column, param(column))
 }
@@ -174,12 +226,40 @@ func (b *UpdateBuilder) addValue(column string, value value) *UpdateBuilder {
 	b.assignments = append(b.assignments, assignment{
 		column:      column,
 		value:       value,
-		valuePrefix: column + "+",
+		valuePrefix: quoteIdent(column) + "+",
+	})
+	return b
+}
+
+// Prepend adds SET column=?+column clauses to the query, it prepends
+// elements to a list.
+func (b *UpdateBuilder) Prepend(column string) *UpdateBuilder {
+	return b.prependValue(column, param(column))
+}
+
+// PrependNamed adds SET column=?+column clauses to the query with a custom
+// parameter name.
+func (b *UpdateBuilder) PrependNamed(column, name string) *UpdateBuilder {
+	return b.prependValue(column, param(name))
+}
+
+// PrependLit adds SET column=literal+column clauses to the query.
+func (b *UpdateBuilder) PrependLit(column, literal string) *UpdateBuilder {
+	return b.prependValue(column, lit(literal))
+}
+
+func (b *UpdateBuilder) prependValue(column string, value value) *UpdateBuilder {
+	b.assignments = append(b.assignments, assignment{
+		column:      column,
+		value:       value,
+		valueSuffix: "+" + quoteIdent(column),
 	})
 	return b
 }
 
-// Remove adds SET column=column-? clauses to the query.
+// Remove adds SET column=column-? clauses to the query. It can be used to
+// decrement a counter, remove elements from a list or a set or remove keys
+// from a map.
 func (b *UpdateBuilder) Remove(column string) *UpdateBuilder {
 	return b.removeValue(column, param(column))
 }
@@ -204,7 +284,7 @@ func (b *UpdateBuilder) removeValue(column string, value value) *UpdateBuilder {
 	b.assignments = append(b.assignments, assignment{
 		column:      column,
 		value:       value,
-		valuePrefix: column + "-",
+		valuePrefix: quoteIdent(column) + "-",
 	})
 	return b
 }
@@ -236,3 +316,20 @@ func (b *UpdateBuilder) Existing() *UpdateBuilder {
 	b.exists = true
 	return b
 }
+
+// Timeout adds USING TIMEOUT clause to the query.
+//
+// USING TIMEOUT is a feature specific to ScyllaDB, it sets a server side
+// timeout of the query. Use gocqlx.Session.CheckUsingTimeout to validate
+// that the cluster supports it.
+func (b *UpdateBuilder) Timeout(d time.Duration) *UpdateBuilder {
+	b.using.Timeout(d)
+	return b
+}
+
+// TimeoutNamed adds USING TIMEOUT clause to the query with a custom parameter
+// name, the bound value shall be a gocql.Duration.
+func (b *UpdateBuilder) TimeoutNamed(name string) *UpdateBuilder {
+	b.using.TimeoutNamed(name)
+	return b
+}
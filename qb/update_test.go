@@ -5,6 +5,7 @@
 package qb
 
 import (
+	"sync"
 	"testing"
 	"time"
 
@@ -56,6 +57,17 @@ func TestUpdateBuilder(t *testing.T) {
 			S: "UPDATE cycling.cyclist_name SET user_uuid=someFunc(?,?),stars=? WHERE id=? ",
 			N: []string{"param_0", "param_1", "stars", "expr"},
 		},
+		{
+			B: Update("cycling.cyclist_name").SetFunc("updated_at", CurrentTimestamp()).SetFunc("day", ToDate(Now())).Where(w),
+			S: "UPDATE cycling.cyclist_name SET updated_at=currentTimestamp(),day=toDate(now()) WHERE id=? ",
+			N: []string{"expr"},
+		},
+		// Add SET SetRaw
+		{
+			B: Update("cycling.cyclist_name").Set("stars").SetRaw("tags=tags+{:tag}").Where(w),
+			S: "UPDATE cycling.cyclist_name SET stars=?,tags=tags+{?} WHERE id=? ",
+			N: []string{"stars", "tag", "expr"},
+		},
 		// Add SET Add
 		{
 			B: Update("cycling.cyclist_name").Add("total").Where(w),
@@ -92,6 +104,42 @@ func TestUpdateBuilder(t *testing.T) {
 			S: "UPDATE cycling.cyclist_name SET total=total-1 WHERE id=? ",
 			N: []string{"expr"},
 		},
+		// Update counters
+		{
+			B: Update("cycling.popular_count").Add("popularity").AddNamed("views", "inc").RemoveNamed("dislikes", "dec").Where(w),
+			S: "UPDATE cycling.popular_count SET popularity=popularity+?,views=views+?,dislikes=dislikes-? WHERE id=? ",
+			N: []string{"popularity", "inc", "dec", "expr"},
+		},
+		// Add SET Prepend
+		{
+			B: Update("cycling.cyclist_name").Prepend("tags").Where(w),
+			S: "UPDATE cycling.cyclist_name SET tags=?+tags WHERE id=? ",
+			N: []string{"tags", "expr"},
+		},
+		// Add SET PrependNamed
+		{
+			B: Update("cycling.cyclist_name").PrependNamed("tags", "new_tags").Where(w),
+			S: "UPDATE cycling.cyclist_name SET tags=?+tags WHERE id=? ",
+			N: []string{"new_tags", "expr"},
+		},
+		// Add SET PrependLit
+		{
+			B: Update("cycling.cyclist_name").PrependLit("tags", "['a']").Where(w),
+			S: "UPDATE cycling.cyclist_name SET tags=['a']+tags WHERE id=? ",
+			N: []string{"expr"},
+		},
+		// Add SET SetElement
+		{
+			B: Update("cycling.cyclist_name").SetElement("tags").Where(w),
+			S: "UPDATE cycling.cyclist_name SET tags[?]=? WHERE id=? ",
+			N: []string{"tags_key", "tags", "expr"},
+		},
+		// Add SET SetElementNamed
+		{
+			B: Update("cycling.cyclist_name").SetElementNamed("tags", "k", "v").Set("stars").Where(w),
+			S: "UPDATE cycling.cyclist_name SET tags[?]=?,stars=? WHERE id=? ",
+			N: []string{"k", "v", "stars", "expr"},
+		},
 		// Add WHERE
 		{
 			B: Update("cycling.cyclist_name").Set("id", "user_uuid", "firstname").Where(w, Gt("firstname")),
@@ -104,6 +152,12 @@ func TestUpdateBuilder(t *testing.T) {
 			S: "UPDATE cycling.cyclist_name SET id=?,user_uuid=?,firstname=? WHERE id=? IF firstname>? ",
 			N: []string{"id", "user_uuid", "firstname", "expr", "firstname"},
 		},
+		// Add IF with multiple conditions
+		{
+			B: Update("cycling.cyclist_name").Set("firstname").Where(w).If(Eq("version"), InNamed("state", "states")),
+			S: "UPDATE cycling.cyclist_name SET firstname=? WHERE id=? IF version=? AND state IN ? ",
+			N: []string{"firstname", "expr", "version", "states"},
+		},
 		// Add TTL
 		{
 			B: Update("cycling.cyclist_name").Set("id", "user_uuid", "firstname").Where(w).TTL(time.Second),
@@ -150,6 +204,12 @@ func TestUpdateBuilder(t *testing.T) {
 			S: "UPDATE cycling.cyclist_name SET timestamp=timestamp-now() ",
 			N: nil,
 		},
+		// Add USING TIMEOUT
+		{
+			B: Update("cycling.cyclist_name").Set("id").TimeoutNamed("timeout"),
+			S: "UPDATE cycling.cyclist_name USING TIMEOUT ? SET id=? ",
+			N: []string{"timeout", "id"},
+		},
 	}
 
 	for _, test := range table {
@@ -162,3 +222,34 @@ func TestUpdateBuilder(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateBuilderClone(t *testing.T) {
+	base := Update("cycling.cyclist_name").Set("name").Where(Eq("id"))
+	b := base.Clone().Set("stars").Where(Eq("race")).If(Eq("version"))
+
+	stmt, _ := b.ToCql()
+	if diff := cmp.Diff("UPDATE cycling.cyclist_name SET name=?,stars=? WHERE id=? AND race=? IF version=? ", stmt); diff != "" {
+		t.Error(diff)
+	}
+	stmt, _ = base.ToCql()
+	if diff := cmp.Diff("UPDATE cycling.cyclist_name SET name=? WHERE id=? ", stmt); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestUpdateBuilderConcurrentToCql(t *testing.T) {
+	b := Update("cycling.cyclist_name").TTL(0).Set("name").Where(Eq("id"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stmt, _ := b.ToCql()
+			if diff := cmp.Diff("UPDATE cycling.cyclist_name USING TTL 0 SET name=? WHERE id=? ", stmt); diff != "" {
+				t.Error(diff)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,228 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Usage describes the table and the columns a statement reads and writes,
+// it can be exported as JSON. Columns read are the result columns and the
+// columns of relations and conditions, "*" stands for all columns.
+type Usage struct {
+	Name  string   `json:"name"`
+	Table string   `json:"table"`
+	Read  []string `json:"read,omitempty"`
+	Write []string `json:"write,omitempty"`
+}
+
+// Statements is a registry of named statements, it's used for impact
+// analysis of schema changes and for documenting data flows.
+type Statements struct {
+	builders map[string]Builder
+}
+
+// Register adds a named statement, it panics if name is already registered.
+func (s *Statements) Register(name string, b Builder) {
+	if s.builders == nil {
+		s.builders = make(map[string]Builder)
+	}
+	if _, ok := s.builders[name]; ok {
+		panic(fmt.Sprintf("statement %q already registered", name))
+	}
+	s.builders[name] = b
+}
+
+// Usage returns usage of the registered SELECT, INSERT, UPDATE and DELETE
+// statements sorted by name, other statements are not reported. Columns
+// given as expressions are reported if they can be recognized i.e.
+// writetime(column), relations added with RawCmp are not inspected.
+func (s *Statements) Usage() []Usage {
+	var u []Usage
+	for name, b := range s.builders {
+		if v, ok := StatementUsage(b); ok {
+			v.Name = name
+			u = append(u, v)
+		}
+	}
+	sort.Slice(u, func(i, j int) bool { return u[i].Name < u[j].Name })
+	return u
+}
+
+// StatementUsage returns usage of a SELECT, INSERT, UPDATE or DELETE
+// statement, it returns false for other statements.
+func StatementUsage(b Builder) (Usage, bool) {
+	var u usage
+	switch b := b.(type) {
+	case *SelectBuilder:
+		u.table = b.table
+		for _, c := range b.distinct {
+			u.read(c)
+		}
+		for _, c := range b.groupBy {
+			u.read(c)
+		}
+		for _, c := range b.columns {
+			u.read(c)
+		}
+		for _, c := range b.rawColumns {
+			u.read(string(c))
+		}
+		if len(b.distinct) == 0 && len(b.groupBy) == 0 && len(b.columns) == 0 && len(b.rawColumns) == 0 {
+			u.read("*")
+		}
+		u.cmps(cmps(b.where))
+		for _, c := range b.orderBy {
+			u.read(strings.Fields(c)[0])
+		}
+		if b.ann != nil {
+			u.read(b.ann.column)
+		}
+	case *InsertBuilder:
+		u.table = b.table
+		for _, c := range b.columns {
+			u.write(c.column)
+		}
+		if b.json {
+			u.write("*")
+		}
+	case *UpdateBuilder:
+		u.table = b.table
+		for _, a := range b.assignments {
+			u.write(a.column)
+		}
+		u.cmps(cmps(b.where))
+		u.cmps(cmps(b._if))
+	case *DeleteBuilder:
+		u.table = b.table
+		for _, s := range b.columns {
+			u.write(s.column)
+		}
+		if len(b.columns) == 0 {
+			u.write("*")
+		}
+		u.cmps(cmps(b.where))
+		u.cmps(cmps(b._if))
+	default:
+		return Usage{}, false
+	}
+
+	return Usage{
+		Table: u.table,
+		Read:  u.r,
+		Write: u.w,
+	}, true
+}
+
+type usage struct {
+	table string
+	r     []string
+	w     []string
+}
+
+func (u *usage) read(expr string) {
+	u.r = appendColumns(u.r, expr)
+}
+
+func (u *usage) write(expr string) {
+	u.w = appendColumns(u.w, expr)
+}
+
+func (u *usage) cmps(cs cmps) {
+	for _, c := range cs {
+		u.read(c.column)
+	}
+}
+
+// appendColumns appends columns referenced by expr that are not in s.
+func appendColumns(s []string, expr string) []string {
+	for _, c := range exprColumns(expr) {
+		found := false
+		for _, v := range s {
+			if v == c {
+				found = true
+				break
+			}
+		}
+		if !found {
+			s = append(s, c)
+		}
+	}
+	return s
+}
+
+// exprColumns returns columns referenced by expr, expr can be a column,
+// a path of UDT fields, a function call or a tuple of them, optionally
+// followed by AS alias.
+func exprColumns(expr string) []string {
+	expr = strings.TrimSpace(expr)
+	if expr == "*" {
+		return []string{expr}
+	}
+	if i := lastIndexOutsideParens(expr, " AS "); i >= 0 {
+		expr = strings.TrimSpace(expr[:i])
+	}
+
+	if open := strings.IndexByte(expr, '('); open >= 0 && strings.HasSuffix(expr, ")") {
+		var columns []string
+		for _, arg := range splitOutsideParens(expr[open+1 : len(expr)-1]) {
+			columns = append(columns, exprColumns(arg)...)
+		}
+		return columns
+	}
+
+	column := strings.SplitN(expr, ".", 2)[0]
+	if isQuoted(column) {
+		return []string{strings.Replace(column[1:len(column)-1], `""`, `"`, -1)}
+	}
+	if isPlainIdent(column) {
+		return []string{column}
+	}
+	return nil
+}
+
+// lastIndexOutsideParens returns the index of the last occurrence of sep in
+// s that is not enclosed in parentheses, or -1.
+func lastIndexOutsideParens(s, sep string) int {
+	depth, idx := 0, -1
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		default:
+			if depth == 0 && strings.HasPrefix(s[i:], sep) {
+				idx = i
+			}
+		}
+	}
+	return idx
+}
+
+// splitOutsideParens splits s by commas not enclosed in parentheses.
+func splitOutsideParens(s string) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStatementUsage(t *testing.T) {
+	table := []struct {
+		B Builder
+		U Usage
+	}{
+		{
+			B: Select("cycling.cyclist_name").Where(Eq("id")),
+			U: Usage{Table: "cycling.cyclist_name", Read: []string{"*", "id"}},
+		},
+		{
+			B: Select("cycling.cyclist_name").
+				Columns("id", As("firstname", "name"), Writetime("lastname"), Cast("age", "text"), UDTField("address", "city")).
+				RawColumns("blobAsText(:prefix) AS p").
+				Where(Eq("id"), TupleGt([]string{"ts", "Seq"}), Token("id").Gt(), RawCmp("x > 1")).
+				OrderBy("ts", DESC),
+			U: Usage{
+				Table: "cycling.cyclist_name",
+				Read:  []string{"id", "firstname", "lastname", "age", "address", "ts", "Seq"},
+			},
+		},
+		{
+			B: Select("cycling.comments_vs").CountAll().OrderByANN("comment_vector"),
+			U: Usage{Table: "cycling.comments_vs", Read: []string{"*", "comment_vector"}},
+		},
+		{
+			B: Insert("cycling.cyclist_name").Columns("id", "lastname").FuncColumn("ts", Now()),
+			U: Usage{Table: "cycling.cyclist_name", Write: []string{"id", "lastname", "ts"}},
+		},
+		{
+			B: Insert("cycling.cyclist_name").Json(),
+			U: Usage{Table: "cycling.cyclist_name", Write: []string{"*"}},
+		},
+		{
+			B: Update("cycling.cyclist_name").Set("firstname").Add("tags").Where(Eq("id")).If(Eq("version")),
+			U: Usage{Table: "cycling.cyclist_name", Read: []string{"id", "version"}, Write: []string{"firstname", "tags"}},
+		},
+		{
+			B: Delete("cycling.cyclist_name").Where(Eq("id")),
+			U: Usage{Table: "cycling.cyclist_name", Read: []string{"id"}, Write: []string{"*"}},
+		},
+		{
+			B: Delete("cycling.cyclist_name").Columns("tags").Element("emails").Where(Eq("id")),
+			U: Usage{Table: "cycling.cyclist_name", Read: []string{"id"}, Write: []string{"tags", "emails"}},
+		},
+	}
+
+	for _, test := range table {
+		u, ok := StatementUsage(test.B)
+		if !ok {
+			t.Fatal("StatementUsage() expected ok")
+		}
+		if diff := cmp.Diff(test.U, u); diff != "" {
+			t.Error(diff)
+		}
+	}
+
+	if _, ok := StatementUsage(CreateTable("cycling.cyclist_name")); ok {
+		t.Error("StatementUsage() expected not ok for CREATE TABLE")
+	}
+}
+
+func TestStatementsUsage(t *testing.T) {
+	var s Statements
+	s.Register("get", Select("cycling.cyclist_name").Columns("firstname").Where(Eq("id")))
+	s.Register("create", CreateTable("cycling.cyclist_name"))
+	s.Register("delete", Delete("cycling.cyclist_name").Where(Eq("id")))
+
+	b, err := json.Marshal(s.Usage())
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := `[{"name":"delete","table":"cycling.cyclist_name","read":["id"],"write":["*"]},` +
+		`{"name":"get","table":"cycling.cyclist_name","read":["firstname","id"]}]`
+	if diff := cmp.Diff(golden, string(b)); diff != "" {
+		t.Error(diff)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Register() expected panic on duplicate name")
+		}
+	}()
+	s.Register("get", Select("cycling.cyclist_name"))
+}
@@ -25,6 +25,8 @@ type using struct {
 	ttlName       string
 	timestamp     int64
 	timestampName string
+	timeout       time.Duration
+	timeoutName   string
 }
 
 func (u *using) TTL(d time.Duration) *using {
@@ -32,7 +34,7 @@ func (u *using) TTL(d time.Duration) *using {
 	if u.ttl == 0 {
 		u.ttl = -1
 	}
-	u.timestampName = ""
+	u.ttlName = ""
 	return u
 }
 
@@ -54,16 +56,30 @@ func (u *using) TimestampNamed(name string) *using {
 	return u
 }
 
+func (u *using) Timeout(d time.Duration) *using {
+	u.timeout = d
+	u.timeoutName = ""
+	return u
+}
+
+func (u *using) TimeoutNamed(name string) *using {
+	u.timeout = 0
+	u.timeoutName = name
+	return u
+}
+
 func (u *using) writeCql(cql *bytes.Buffer) (names []string) {
 	hasTTL := false
 
 	if u.ttl != 0 {
 		hasTTL = true
-		if u.ttl == -1 {
-			u.ttl = 0
+		// TTL of zero is stored as -1 to tell it apart from unset TTL.
+		ttl := u.ttl
+		if ttl == -1 {
+			ttl = 0
 		}
 		cql.WriteString("USING TTL ")
-		cql.WriteString(fmt.Sprint(u.ttl))
+		cql.WriteString(fmt.Sprint(ttl))
 		cql.WriteByte(' ')
 	} else if u.ttlName != "" {
 		hasTTL = true
@@ -88,5 +104,48 @@ func (u *using) writeCql(cql *bytes.Buffer) (names []string) {
 		names = append(names, u.timestampName)
 	}
 
+	if u.timeout != 0 || u.timeoutName != "" {
+		hasPrefix := hasTTL || u.timestamp != 0 || u.timestampName != ""
+		if hasPrefix {
+			cql.WriteString("AND TIMEOUT ")
+		} else {
+			cql.WriteString("USING TIMEOUT ")
+		}
+		if u.timeout != 0 {
+			writeDuration(cql, u.timeout)
+		} else {
+			cql.WriteByte('?')
+			names = append(names, u.timeoutName)
+		}
+		cql.WriteByte(' ')
+	}
+
 	return
 }
+
+// writeDuration writes d as a CQL duration literal i.e. 1m30s or 500ms.
+func writeDuration(cql *bytes.Buffer, d time.Duration) {
+	units := []struct {
+		d    time.Duration
+		name string
+	}{
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+		{time.Millisecond, "ms"},
+		{time.Microsecond, "us"},
+		{time.Nanosecond, "ns"},
+	}
+
+	if d < 0 {
+		cql.WriteByte('-')
+		d = -d
+	}
+	for _, u := range units {
+		if n := d / u.d; n > 0 {
+			cql.WriteString(fmt.Sprint(int64(n)))
+			cql.WriteString(u.name)
+			d -= n * u.d
+		}
+	}
+}
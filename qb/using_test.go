@@ -35,6 +35,33 @@ func TestUsing(t *testing.T) {
 			B: new(using).TTL(time.Second),
 			S: "USING TTL 1 ",
 		},
+		// Timeout
+		{
+			B: new(using).Timeout(time.Second),
+			S: "USING TIMEOUT 1s ",
+		},
+		{
+			B: new(using).Timeout(90*time.Second + 500*time.Millisecond),
+			S: "USING TIMEOUT 1m30s500ms ",
+		},
+		// TimeoutNamed
+		{
+			B: new(using).TimeoutNamed("timeout"),
+			S: "USING TIMEOUT ? ",
+			N: []string{"timeout"},
+		},
+		// TTL Timestamp Timeout
+		{
+			B: new(using).TTL(time.Second).TimestampNamed("ts").TimeoutNamed("timeout"),
+			S: "USING TTL 1 AND TIMESTAMP ? AND TIMEOUT ? ",
+			N: []string{"ts", "timeout"},
+		},
+		// Timestamp Timeout
+		{
+			B: new(using).TimestampNamed("ts").Timeout(time.Millisecond),
+			S: "USING TIMESTAMP ? AND TIMEOUT 1ms ",
+			N: []string{"ts"},
+		},
 		// TTLNamed
 		{
 			B: new(using).TTLNamed("ttl"),
@@ -69,6 +96,12 @@ func TestUsing(t *testing.T) {
 			S: "USING TTL ? AND TIMESTAMP ? ",
 			N: []string{"ttl", "ts"},
 		},
+		// TimestampNamed TTL
+		{
+			B: new(using).TimestampNamed("ts").TTL(time.Second),
+			S: "USING TTL 1 AND TIMESTAMP ? ",
+			N: []string{"ts"},
+		},
 		// TTLNamed Timestamp
 		{
 			B: new(using).TTLNamed("ttl").Timestamp(time.Date(2005, 05, 05, 0, 0, 0, 0, time.UTC)),
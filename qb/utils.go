@@ -25,9 +25,28 @@ type columns []string
 
 func (cols columns) writeCql(cql *bytes.Buffer) {
 	for i, c := range cols {
-		cql.WriteString(c)
+		writeIdent(cql, c)
 		if i < len(cols)-1 {
 			cql.WriteByte(',')
 		}
 	}
 }
+
+// option is a name=value property used in WITH clauses of DDL statements.
+type option struct {
+	name  string
+	value string
+}
+
+type options []option
+
+func (opts options) writeCql(cql *bytes.Buffer) {
+	for i, o := range opts {
+		cql.WriteString(o.name)
+		cql.WriteByte('=')
+		cql.WriteString(o.value)
+		if i < len(opts)-1 {
+			cql.WriteString(" AND ")
+		}
+	}
+}
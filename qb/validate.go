@@ -0,0 +1,130 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Validator flags statements that are dangerous to run in production.
+// It knows the primary key of the table, package table provides validators
+// for table models.
+type Validator struct {
+	// PartKey lists partition key columns of the table.
+	PartKey []string
+	// SortKey lists clustering columns of the table.
+	SortKey []string
+	// AllowFiltering allows statements with ALLOW FILTERING.
+	AllowFiltering bool
+	// MaxIn is the maximal number of values in IN relations on partition key
+	// columns, zero means no limit. Only relations with known number of values
+	// i.e. InTuple or InLit are checked.
+	MaxIn int
+}
+
+// ValidationError lists problems found by Validator.
+type ValidationError struct {
+	Stmt   string
+	Issues []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid statement %q: %s", e.Stmt, strings.Join(e.Issues, ", "))
+}
+
+// Validate checks the statement built by b, it returns ValidationError if
+// the statement:
+//
+//   - is a DELETE or UPDATE not restricting all primary key columns,
+//   - uses ALLOW FILTERING and AllowFiltering is not set,
+//   - has an IN relation on a partition key column with more than MaxIn
+//     values.
+//
+// Relations added with RawCmp are not inspected.
+func (v Validator) Validate(b Builder) error {
+	var (
+		w        where
+		issues   []string
+		fullKey  bool
+		stmtKind string
+	)
+	switch b := b.(type) {
+	case *SelectBuilder:
+		w = b.where
+		if b.allowFiltering && !v.AllowFiltering {
+			issues = append(issues, "ALLOW FILTERING is not allowed")
+		}
+	case *UpdateBuilder:
+		w, fullKey, stmtKind = b.where, true, "UPDATE"
+	case *DeleteBuilder:
+		w, fullKey, stmtKind = b.where, true, "DELETE"
+	default:
+		return nil
+	}
+
+	if fullKey {
+		var missing []string
+		for _, c := range append(append([]string(nil), v.PartKey...), v.SortKey...) {
+			if !w.restricts(c) {
+				missing = append(missing, c)
+			}
+		}
+		if len(missing) > 0 {
+			issues = append(issues, fmt.Sprintf("%s does not restrict primary key columns %s", stmtKind, strings.Join(missing, ",")))
+		}
+	}
+
+	if v.MaxIn > 0 {
+		for _, c := range v.PartKey {
+			if n := w.inValues(c); n > v.MaxIn {
+				issues = append(issues, fmt.Sprintf("IN on partition key column %s has %d values, max is %d", c, n, v.MaxIn))
+			}
+		}
+	}
+
+	if len(issues) == 0 {
+		return nil
+	}
+	stmt, _ := b.ToCql()
+	return &ValidationError{
+		Stmt:   stmt,
+		Issues: issues,
+	}
+}
+
+// restricts returns true if column is restricted by an equality or IN
+// relation.
+func (w where) restricts(column string) bool {
+	for _, c := range w {
+		if c.op != eq && c.op != in {
+			continue
+		}
+		for _, name := range strings.Split(strings.Trim(c.column, "()"), ",") {
+			// Columns of multi-column relations are quoted.
+			if name == column || name == quoteIdent(column) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inValues returns the number of values in IN relation on column, or zero if
+// it's unknown.
+func (w where) inValues(column string) int {
+	for _, c := range w {
+		if c.op != in || c.column != column {
+			continue
+		}
+		switch v := c.value.(type) {
+		case tupleParam:
+			return v.count
+		case lit:
+			return strings.Count(string(v), ",") + 1
+		}
+	}
+	return 0
+}
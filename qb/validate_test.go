@@ -0,0 +1,97 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidator(t *testing.T) {
+	v := Validator{
+		PartKey: []string{"id"},
+		SortKey: []string{"race"},
+		MaxIn:   2,
+	}
+
+	table := []struct {
+		Name   string
+		B      Builder
+		Issues []string
+	}{
+		{
+			Name: "select",
+			B:    Select("cycling.cyclist_name").Where(Eq("id")),
+		},
+		{
+			Name:   "select allow filtering",
+			B:      Select("cycling.cyclist_name").Where(Gt("stars")).AllowFiltering(),
+			Issues: []string{"ALLOW FILTERING is not allowed"},
+		},
+		{
+			Name:   "select IN",
+			B:      Select("cycling.cyclist_name").Where(InTuple("id", 3)),
+			Issues: []string{"IN on partition key column id has 3 values, max is 2"},
+		},
+		{
+			Name:   "select IN literal",
+			B:      Select("cycling.cyclist_name").Where(InLit("id", "(1,2,3)")),
+			Issues: []string{"IN on partition key column id has 3 values, max is 2"},
+		},
+		{
+			Name: "select IN param",
+			B:    Select("cycling.cyclist_name").Where(In("id")),
+		},
+		{
+			Name: "update",
+			B:    Update("cycling.cyclist_name").Set("stars").Where(Eq("id"), Eq("race")),
+		},
+		{
+			Name: "update tuple",
+			B:    Update("cycling.cyclist_name").Set("stars").Where(TupleEq([]string{"id", "race"})),
+		},
+		{
+			Name:   "update partial key",
+			B:      Update("cycling.cyclist_name").Set("stars").Where(Eq("id")),
+			Issues: []string{"UPDATE does not restrict primary key columns race"},
+		},
+		{
+			Name:   "delete no key",
+			B:      Delete("cycling.cyclist_name").Where(Gt("race")),
+			Issues: []string{"DELETE does not restrict primary key columns id,race"},
+		},
+		{
+			Name: "insert",
+			B:    Insert("cycling.cyclist_name").Columns("id"),
+		},
+	}
+
+	for i := range table {
+		test := table[i]
+		t.Run(test.Name, func(t *testing.T) {
+			err := v.Validate(test.B)
+			var issues []string
+			if err != nil {
+				var verr *ValidationError
+				if !errors.As(err, &verr) {
+					t.Fatalf("Validate() error=%#v, expected ValidationError", err)
+				}
+				issues = verr.Issues
+			}
+			if diff := cmp.Diff(test.Issues, issues); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestValidatorAllowFiltering(t *testing.T) {
+	v := Validator{AllowFiltering: true}
+	if err := v.Validate(Select("cycling.cyclist_name").AllowFiltering()); err != nil {
+		t.Fatal("Validate() error", err)
+	}
+}
@@ -22,7 +22,17 @@ type param string
 
 func (p param) writeCql(cql *bytes.Buffer) (names []string) {
 	cql.WriteByte('?')
-	return []string{string(p)}
+	return []string{unquote(string(p))}
+}
+
+// params is a list of named CQL '?' parameters enclosed in parentheses.
+type params []string
+
+func (p params) writeCql(cql *bytes.Buffer) (names []string) {
+	cql.WriteByte('(')
+	placeholders(cql, len(p))
+	cql.WriteByte(')')
+	return append(names, p...)
 }
 
 // param is a named CQL tuple '?' parameter.
@@ -32,7 +42,7 @@ type tupleParam struct {
 }
 
 func (t tupleParam) writeCql(cql *bytes.Buffer) (names []string) {
-	baseName := string(t.param) + "_"
+	baseName := unquote(string(t.param)) + "_"
 	cql.WriteByte('(')
 	for i := 0; i < t.count-1; i++ {
 		cql.WriteByte('?')
@@ -53,3 +63,39 @@ func (l lit) writeCql(cql *bytes.Buffer) (names []string) {
 	cql.WriteString(string(l))
 	return nil
 }
+
+// raw is a raw CQL fragment, named parameters in form of :name are replaced
+// with '?' placeholders. Names in string literals are left as is.
+type raw string
+
+func (r raw) writeCql(cql *bytes.Buffer) (names []string) {
+	s := string(r)
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\'' {
+			quoted = !quoted
+		}
+		if c != ':' || quoted || i+1 == len(s) || !isIdentStart(s[i+1]) {
+			cql.WriteByte(c)
+			continue
+		}
+
+		j := i + 1
+		for j < len(s) && isIdent(s[j]) {
+			j++
+		}
+		cql.WriteByte('?')
+		names = append(names, s[i+1:j])
+		i = j - 1
+	}
+	return
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isIdent(c byte) bool {
+	return isIdentStart(c) || '0' <= c && c <= '9'
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package qb
+
+// Vector search reference:
+// https://cassandra.apache.org/doc/latest/cassandra/developing/cql/vector-search.html
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// VectorType returns a CQL vector type of dimension elements of elemType
+// i.e. vector<float, 3>, it can be used as a column type in CreateTable.
+func VectorType(elemType string, dimension int) string {
+	return "vector<" + elemType + ", " + strconv.Itoa(dimension) + ">"
+}
+
+// annOrder is an approximate nearest neighbour ordering column ANN OF value.
+type annOrder struct {
+	column string
+	value  value
+}
+
+func (a annOrder) writeCql(cql *bytes.Buffer) (names []string) {
+	writeIdent(cql, a.column)
+	cql.WriteString(" ANN OF ")
+	return a.value.writeCql(cql)
+}
+
+// OrderByANN sets ORDER BY column ANN OF ? clause on the query, it orders
+// rows by similarity of vector column to the bound vector. The parameter name
+// is the column name. Vector search queries require Limit.
+func (b *SelectBuilder) OrderByANN(column string) *SelectBuilder {
+	return b.OrderByANNNamed(column, column)
+}
+
+// OrderByANNNamed sets ORDER BY column ANN OF ? clause on the query with a
+// custom parameter name.
+func (b *SelectBuilder) OrderByANNNamed(column, name string) *SelectBuilder {
+	b.ann = &annOrder{column: column, value: param(name)}
+	return b
+}
+
+// OrderByANNLit sets ORDER BY column ANN OF literal clause on the query i.e.
+// OrderByANNLit("embedding", "[0.1, 0.2, 0.3]").
+func (b *SelectBuilder) OrderByANNLit(column, literal string) *SelectBuilder {
+	b.ann = &annOrder{column: column, value: lit(literal)}
+	return b
+}
+
+// SimilarityCosine produces 'similarity_cosine(column,?) AS
+// similarity_cosine_column', the parameter name is name.
+func (b *SelectBuilder) SimilarityCosine(column, name string) *SelectBuilder {
+	return b.similarity("similarity_cosine", column, name)
+}
+
+// SimilarityEuclidean produces 'similarity_euclidean(column,?) AS
+// similarity_euclidean_column', the parameter name is name.
+func (b *SelectBuilder) SimilarityEuclidean(column, name string) *SelectBuilder {
+	return b.similarity("similarity_euclidean", column, name)
+}
+
+// SimilarityDotProduct produces 'similarity_dot_product(column,?) AS
+// similarity_dot_product_column', the parameter name is name.
+func (b *SelectBuilder) SimilarityDotProduct(column, name string) *SelectBuilder {
+	return b.similarity("similarity_dot_product", column, name)
+}
+
+func (b *SelectBuilder) similarity(fn, column, name string) *SelectBuilder {
+	b.rawColumns = append(b.rawColumns, raw(As(fn+"("+quoteIdent(column)+",:"+name+")", fn+"_"+unquote(column))))
+	return b
+}
@@ -0,0 +1,9 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+// Package queryhttp exposes registered read-only queries as HTTP endpoints.
+// Query parameters are taken from the URL query and results are streamed
+// page by page as JSON lines, it's meant for quickly exposing internal
+// endpoints backed by query builders.
+package queryhttp
@@ -0,0 +1,141 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package queryhttp
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+)
+
+// PageStateHeader is the HTTP header carrying the paging state. It's set on
+// responses when there are more pages, and shall be sent back with the next
+// request to fetch the next page.
+const PageStateHeader = "X-Page-State"
+
+// ContentType is the content type of responses.
+const ContentType = "application/x-ndjson"
+
+type query struct {
+	stmt  string
+	names []string
+	mask  func(column string, v interface{}) interface{}
+}
+
+// Handler serves registered queries at /name, every request returns a single
+// page of rows encoded as JSON lines. Values of query parameters are bound
+// as strings, the driver converts them for text, numeric, uuid, inet, date
+// and duration columns.
+type Handler struct {
+	session  gocqlx.Session
+	pageSize int
+	queries  map[string]query
+}
+
+// NewHandler returns a new Handler executing queries in session. If pageSize
+// is not positive the session page size is used.
+func NewHandler(session gocqlx.Session, pageSize int) *Handler {
+	return &Handler{
+		session:  session,
+		pageSize: pageSize,
+		queries:  make(map[string]query),
+	}
+}
+
+// Register registers a SELECT query built by b under name. It panics if
+// name is already registered. Register must not be called concurrently with
+// ServeHTTP.
+func (h *Handler) Register(name string, b *qb.SelectBuilder) {
+	h.RegisterMasked(name, b, nil)
+}
+
+// RegisterMasked works like Register, column values of returned rows are
+// replaced by mask, see gocqlx.Iterx.Mask. Use table.Metadata.PIIMask to
+// mask PII columns of a table i.e.
+//
+//	h.RegisterMasked("users", b, usersMetadata.PIIMask(table.HMAC(key)))
+func (h *Handler) RegisterMasked(name string, b *qb.SelectBuilder, mask func(column string, v interface{}) interface{}) {
+	if _, ok := h.queries[name]; ok {
+		panic("queryhttp: multiple registrations for " + name)
+	}
+	stmt, names := b.ToCql()
+	h.queries[name] = query{stmt: stmt, names: names, mask: mask}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q, ok := h.queries[strings.TrimPrefix(r.URL.Path, "/")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	params, err := bindParams(r, q.names)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	state, err := base64.RawURLEncoding.DecodeString(r.Header.Get(PageStateHeader))
+	if err != nil {
+		http.Error(w, "invalid page state", http.StatusBadRequest)
+		return
+	}
+
+	// Setting page state disables automatic paging so that the iterator
+	// ends with the page.
+	qx := h.session.ContextQuery(r.Context(), q.stmt, q.names).BindMap(params).PageState(state)
+	if h.pageSize > 0 {
+		qx.PageSize(h.pageSize)
+	}
+	iter := qx.Iter().WithContext(r.Context()).Mask(q.mask)
+
+	// Query errors are reported when the first page is fetched, successful
+	// SELECT always returns columns.
+	if len(iter.Columns()) == 0 {
+		err := iter.Close()
+		if err == nil {
+			err = fmt.Errorf("query %s returned no columns", q.stmt)
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	if next := iter.PageState(); len(next) > 0 {
+		w.Header().Set(PageStateHeader, base64.RawURLEncoding.EncodeToString(next))
+	}
+	w.WriteHeader(http.StatusOK)
+
+	// Status is already sent, errors can only cut the response short.
+	rr := iter.Reader(gocqlx.JSONLines)
+	io.Copy(w, rr) // nolint: errcheck
+	rr.Close()
+}
+
+// bindParams returns values of URL query parameters for names, all names
+// must be present.
+func bindParams(r *http.Request, names []string) (map[string]interface{}, error) {
+	values := r.URL.Query()
+	params := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		v, ok := values[name]
+		if !ok || len(v) == 0 {
+			return nil, fmt.Errorf("missing parameter %s", name)
+		}
+		params[name] = v[0]
+	}
+	return params, nil
+}
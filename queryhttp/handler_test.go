@@ -0,0 +1,91 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package queryhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+)
+
+func TestHandlerBadRequest(t *testing.T) {
+	h := NewHandler(gocqlx.Session{}, 10)
+	h.Register("users", qb.Select("users").Where(qb.Eq("id")))
+
+	table := []struct {
+		Name   string
+		Method string
+		URL    string
+		Header string
+		Code   int
+	}{
+		{
+			Name:   "method",
+			Method: http.MethodPost,
+			URL:    "/users?id=1",
+			Code:   http.StatusMethodNotAllowed,
+		},
+		{
+			Name:   "unknown query",
+			Method: http.MethodGet,
+			URL:    "/posts?id=1",
+			Code:   http.StatusNotFound,
+		},
+		{
+			Name:   "missing parameter",
+			Method: http.MethodGet,
+			URL:    "/users?name=1",
+			Code:   http.StatusBadRequest,
+		},
+		{
+			Name:   "page state",
+			Method: http.MethodGet,
+			URL:    "/users?id=1",
+			Header: "not base64!",
+			Code:   http.StatusBadRequest,
+		},
+	}
+
+	for i := range table {
+		test := table[i]
+		t.Run(test.Name, func(t *testing.T) {
+			r := httptest.NewRequest(test.Method, test.URL, nil)
+			if test.Header != "" {
+				r.Header.Set(PageStateHeader, test.Header)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != test.Code {
+				t.Fatalf("ServeHTTP() code=%d, expected %d", w.Code, test.Code)
+			}
+		})
+	}
+}
+
+func TestBindParams(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/users?id=1&name=a&name=b&other=c", nil)
+	params, err := bindParams(r, []string{"id", "name"})
+	if err != nil {
+		t.Fatal("bindParams() error:", err)
+	}
+	if diff := cmp.Diff(map[string]interface{}{"id": "1", "name": "a"}, params); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestHandlerRegisterDuplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Register() expected panic")
+		}
+	}()
+	h := NewHandler(gocqlx.Session{}, 0)
+	h.Register("users", qb.Select("users"))
+	h.Register("users", qb.Select("users"))
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+// +build all integration
+
+package queryhttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	. "github.com/scylladb/gocqlx/v2/gocqlxtest"
+	"github.com/scylladb/gocqlx/v2/qb"
+	"github.com/scylladb/gocqlx/v2/queryhttp"
+	"github.com/scylladb/gocqlx/v2/table"
+)
+
+func TestHandler(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.queryhttp_table (pk int, ck int, name text, PRIMARY KEY (pk, ck))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	for i, name := range []string{"a", "b", "c"} {
+		if err := session.Query(`INSERT INTO gocqlx_test.queryhttp_table (pk, ck, name) VALUES (1, ?, ?)`, nil).Bind(i, name).ExecRelease(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	h := queryhttp.NewHandler(session, 2)
+	h.Register("rows", qb.Select("gocqlx_test.queryhttp_table").Columns("ck", "name").Where(qb.Eq("pk")))
+
+	get := func(state string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/rows?pk=1", nil)
+		if state != "" {
+			r.Header.Set(queryhttp.PageStateHeader, state)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != http.StatusOK {
+			t.Fatalf("ServeHTTP() code=%d, expected %d: %s", w.Code, http.StatusOK, w.Body)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != queryhttp.ContentType {
+			t.Fatalf("ServeHTTP() Content-Type=%q, expected %q", ct, queryhttp.ContentType)
+		}
+		return w
+	}
+
+	w := get("")
+	if diff := cmp.Diff("{\"ck\":0,\"name\":\"a\"}\n{\"ck\":1,\"name\":\"b\"}\n", w.Body.String()); diff != "" {
+		t.Fatal(diff)
+	}
+	state := w.Header().Get(queryhttp.PageStateHeader)
+	if state == "" {
+		t.Fatal("ServeHTTP() expected page state")
+	}
+
+	w = get(state)
+	if diff := cmp.Diff("{\"ck\":2,\"name\":\"c\"}\n", w.Body.String()); diff != "" {
+		t.Fatal(diff)
+	}
+	if state := w.Header().Get(queryhttp.PageStateHeader); state != "" {
+		t.Fatalf("ServeHTTP() unexpected page state %q on last page", state)
+	}
+
+	m := table.Metadata{
+		Name:    "gocqlx_test.queryhttp_table",
+		Columns: []string{"pk", "ck", "name"},
+		PartKey: []string{"pk"},
+		SortKey: []string{"ck"},
+		PII:     []string{"name"},
+	}
+	h.RegisterMasked("masked", qb.Select(m.Name).Columns("ck", "name").Where(qb.Eq("pk"), qb.Eq("ck")), m.PIIMask(nil))
+
+	r := httptest.NewRequest(http.MethodGet, "/masked?pk=1&ck=0", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if diff := cmp.Diff("{\"ck\":0,\"name\":\"[REDACTED]\"}\n", w.Body.String()); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/gocql/gocql"
 	"github.com/scylladb/go-reflectx"
@@ -94,6 +95,14 @@ type Queryx struct {
 	Names  []string
 	Mapper *reflectx.Mapper
 	err    error
+
+	pageTimeout  time.Duration
+	pageState    []byte
+	pageStateSet bool
+	maxRows      int
+	maxPages     int
+	location     *time.Location
+	sortBy       []sortKey
 }
 
 // Query creates a new Queryx from gocql.Query using a default mapper.
@@ -147,8 +156,7 @@ func bindStructArgs(names []string, arg0 interface{}, arg1 map[string]interface{
 
 	err := m.TraversalsByNameFunc(v.Type(), names, func(i int, t []int) error {
 		if len(t) != 0 {
-			val := reflectx.FieldByIndexesReadOnly(v, t) // nolint:scopelint
-			arglist = append(arglist, val.Interface())
+			arglist = append(arglist, fieldArg(v, t, m)) // nolint:scopelint
 		} else {
 			val, ok := arg1[names[i]]
 			if !ok {
@@ -163,6 +171,65 @@ func bindStructArgs(names []string, arg0 interface{}, arg1 map[string]interface{
 	return arglist, err
 }
 
+// fieldArg returns value of the field of v at traversal t, values of fields
+// with a compressor are compressed.
+func fieldArg(v reflect.Value, t []int, m *reflectx.Mapper) interface{} {
+	val := reflectx.FieldByIndexesReadOnly(v, t)
+	fi := m.TypeMap(v.Type()).GetByTraversal(t)
+	if c := fieldCompressor(fi); c != nil {
+		return compressedValue{column: fi.Name, value: udtWrapValue(val, m, DefaultUnsafe), compressor: c}
+	}
+	return val.Interface()
+}
+
+// BindStructs binds query named parameters to values from args using mapper.
+// Each parameter is bound to a value of the first struct in args that has
+// a field with a matching name. If value cannot be found in any of the structs
+// error is reported.
+func (q *Queryx) BindStructs(args ...interface{}) *Queryx {
+	arglist, err := bindStructsArgs(q.Names, args, q.Mapper)
+	if err != nil {
+		q.err = fmt.Errorf("bind error: %s", err)
+	} else {
+		q.err = nil
+		q.Bind(arglist...)
+	}
+
+	return q
+}
+
+func bindStructsArgs(names []string, args []interface{}, m *reflectx.Mapper) ([]interface{}, error) {
+	arglist := make([]interface{}, len(names))
+	found := make([]bool, len(names))
+
+	for _, arg := range args {
+		// grab the indirected value of arg
+		v := reflect.ValueOf(arg)
+		for v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+
+		err := m.TraversalsByNameFunc(v.Type(), names, func(i int, t []int) error {
+			if len(t) != 0 && !found[i] {
+				arglist[i] = fieldArg(v, t, m) // nolint:scopelint
+				found[i] = true
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i, ok := range found {
+		if !ok {
+			return nil, fmt.Errorf("could not find name %q in %#v", names[i], args)
+		}
+	}
+
+	return arglist, nil
+}
+
 // BindMap binds query named parameters using map.
 func (q *Queryx) BindMap(arg map[string]interface{}) *Queryx {
 	arglist, err := bindMapArgs(q.Names, arg)
@@ -192,10 +259,37 @@ func bindMapArgs(names []string, arg map[string]interface{}) ([]interface{}, err
 // Bind sets query arguments of query. This can also be used to rebind new query arguments
 // to an existing query instance.
 func (q *Queryx) Bind(v ...interface{}) *Queryx {
-	q.Query.Bind(udtWrapSlice(q.Mapper, DefaultUnsafe, v)...)
+	q.Query.Bind(locationWrapSlice(udtWrapSlice(q.Mapper, DefaultUnsafe, v), q.location)...)
+	return q
+}
+
+// BindPositional binds query parameters positionally like Bind, but validates
+// values against the query parameter names. If the number of values does not
+// match the number of names an error describing missing or unexpected values
+// is reported. It eases migration of code that binds values positionally to
+// named binding. If query has no parameter names no validation is done.
+func (q *Queryx) BindPositional(v ...interface{}) *Queryx {
+	if err := checkPositionalArgs(q.Names, v); err != nil {
+		q.err = fmt.Errorf("bind error: %s", err)
+	} else {
+		q.err = nil
+		q.Bind(v...)
+	}
+
 	return q
 }
 
+func checkPositionalArgs(names []string, v []interface{}) error {
+	switch {
+	case names == nil || len(names) == len(v):
+		return nil
+	case len(names) > len(v):
+		return fmt.Errorf("expected %d values got %d, missing values for %q", len(names), len(v), names[len(v):])
+	default:
+		return fmt.Errorf("expected %d values for %q got %d, unexpected values %v", len(names), names, len(v), v[len(names):])
+	}
+}
+
 // Err returns any binding errors.
 func (q *Queryx) Err() error {
 	return q.err
@@ -219,6 +313,9 @@ func (q *Queryx) ExecRelease() error {
 // ExecCAS executes the Lightweight Transaction query, returns whether query was applied.
 // See: https://docs.scylladb.com/using-scylla/lwt/ for more details.
 func (q *Queryx) ExecCAS() (applied bool, err error) {
+	if q.err != nil {
+		return false, q.err
+	}
 	iter := q.Iter().StructOnly()
 	if err := iter.Get(&struct{}{}); err != nil {
 		return false, err
@@ -262,9 +359,14 @@ func (q *Queryx) GetRelease(dest interface{}) error {
 
 // GetCAS executes a lightweight transaction.
 // If the transaction fails because the existing values did not match,
-// the previous values will be stored in dest object.
+// the previous values will be stored in dest object. If dest is a struct
+// pointer the values are scanned with Iter.StructScan, the [applied] column
+// does not need to be mapped.
 // See: https://docs.scylladb.com/using-scylla/lwt/ for more details.
 func (q *Queryx) GetCAS(dest interface{}) (applied bool, err error) {
+	if q.err != nil {
+		return false, q.err
+	}
 	iter := q.Iter()
 	if err := iter.Get(dest); err != nil {
 		return false, err
@@ -312,9 +414,25 @@ func (q *Queryx) SelectRelease(dest interface{}) error {
 // big to be loaded with Select in order to do row by row iteration.
 // See Iterx StructScan function.
 func (q *Queryx) Iter() *Iterx {
-	return &Iterx{
-		Iter:   q.Query.Iter(),
-		Mapper: q.Mapper,
-		unsafe: DefaultUnsafe,
+	iter := &Iterx{
+		Mapper:   q.Mapper,
+		unsafe:   DefaultUnsafe,
+		maxRows:  q.maxRows,
+		maxPages: q.maxPages,
+		sortBy:   q.sortBy,
+		location: q.location,
+	}
+	// Pages are fetched one by one with PageTimeout and MaxPages, the driver
+	// prefetches pages when paging automatically.
+	if q.pageTimeout > 0 || q.maxPages > 0 {
+		iter.pager = &pager{
+			query:   q.Query,
+			timeout: q.pageTimeout,
+			single:  q.pageStateSet,
+		}
+		iter.Iter = iter.pager.fetch(q.pageState)
+	} else {
+		iter.Iter = q.Query.Iter()
 	}
+	return iter
 }
@@ -88,6 +88,27 @@ func TestQueryxBindStruct(t *testing.T) {
 		}
 	})
 
+	t.Run("udt field", func(t *testing.T) {
+		type Address struct {
+			City string
+		}
+		v := &struct {
+			Name    string
+			Address Address
+		}{
+			Name:    "name",
+			Address: Address{City: "city"},
+		}
+		args, err := bindStructArgs([]string{"name", "address.city"}, v, nil, DefaultMapper)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(args, []interface{}{"name", "city"}); diff != "" {
+			t.Error("args mismatch", diff)
+		}
+	})
+
 	t.Run("error", func(t *testing.T) {
 		names := []string{"name", "age", "first", "not_found"}
 		_, err := bindStructArgs(names, v, nil, DefaultMapper)
@@ -123,6 +144,52 @@ func TestQueryxBindStruct(t *testing.T) {
 	})
 }
 
+func TestQueryxBindStructs(t *testing.T) {
+	type entity struct {
+		ID   int
+		Name string
+	}
+	type context struct {
+		ID     int
+		Tenant string
+	}
+
+	e := &entity{ID: 1, Name: "name"}
+	c := context{ID: 2, Tenant: "tenant"}
+
+	t.Run("simple", func(t *testing.T) {
+		names := []string{"id", "name", "tenant"}
+		args, err := bindStructsArgs(names, []interface{}{e, c}, DefaultMapper)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(args, []interface{}{1, "name", "tenant"}); diff != "" {
+			t.Error("args mismatch", diff)
+		}
+	})
+
+	t.Run("order", func(t *testing.T) {
+		names := []string{"id", "name", "tenant"}
+		args, err := bindStructsArgs(names, []interface{}{c, e}, DefaultMapper)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(args, []interface{}{2, "name", "tenant"}); diff != "" {
+			t.Error("args mismatch", diff)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		names := []string{"id", "name", "not_found"}
+		_, err := bindStructsArgs(names, []interface{}{e, c}, DefaultMapper)
+		if err == nil {
+			t.Fatal("unexpected error")
+		}
+	})
+}
+
 func TestQueryxBindMap(t *testing.T) {
 	v := map[string]interface{}{
 		"name":  "name",
@@ -152,6 +219,60 @@ func TestQueryxBindMap(t *testing.T) {
 	})
 }
 
+func TestQueryxBindPositional(t *testing.T) {
+	names := []string{"name", "age", "first", "last"}
+
+	t.Run("simple", func(t *testing.T) {
+		if err := checkPositionalArgs(names, []interface{}{"name", 30, "first", "last"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("no names", func(t *testing.T) {
+		if err := checkPositionalArgs(nil, []interface{}{"name", 30}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		err := checkPositionalArgs(names, []interface{}{"name", 30})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if diff := cmp.Diff(`expected 4 values got 2, missing values for ["first" "last"]`, err.Error()); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("unexpected", func(t *testing.T) {
+		err := checkPositionalArgs(names, []interface{}{"name", 30, "first", "last", 1})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if diff := cmp.Diff(`expected 4 values for ["name" "age" "first" "last"] got 5, unexpected values [1]`, err.Error()); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestQueryxCASBindError(t *testing.T) {
+	q := &Queryx{
+		Names:  []string{"not_found"},
+		Mapper: DefaultMapper,
+	}
+	q.BindMap(map[string]interface{}{})
+	if q.Err() == nil {
+		t.Fatal("expected bind error")
+	}
+
+	if _, err := q.ExecCAS(); err != q.Err() {
+		t.Fatalf("ExecCAS() error %v, expected %v", err, q.Err())
+	}
+	if _, err := q.GetCAS(&struct{}{}); err != q.Err() {
+		t.Fatalf("GetCAS() error %v, expected %v", err, q.Err())
+	}
+}
+
 func TestQyeryxAllWrapped(t *testing.T) {
 	var (
 		gocqlQueryPtr = reflect.TypeOf((*gocql.Query)(nil))
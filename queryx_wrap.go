@@ -132,6 +132,8 @@ func (q *Queryx) SerialConsistency(cons gocql.SerialConsistency) *Queryx {
 // must be used for all subsequent pages.
 func (q *Queryx) PageState(state []byte) *Queryx {
 	q.Query.PageState(state)
+	q.pageState = state
+	q.pageStateSet = true
 	return q
 }
 
@@ -0,0 +1,87 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gocql/gocql"
+	"github.com/scylladb/go-reflectx"
+)
+
+// RoundTrip binds struct v to columns, passes the bound values through
+// a fake backend and scans the returned row into a new value of the same
+// type. It exercises the same bind and scan code paths as Queryx and Iterx
+// without a cluster, it's meant for testing that models survive a write and
+// read cycle. If m is nil DefaultMapper is used.
+func RoundTrip(m *reflectx.Mapper, columns []gocql.ColumnInfo, v interface{}) (interface{}, error) {
+	if m == nil {
+		m = DefaultMapper
+	}
+	names := columnNames(columns)
+
+	args, err := bindStructArgs(names, v, nil, m)
+	if err != nil {
+		return nil, fmt.Errorf("bind error: %s", err)
+	}
+	row, err := fakeBackend(columns, udtWrapSlice(m, DefaultUnsafe, args))
+	if err != nil {
+		return nil, err
+	}
+
+	dest := reflect.New(reflectx.Deref(reflect.TypeOf(v)))
+	fields := m.TraversalsByName(dest.Type(), names)
+	if f, err := missingFields(fields); err != nil {
+		return nil, fmt.Errorf("missing destination name %q in %s", names[f], dest.Elem().Type())
+	}
+	iter := &Iterx{Mapper: m}
+	values := make([]interface{}, len(columns))
+	if err := iter.fieldsByTraversal(dest, fields, fieldCompressors(m, dest.Type(), fields), values); err != nil {
+		return nil, err
+	}
+	for i := range columns {
+		if err := gocql.Unmarshal(columns[i].TypeInfo, row[i], values[i]); err != nil {
+			return nil, fmt.Errorf("unmarshal %s: %s", columns[i].Name, err)
+		}
+	}
+
+	return dest.Elem().Interface(), nil
+}
+
+// fakeBackend marshals args the same way the driver does when sending a query
+// and mimics the storage semantics of the database, empty collections are
+// stored as null.
+func fakeBackend(columns []gocql.ColumnInfo, args []interface{}) ([][]byte, error) {
+	if len(args) != len(columns) {
+		return nil, fmt.Errorf("expected %d values got %d", len(columns), len(args))
+	}
+
+	row := make([][]byte, len(args))
+	for i, arg := range args {
+		b, err := gocql.Marshal(columns[i].TypeInfo, arg)
+		if err != nil {
+			return nil, fmt.Errorf("marshal %s: %s", columns[i].Name, err)
+		}
+		if isCollection(columns[i].TypeInfo) && isEmptyCollection(b) {
+			b = nil
+		}
+		row[i] = b
+	}
+	return row, nil
+}
+
+func isCollection(info gocql.TypeInfo) bool {
+	switch info.Type() {
+	case gocql.TypeList, gocql.TypeSet, gocql.TypeMap:
+		return true
+	default:
+		return false
+	}
+}
+
+func isEmptyCollection(b []byte) bool {
+	return len(b) == 4 && b[0] == 0 && b[1] == 0 && b[2] == 0 && b[3] == 0
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package gocqlx
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add(int32(0), "", []byte(nil), false, 0.0, int64(0), "")
+	f.Add(int32(math.MaxInt32), "name", []byte{}, true, math.Inf(1), int64(math.MaxInt64), ",")
+	f.Add(int32(math.MinInt32), "ąę", []byte{0}, false, math.NaN(), int64(math.MinInt64), "a,b")
+
+	f.Fuzz(func(t *testing.T, id int32, name string, data []byte, flag bool, score float64, ms int64, tags string) {
+		v := roundTripRow{
+			ID:    id,
+			Name:  name,
+			Data:  data,
+			Flag:  flag,
+			Score: score,
+			Time:  unixMilli(ms),
+		}
+		if tags != "" {
+			v.Tags = strings.Split(tags, ",")
+			v.Attrs = make(map[string]int64, len(v.Tags))
+			for i, tag := range v.Tags {
+				v.Attrs[tag] = ms - int64(i)
+			}
+		}
+		testRoundTrip(t, v)
+	})
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+type roundTripRow struct {
+	ID    int32
+	Name  string
+	Data  []byte
+	Flag  bool
+	Score float64
+	Time  time.Time
+	Tags  []string
+	Attrs map[string]int64
+}
+
+var roundTripColumns = []gocql.ColumnInfo{
+	{Name: "id", TypeInfo: nativeType(gocql.TypeInt)},
+	{Name: "name", TypeInfo: nativeType(gocql.TypeText)},
+	{Name: "data", TypeInfo: nativeType(gocql.TypeBlob)},
+	{Name: "flag", TypeInfo: nativeType(gocql.TypeBoolean)},
+	{Name: "score", TypeInfo: nativeType(gocql.TypeDouble)},
+	{Name: "time", TypeInfo: nativeType(gocql.TypeTimestamp)},
+	{Name: "tags", TypeInfo: gocql.CollectionType{
+		NativeType: gocql.NewNativeType(4, gocql.TypeList, ""),
+		Elem:       nativeType(gocql.TypeText),
+	}},
+	{Name: "attrs", TypeInfo: gocql.CollectionType{
+		NativeType: gocql.NewNativeType(4, gocql.TypeMap, ""),
+		Key:        nativeType(gocql.TypeText),
+		Elem:       nativeType(gocql.TypeBigInt),
+	}},
+}
+
+func nativeType(t gocql.Type) gocql.TypeInfo {
+	return gocql.NewNativeType(4, t, "")
+}
+
+var roundTripOpts = []cmp.Option{
+	cmpopts.EquateEmpty(),
+	cmpopts.EquateNaNs(),
+}
+
+func testRoundTrip(t *testing.T, v roundTripRow) {
+	t.Helper()
+
+	got, err := RoundTrip(nil, roundTripColumns, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(v, got, roundTripOpts...); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	table := []struct {
+		Name string
+		V    roundTripRow
+	}{
+		{
+			Name: "zero",
+		},
+		{
+			Name: "empty collections",
+			V: roundTripRow{
+				Data:  []byte{},
+				Tags:  []string{},
+				Attrs: map[string]int64{},
+			},
+		},
+		{
+			Name: "values",
+			V: roundTripRow{
+				ID:    math.MinInt32,
+				Name:  "Michał",
+				Data:  []byte{0, 1, 2},
+				Flag:  true,
+				Score: math.NaN(),
+				Time:  time.Date(2020, 4, 29, 12, 0, 0, int(time.Millisecond), time.UTC),
+				Tags:  []string{"a", "", "b"},
+				Attrs: map[string]int64{"": math.MaxInt64, "a": math.MinInt64},
+			},
+		},
+		{
+			Name: "min timestamp",
+			V: roundTripRow{
+				Time: unixMilli(math.MinInt64),
+			},
+		},
+		{
+			Name: "max timestamp",
+			V: roundTripRow{
+				Time: unixMilli(math.MaxInt64),
+			},
+		},
+		{
+			Name: "before epoch timestamp",
+			V: roundTripRow{
+				Time: unixMilli(-1),
+			},
+		},
+	}
+
+	for i := range table {
+		test := table[i]
+		t.Run(test.Name, func(t *testing.T) {
+			testRoundTrip(t, test.V)
+		})
+	}
+}
+
+// unixMilli returns the UTC time corresponding to the given number of
+// milliseconds since epoch, that is the CQL timestamp precision.
+func unixMilli(ms int64) time.Time {
+	return time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond)).UTC()
+}
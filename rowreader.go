@@ -0,0 +1,309 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// RowFormat specifies encoding of rows returned by Iterx.Reader.
+type RowFormat int
+
+const (
+	// JSONLines encodes every row as a JSON object with column names as keys
+	// followed by a new line, null columns are encoded as null. NaN and
+	// infinite floats, which have no JSON representation, are encoded as null
+	// and tuples as arrays.
+	JSONLines RowFormat = iota
+	// CSV encodes rows as RFC 4180 CSV with a header line of column names.
+	// Null columns are encoded as empty fields, blobs as 0x prefixed hex,
+	// timestamps as RFC 3339 and collections, tuples and UDTs as JSON.
+	CSV
+)
+
+// Reader returns a reader of rows encoded in format f, column values are
+// masked if Mask is set. Rows are read and encoded as the reader is consumed
+// so that results can be piped to a HTTP response or an object storage
+// upload without buffering them. The iterator is closed when all the rows
+// are read or when the reader is closed, an error returned by Iterx.Close is
+// returned by Read.
+//
+// The iterator must not be used by the caller after calling Reader.
+func (iter *Iterx) Reader(f RowFormat) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		err := iter.encodeRows(pw, f)
+		if cerr := iter.Close(); err == nil {
+			err = cerr
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+func (iter *Iterx) encodeRows(w io.Writer, f RowFormat) error {
+	columns := iter.Columns()
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+	values := newRowValues(columns)
+
+	var enc rowEncoder
+	switch f {
+	case JSONLines:
+		enc = newJSONRowEncoder(w, names)
+	case CSV:
+		enc = newCSVRowEncoder(w, names)
+	default:
+		return fmt.Errorf("unknown row format %d", f)
+	}
+
+	if err := enc.WriteHeader(); err != nil {
+		return err
+	}
+	for iter.Scan(values.dest...) {
+		row := values.row()
+		if iter.mask != nil {
+			for i := range row {
+				row[i] = iter.mask(names[i], row[i])
+			}
+		}
+		if err := enc.WriteRow(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rowValues holds scan destinations of a row, tuple columns are scanned
+// into a destination per element.
+type rowValues struct {
+	columns []gocql.ColumnInfo
+	dest    []interface{}
+}
+
+func newRowValues(columns []gocql.ColumnInfo) *rowValues {
+	r := &rowValues{
+		columns: columns,
+	}
+	for _, c := range columns {
+		if t, ok := c.TypeInfo.(gocql.TupleTypeInfo); ok {
+			for _, e := range t.Elems {
+				r.dest = append(r.dest, newNullable(e))
+			}
+			continue
+		}
+		r.dest = append(r.dest, newNullable(c.TypeInfo))
+	}
+	return r
+}
+
+// row returns the scanned values, nulls are nil and tuples are
+// []interface{} of the element values.
+func (r *rowValues) row() []interface{} {
+	row := make([]interface{}, len(r.columns))
+	d := 0
+	for i, c := range r.columns {
+		if t, ok := c.TypeInfo.(gocql.TupleTypeInfo); ok {
+			elems := make([]interface{}, len(t.Elems))
+			for j := range elems {
+				elems[j] = nullableValue(r.dest[d])
+				d++
+			}
+			row[i] = elems
+			continue
+		}
+		row[i] = nullableValue(r.dest[d])
+		d++
+	}
+	return row
+}
+
+// newNullable returns **T for info so that nulls can be told apart from
+// zero values.
+func newNullable(info gocql.TypeInfo) interface{} {
+	return reflect.New(reflect.TypeOf(info.New())).Interface()
+}
+
+// nullableValue returns value pointed by **T or nil if it's null.
+func nullableValue(v interface{}) interface{} {
+	if p := reflect.ValueOf(v).Elem(); !p.IsNil() {
+		return p.Elem().Interface()
+	}
+	return nil
+}
+
+// jsonValue returns v with NaN and infinite floats, which cannot be encoded
+// as JSON, replaced with nil. Slices and maps holding floats are copied.
+func jsonValue(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if f := rv.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil
+		}
+	case reflect.Slice, reflect.Array:
+		if !holdsFloats(rv.Type().Elem()) {
+			break
+		}
+		r := make([]interface{}, rv.Len())
+		for i := range r {
+			r[i] = jsonValue(rv.Index(i).Interface())
+		}
+		return r
+	case reflect.Map:
+		if !holdsFloats(rv.Type().Elem()) {
+			break
+		}
+		r := reflect.MakeMapWithSize(reflect.MapOf(rv.Type().Key(), emptyInterfaceType), rv.Len())
+		for it := rv.MapRange(); it.Next(); {
+			e := reflect.New(emptyInterfaceType).Elem()
+			if ev := jsonValue(it.Value().Interface()); ev != nil {
+				e.Set(reflect.ValueOf(ev))
+			}
+			r.SetMapIndex(it.Key(), e)
+		}
+		return r.Interface()
+	}
+	return v
+}
+
+var emptyInterfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// holdsFloats returns true if values of type t may be or contain floats.
+func holdsFloats(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64, reflect.Interface:
+		return true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return holdsFloats(t.Elem())
+	default:
+		return false
+	}
+}
+
+// rowEncoder writes rows of values, nil values are nulls.
+type rowEncoder interface {
+	WriteHeader() error
+	WriteRow(values []interface{}) error
+}
+
+type jsonRowEncoder struct {
+	w     io.Writer
+	names [][]byte
+	buf   bytes.Buffer
+}
+
+func newJSONRowEncoder(w io.Writer, names []string) *jsonRowEncoder {
+	e := &jsonRowEncoder{
+		w:     w,
+		names: make([][]byte, len(names)),
+	}
+	for i, n := range names {
+		e.names[i], _ = json.Marshal(n)
+	}
+	return e
+}
+
+func (e *jsonRowEncoder) WriteHeader() error {
+	return nil
+}
+
+func (e *jsonRowEncoder) WriteRow(values []interface{}) error {
+	e.buf.Reset()
+	e.buf.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		e.buf.Write(e.names[i])
+		e.buf.WriteByte(':')
+		b, err := json.Marshal(jsonValue(v))
+		if err != nil {
+			return fmt.Errorf("encode column %s: %s", e.names[i], err)
+		}
+		e.buf.Write(b)
+	}
+	e.buf.WriteString("}\n")
+
+	_, err := e.w.Write(e.buf.Bytes())
+	return err
+}
+
+type csvRowEncoder struct {
+	w      *csv.Writer
+	names  []string
+	record []string
+}
+
+func newCSVRowEncoder(w io.Writer, names []string) *csvRowEncoder {
+	return &csvRowEncoder{
+		w:      csv.NewWriter(w),
+		names:  names,
+		record: make([]string, len(names)),
+	}
+}
+
+func (e *csvRowEncoder) WriteHeader() error {
+	return e.write(e.names)
+}
+
+func (e *csvRowEncoder) WriteRow(values []interface{}) error {
+	for i, v := range values {
+		s, err := csvField(v)
+		if err != nil {
+			return fmt.Errorf("encode column %s: %s", e.names[i], err)
+		}
+		e.record[i] = s
+	}
+	return e.write(e.record)
+}
+
+// write writes and flushes the record so that rows are not held back by
+// the csv.Writer buffer.
+func (e *csvRowEncoder) write(record []string) error {
+	if err := e.w.Write(record); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func csvField(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return "0x" + hex.EncodeToString(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case int, int8, int16, int32, int64, float32, float64:
+		return fmt.Sprint(v), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+
+	b, err := json.Marshal(jsonValue(v))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRowEncoder(t *testing.T) {
+	names := []string{"id", "name", "data", "ts", "tags", "ok"}
+	id := gocql.UUID{0x6a, 0xb0, 0x9b, 0xec, 0xe6, 0x8e, 0x48, 0xd9, 0xa5, 0xf6, 0x02, 0x47, 0x4b, 0xa1, 0x12, 0xfe}
+	ts := time.Date(2005, 5, 5, 10, 30, 0, 500, time.UTC)
+	rows := [][]interface{}{
+		{id, `say "hi", bye`, []byte{0xca, 0xfe}, ts, []string{"a", "b"}, true},
+		{id, nil, nil, nil, nil, false},
+	}
+
+	table := []struct {
+		Name string
+		New  func(buf *bytes.Buffer) rowEncoder
+		S    string
+	}{
+		{
+			Name: "json lines",
+			New:  func(buf *bytes.Buffer) rowEncoder { return newJSONRowEncoder(buf, names) },
+			S: `{"id":"6ab09bec-e68e-48d9-a5f6-02474ba112fe","name":"say \"hi\", bye","data":"yv4=","ts":"2005-05-05T10:30:00.0000005Z","tags":["a","b"],"ok":true}` + "\n" +
+				`{"id":"6ab09bec-e68e-48d9-a5f6-02474ba112fe","name":null,"data":null,"ts":null,"tags":null,"ok":false}` + "\n",
+		},
+		{
+			Name: "csv",
+			New:  func(buf *bytes.Buffer) rowEncoder { return newCSVRowEncoder(buf, names) },
+			S: "id,name,data,ts,tags,ok\n" +
+				`6ab09bec-e68e-48d9-a5f6-02474ba112fe,"say ""hi"", bye",0xcafe,2005-05-05T10:30:00.0000005Z,"[""a"",""b""]",true` + "\n" +
+				"6ab09bec-e68e-48d9-a5f6-02474ba112fe,,,,,false\n",
+		},
+	}
+
+	for i := range table {
+		test := table[i]
+		t.Run(test.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := test.New(&buf)
+			if err := enc.WriteHeader(); err != nil {
+				t.Fatal("WriteHeader() error:", err)
+			}
+			for _, row := range rows {
+				if err := enc.WriteRow(row); err != nil {
+					t.Fatal("WriteRow() error:", err)
+				}
+			}
+			if diff := cmp.Diff(test.S, buf.String()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestRowEncoderNonFiniteFloats(t *testing.T) {
+	names := []string{"a", "b", "c", "d"}
+	row := []interface{}{math.NaN(), float32(math.Inf(1)), []float64{1.5, math.Inf(-1)}, map[string]float64{"x": math.NaN()}}
+
+	table := []struct {
+		Name string
+		New  func(buf *bytes.Buffer) rowEncoder
+		S    string
+	}{
+		{
+			Name: "json lines",
+			New:  func(buf *bytes.Buffer) rowEncoder { return newJSONRowEncoder(buf, names) },
+			S:    `{"a":null,"b":null,"c":[1.5,null],"d":{"x":null}}` + "\n",
+		},
+		{
+			Name: "csv",
+			New:  func(buf *bytes.Buffer) rowEncoder { return newCSVRowEncoder(buf, names) },
+			S:    "a,b,c,d\n" + `NaN,+Inf,"[1.5,null]","{""x"":null}"` + "\n",
+		},
+	}
+
+	for i := range table {
+		test := table[i]
+		t.Run(test.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := test.New(&buf)
+			if err := enc.WriteHeader(); err != nil {
+				t.Fatal("WriteHeader() error:", err)
+			}
+			if err := enc.WriteRow(row); err != nil {
+				t.Fatal("WriteRow() error:", err)
+			}
+			if diff := cmp.Diff(test.S, buf.String()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestRowValuesTuple(t *testing.T) {
+	tuple := gocql.TupleTypeInfo{
+		NativeType: gocql.NewNativeType(4, gocql.TypeTuple, ""),
+		Elems:      []gocql.TypeInfo{nativeType(gocql.TypeInt), nativeType(gocql.TypeText)},
+	}
+	columns := []gocql.ColumnInfo{
+		{Name: "id", TypeInfo: nativeType(gocql.TypeInt)},
+		{Name: "pair", TypeInfo: tuple},
+		{Name: "name", TypeInfo: nativeType(gocql.TypeText)},
+	}
+
+	r := newRowValues(columns)
+	if len(r.dest) != 4 {
+		t.Fatalf("expected 4 scan destinations got %d", len(r.dest))
+	}
+
+	// Emulate Iter.Scan, tuples are unmarshalled into a destination per
+	// element.
+	id, err := gocql.Marshal(columns[0].TypeInfo, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pair, err := gocql.Marshal(tuple, []interface{}{2, "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gocql.Unmarshal(columns[0].TypeInfo, id, r.dest[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := gocql.Unmarshal(tuple, pair, r.dest[1:3]); err != nil {
+		t.Fatal(err)
+	}
+	if err := gocql.Unmarshal(columns[2].TypeInfo, nil, r.dest[3]); err != nil {
+		t.Fatal(err)
+	}
+
+	row := r.row()
+	if diff := cmp.Diff([]interface{}{1, []interface{}{2, "b"}, nil}, row); diff != "" {
+		t.Fatal(diff)
+	}
+
+	var buf bytes.Buffer
+	if err := newJSONRowEncoder(&buf, []string{"id", "pair", "name"}).WriteRow(row); err != nil {
+		t.Fatal("WriteRow() error:", err)
+	}
+	if diff := cmp.Diff(`{"id":1,"pair":[2,"b"],"name":null}`+"\n", buf.String()); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gocql/gocql"
+)
+
+// ErrMissingDestination is the ScanError cause when a struct has no field
+// for a column.
+var ErrMissingDestination = errors.New("missing destination")
+
+// ScanError is returned when scanning a row into a struct fails. Use
+// errors.As to access it.
+type ScanError struct {
+	// Column is the name of the column.
+	Column string
+	// Type is the CQL type of the column, it's nil for missing destinations.
+	Type gocql.TypeInfo
+	// Field is the path of the destination struct field i.e. "Address.City",
+	// it's empty for missing destinations.
+	Field string
+	// GoType is the type of the destination field or the struct type for
+	// missing destinations.
+	GoType reflect.Type
+	// Err is the cause of the error.
+	Err error
+}
+
+func (e *ScanError) Error() string {
+	if e.Err == ErrMissingDestination {
+		return fmt.Sprintf("missing destination name %q in %s", e.Column, e.GoType)
+	}
+	return fmt.Sprintf("can not scan column %q of type %s into field %s of type %s: %s", e.Column, e.Type, e.Field, e.GoType, e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+// RowError is a scan error of a row skipped by Iterx.ContinueOnError or
+// delivered by Iterx.ForEach and Iterx.SelectChan.
+type RowError struct {
+	// Row is the index of the row in the result, starting from zero.
+	Row int
+	Err error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %s", e.Row, e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// RowErrors is returned by Iterx when rows were skipped due to scan errors.
+type RowErrors []RowError
+
+func (e RowErrors) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "failed to scan %d rows: ", len(e))
+	for i, r := range e {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		fmt.Fprintf(&b, "row %d: %s", r.Row, r.Err)
+	}
+	return b.String()
+}
+
+var _ gocql.Unmarshaler = &scanValue{}
+
+// scanValue annotates unmarshal errors of dest with column and field.
+type scanValue struct {
+	dest   interface{}
+	iter   *Iterx
+	column string
+	field  string
+	goType reflect.Type
+}
+
+func (v *scanValue) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	err := gocql.Unmarshal(info, data, v.dest)
+	if err == nil {
+		return nil
+	}
+
+	err = &ScanError{
+		Column: v.column,
+		Type:   info,
+		Field:  v.field,
+		GoType: v.goType,
+		Err:    err,
+	}
+	// Record the error and let the driver read the rest of the row.
+	if v.iter != nil && v.iter.rowErrorPolicy != StopOnRowError {
+		if v.iter.rowErr == nil {
+			v.iter.rowErr = err
+		}
+		return nil
+	}
+	return err
+}
+
+// fieldPath returns dot separated names of fields on the traversal path of t.
+func fieldPath(t reflect.Type, traversal []int) (path string, typ reflect.Type) {
+	names := make([]string, len(traversal))
+	for i, idx := range traversal {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		f := t.Field(idx)
+		names[i] = f.Name
+		t = f.Type
+	}
+	return strings.Join(names, "."), t
+}
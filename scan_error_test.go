@@ -0,0 +1,103 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gocql/gocql"
+)
+
+func TestScanValue(t *testing.T) {
+	type Address struct {
+		Zip int
+	}
+	type Person struct {
+		Name string
+		*Address
+	}
+
+	field, typ := fieldPath(reflect.TypeOf(&Person{}), []int{1, 0})
+	if field != "Address.Zip" || typ != reflect.TypeOf(0) {
+		t.Fatalf("fieldPath()=%s %s", field, typ)
+	}
+
+	var zip int
+	v := &scanValue{dest: &zip, column: "zip", field: field, goType: typ}
+	if err := gocql.Unmarshal(nativeType(gocql.TypeInt), []byte{0, 0, 0, 1}, v); err != nil {
+		t.Fatal("Unmarshal() error", err)
+	}
+	if zip != 1 {
+		t.Fatalf("zip=%d, expected 1", zip)
+	}
+
+	err := gocql.Unmarshal(nativeType(gocql.TypeText), []byte("a"), v)
+	var scanErr *ScanError
+	if !errors.As(err, &scanErr) {
+		t.Fatalf("Unmarshal() error=%#v, expected ScanError", err)
+	}
+	if scanErr.Column != "zip" || scanErr.Field != "Address.Zip" || scanErr.GoType != typ || scanErr.Type.Type() != gocql.TypeText {
+		t.Fatalf("unexpected ScanError %#v", scanErr)
+	}
+	const golden = `can not scan column "zip" of type text into field Address.Zip of type int: `
+	if msg := err.Error(); len(msg) < len(golden) || msg[:len(golden)] != golden {
+		t.Fatalf("Error()=%q, expected prefix %q", msg, golden)
+	}
+}
+
+func TestScanErrorMissingDestination(t *testing.T) {
+	err := error(&ScanError{Column: "a", GoType: reflect.TypeOf(struct{}{}), Err: ErrMissingDestination})
+	if !errors.Is(err, ErrMissingDestination) {
+		t.Fatal("expected ErrMissingDestination")
+	}
+	if err.Error() != `missing destination name "a" in struct {}` {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestScanValueContinueOnError(t *testing.T) {
+	iter := (&Iterx{}).ContinueOnError()
+
+	var zip int
+	v := &scanValue{dest: &zip, iter: iter, column: "zip"}
+	if err := gocql.Unmarshal(nativeType(gocql.TypeText), []byte("a"), v); err != nil {
+		t.Fatal("Unmarshal() error", err)
+	}
+	var scanErr *ScanError
+	if !errors.As(iter.rowErr, &scanErr) || scanErr.Column != "zip" {
+		t.Fatalf("rowErr=%#v, expected ScanError", iter.rowErr)
+	}
+}
+
+func TestIterxInitStructScan(t *testing.T) {
+	columns := columnNames(roundTripColumns)
+
+	for _, iter := range []*Iterx{
+		{Mapper: DefaultMapper},
+		(&Iterx{Mapper: DefaultMapper}).ContinueOnError(),
+	} {
+		var v roundTripRow
+		if err := iter.initStructScan(reflect.ValueOf(&v), columns); err != nil {
+			t.Fatal("initStructScan() error:", err)
+		}
+		if err := iter.bindStructScan(reflect.ValueOf(&v)); err != nil {
+			t.Fatal("bindStructScan() error:", err)
+		}
+		for i := range columns {
+			if _, ok := iter.scanDest[i].(*scanValue); !ok {
+				t.Fatalf("policy %d expected scanValue for column %s", iter.rowErrorPolicy, columns[i])
+			}
+		}
+	}
+}
+
+func TestRowErrors(t *testing.T) {
+	err := RowErrors{{Row: 1, Err: errors.New("a")}, {Row: 3, Err: errors.New("b")}}
+	if err.Error() != "failed to scan 2 rows: row 1: a; row 3: b" {
+		t.Fatal(err.Error())
+	}
+}
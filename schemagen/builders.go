@@ -0,0 +1,114 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package schemagen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// methodData is a typed builder method that adds a column restriction or
+// assignment and binds value v of the column type.
+type methodData struct {
+	Builder string
+	Name    string
+	Doc     string
+	Type    string
+	Call    string
+	Param   string
+}
+
+type cmpOp struct {
+	Name string
+	Fn   string
+	CQL  string
+}
+
+var (
+	opEq     = cmpOp{Name: "Eq", Fn: "qb.EqNamed", CQL: "="}
+	opIn     = cmpOp{Name: "In", Fn: "qb.InNamed", CQL: "IN"}
+	opLt     = cmpOp{Name: "Lt", Fn: "qb.LtNamed", CQL: "<"}
+	opLtOrEq = cmpOp{Name: "LtOrEq", Fn: "qb.LtOrEqNamed", CQL: "<="}
+	opGt     = cmpOp{Name: "Gt", Fn: "qb.GtNamed", CQL: ">"}
+	opGtOrEq = cmpOp{Name: "GtOrEq", Fn: "qb.GtOrEqNamed", CQL: ">="}
+)
+
+// keyOps returns comparators allowed on column in WHERE clause of SELECT and
+// DELETE statements.
+func keyOps(td *tableData, column string) []cmpOp {
+	if contains(td.Metadata.PartKey, column) {
+		return []cmpOp{opEq, opIn}
+	}
+	if contains(td.Metadata.SortKey, column) {
+		return []cmpOp{opEq, opIn, opLt, opLtOrEq, opGt, opGtOrEq}
+	}
+	return nil
+}
+
+// whereMethod returns method of builder restricting field with op.
+func whereMethod(td *tableData, builder string, f fieldData, op cmpOp) methodData {
+	param := strings.ToLower(f.Column + "_" + op.Name)
+	typ := f.Type
+	if op == opIn {
+		typ = "..." + typ
+	}
+	return methodData{
+		Builder: builder,
+		Name:    "Where" + f.Name + op.Name,
+		Doc:     fmt.Sprintf("adds %s %s ? restriction", f.Column, op.CQL),
+		Type:    typ,
+		Call:    fmt.Sprintf("Where(%s(%s, %q))", op.Fn, f.Const, param),
+		Param:   param,
+	}
+}
+
+// setBuilderMethods sets typed methods of SELECT, UPDATE and DELETE builders
+// of td, SELECT builders can also restrict columns with secondary indexes.
+func setBuilderMethods(td *tableData) {
+	var (
+		sel = td.Name + "SelectBuilder"
+		upd = td.Name + "UpdateBuilder"
+		del = td.Name + "DeleteBuilder"
+	)
+	for _, f := range td.Fields {
+		ops := keyOps(td, f.Column)
+		for _, op := range ops {
+			td.SelectMethods = append(td.SelectMethods, whereMethod(td, sel, f, op))
+			td.DeleteMethods = append(td.DeleteMethods, whereMethod(td, del, f, op))
+		}
+		// indexed columns can be restricted without ALLOW FILTERING
+		if len(ops) == 0 && contains(td.Metadata.Indexes, f.Column) {
+			td.SelectMethods = append(td.SelectMethods, whereMethod(td, sel, f, opEq))
+		}
+		if len(ops) > 0 {
+			td.UpdateMethods = append(td.UpdateMethods, whereMethod(td, upd, f, opEq))
+			continue
+		}
+		td.UpdateMethods = append(td.UpdateMethods, methodData{
+			Builder: upd,
+			Name:    "Set" + f.Name,
+			Doc:     fmt.Sprintf("sets %s", f.Column),
+			Type:    f.Type,
+			Call:    fmt.Sprintf("SetNamed(%s, %q)", f.Const, f.Column),
+			Param:   f.Column,
+		})
+	}
+	for _, c := range td.Metadata.SortKey {
+		for _, f := range td.Fields {
+			if f.Column == c {
+				td.OrderBy = append(td.OrderBy, f)
+			}
+		}
+	}
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,227 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package schemagen
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/scylladb/gocqlx/v2/table"
+)
+
+//go:embed keyspace.tmpl
+var keyspaceTmpl string
+
+var tmpl = template.Must(template.New("keyspace").Funcs(template.FuncMap{
+	"join": strings.Join,
+	"columns": func(consts map[string]string, columns []string) string {
+		c := make([]string, len(columns))
+		for i := range columns {
+			c[i] = consts[columns[i]]
+		}
+		return "[]string{" + strings.Join(c, ", ") + "}"
+	},
+}).Parse(keyspaceTmpl))
+
+type keyspaceData struct {
+	Package       string
+	Keyspace      string
+	Repository    bool
+	UDTMarshalers bool
+	Imports       [][]string
+	Tables        []tableData
+	Views         []tableData
+	Types         []udtData
+}
+
+type tableData struct {
+	Name          string
+	Kind          string
+	Base          *tableData
+	Metadata      table.Metadata
+	Fields        []fieldData
+	Consts        map[string]string
+	OrderBy       []fieldData
+	SelectMethods []methodData
+	UpdateMethods []methodData
+	DeleteMethods []methodData
+	Repository    *repositoryData
+}
+
+type udtData struct {
+	Name   string
+	CQL    string
+	Fields []fieldData
+}
+
+type fieldData struct {
+	Name   string
+	Type   string
+	Column string
+	Const  string
+}
+
+// renderKeyspace returns formatted Go source of package pkg with models of
+// keyspace ks.
+func renderKeyspace(ks *keyspaceSchema, pkg string, cfg Config) ([]byte, error) { // nolint: gocritic
+	m := newTypeMapper(ks, cfg)
+	for _, p := range []string{
+		"context",
+		"github.com/scylladb/gocqlx/v2",
+		"github.com/scylladb/gocqlx/v2/qb",
+		"github.com/scylladb/gocqlx/v2/table",
+	} {
+		m.imports[p] = true
+	}
+
+	data := keyspaceData{
+		Package:       pkg,
+		Keyspace:      ks.Name,
+		Repository:    cfg.Repository,
+		UDTMarshalers: cfg.UDTMarshalers,
+	}
+	if cfg.Repository || cfg.UDTMarshalers && len(ks.Types) > 0 {
+		m.imports["github.com/gocql/gocql"] = true
+	}
+	for _, t := range ks.Tables {
+		td := tableData{
+			Name:     m.naming(t.Name),
+			Kind:     "table",
+			Metadata: t,
+			Consts:   make(map[string]string, len(t.Columns)),
+		}
+		td.Metadata.Name = qualifiedName(ks.Name, t.Name)
+		for _, c := range t.Columns {
+			typ, err := m.columnType(t.Name, c, t.Types[c])
+			if err != nil {
+				return nil, fmt.Errorf("table %s column %s: %s", t.Name, c, err)
+			}
+			f := fieldData{Name: m.naming(c), Type: typ, Column: c}
+			f.Const = td.Name + "Col" + f.Name
+			td.Fields = append(td.Fields, f)
+			td.Consts[c] = f.Const
+		}
+		if err := checkFields(td.Fields); err != nil {
+			return nil, fmt.Errorf("table %s: %s", t.Name, err)
+		}
+		setBuilderMethods(&td)
+		if cfg.Repository {
+			setRepository(&td)
+		}
+		data.Tables = append(data.Tables, td)
+	}
+	for _, v := range ks.Views {
+		var base *tableData
+		for i := range data.Tables {
+			if data.Tables[i].Metadata.Name == qualifiedName(ks.Name, v.Base) {
+				base = &data.Tables[i]
+			}
+		}
+		if base == nil {
+			return nil, fmt.Errorf("view %s: base table %s not found", v.Metadata.Name, v.Base)
+		}
+		vm := v.Metadata
+		vm.Name = qualifiedName(ks.Name, vm.Name)
+		data.Views = append(data.Views, viewData(m.naming(v.Metadata.Name), vm, base))
+	}
+	for _, t := range ks.Types {
+		if len(t.FieldNames) != len(t.FieldTypes) {
+			return nil, fmt.Errorf("type %s: field names and types mismatch", t.TypeName)
+		}
+		ud := udtData{
+			Name: m.udtName(t.TypeName),
+			CQL:  t.TypeName,
+		}
+		for i, f := range t.FieldNames {
+			typ, err := m.goType(t.FieldTypes[i])
+			if err != nil {
+				return nil, fmt.Errorf("type %s field %s: %s", t.TypeName, f, err)
+			}
+			ud.Fields = append(ud.Fields, fieldData{Name: m.naming(f), Type: typ, Column: f})
+		}
+		if err := checkFields(ud.Fields); err != nil {
+			return nil, fmt.Errorf("type %s: %s", t.TypeName, err)
+		}
+		data.Types = append(data.Types, ud)
+	}
+	data.Imports = groupImports(m.imports)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	b, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %s", err)
+	}
+	return b, nil
+}
+
+// qualifiedName returns name of table or view qualified with keyspace so
+// that generated queries do not depend on the session keyspace.
+func qualifiedName(keyspace, name string) string {
+	return keyspace + "." + name
+}
+
+// viewData returns data of materialized view with metadata m, fields and
+// column constants are shared with the base table.
+func viewData(name string, m table.Metadata, base *tableData) tableData { // nolint: gocritic
+	td := tableData{
+		Name:     name,
+		Kind:     "materialized view",
+		Base:     base,
+		Metadata: m,
+		Consts:   base.Consts,
+	}
+	for _, c := range m.Columns {
+		for _, f := range base.Fields {
+			if f.Column == c {
+				td.Fields = append(td.Fields, f)
+			}
+		}
+	}
+	setBuilderMethods(&td)
+	return td
+}
+
+// checkFields returns error if two columns map to the same Go name.
+func checkFields(fields []fieldData) error {
+	names := make(map[string]string, len(fields))
+	for _, f := range fields {
+		if c, ok := names[f.Name]; ok {
+			return fmt.Errorf("columns %s and %s map to the same Go name %s", c, f.Column, f.Name)
+		}
+		names[f.Name] = f.Column
+	}
+	return nil
+}
+
+// groupImports returns sorted standard library imports followed by sorted
+// other imports.
+func groupImports(imports map[string]bool) [][]string {
+	var std, other []string
+	for p := range imports {
+		if strings.Contains(strings.SplitN(p, "/", 2)[0], ".") {
+			other = append(other, p)
+		} else {
+			std = append(std, p)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+
+	var groups [][]string
+	for _, g := range [][]string{std, other} {
+		if len(g) > 0 {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
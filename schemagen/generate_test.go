@@ -0,0 +1,128 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package schemagen
+
+import (
+	"flag"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/gocqlx/v2/table"
+)
+
+var flagUpdate = flag.Bool("update", false, "update golden files")
+
+var testKeyspace = &keyspaceSchema{
+	Name: "examples",
+	Tables: []table.Metadata{
+		{
+			Name:    "playlists",
+			Columns: []string{"id", "title", "album", "artist", "song_id", "owner"},
+			PartKey: []string{"id"},
+			SortKey: []string{"title", "album", "artist"},
+			Static:  []string{"owner"},
+			Types: map[string]string{
+				"id":      "uuid",
+				"title":   "text",
+				"album":   "text",
+				"artist":  "text",
+				"song_id": "uuid",
+				"owner":   "text",
+			},
+		},
+		{
+			Name:    "songs",
+			Columns: []string{"id", "album", "artist", "data", "duration", "location", "tags", "ratings", "title"},
+			PartKey: []string{"id"},
+			Indexes: []string{"artist"},
+			Types: map[string]string{
+				"id":       "uuid",
+				"album":    "frozen<album>",
+				"artist":   "text",
+				"data":     "blob",
+				"duration": "duration",
+				"location": "tuple<double, double>",
+				"tags":     "set<text>",
+				"ratings":  "map<text, int>",
+				"title":    "text",
+			},
+		},
+	},
+	Views: []viewSchema{
+		{
+			Metadata: table.Metadata{
+				Name:    "songs_by_title",
+				Columns: []string{"title", "id", "artist"},
+				PartKey: []string{"title"},
+				SortKey: []string{"id"},
+			},
+			Base: "songs",
+		},
+	},
+	Types: []userType{
+		{
+			TypeName:   "album",
+			FieldNames: []string{"name", "release_date", "tracks"},
+			FieldTypes: []string{"text", "date", "list<frozen<track>>"},
+		},
+		{
+			TypeName:   "track",
+			FieldNames: []string{"title", "length"},
+			FieldTypes: []string{"text", "time"},
+		},
+	},
+}
+
+func TestRenderKeyspace(t *testing.T) {
+	table := []struct {
+		Name   string
+		Config Config
+		Golden string
+	}{
+		{
+			Name:   "default",
+			Golden: "testdata/models.go.txt",
+		},
+		{
+			Name:   "repository",
+			Config: Config{Repository: true},
+			Golden: "testdata/repository.go.txt",
+		},
+		{
+			Name:   "udt marshalers",
+			Config: Config{UDTMarshalers: true},
+			Golden: "testdata/udt_marshalers.go.txt",
+		},
+	}
+
+	for _, test := range table {
+		t.Run(test.Name, func(t *testing.T) {
+			testRenderKeyspace(t, test.Config, test.Golden)
+		})
+	}
+}
+
+func testRenderKeyspace(t *testing.T, cfg Config, golden string) { // nolint: gocritic
+	t.Helper()
+
+	b, err := renderKeyspace(testKeyspace, "models", cfg)
+	if err != nil {
+		t.Fatal("renderKeyspace() error:", err)
+	}
+
+	if *flagUpdate {
+		if err := ioutil.WriteFile(golden, b, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(want), string(b)); diff != "" {
+		t.Fatal(diff)
+	}
+}
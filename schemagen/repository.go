@@ -0,0 +1,87 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package schemagen
+
+import (
+	"go/token"
+	"unicode"
+)
+
+// repositoryData is a repository of table rows.
+type repositoryData struct {
+	PrimaryKey []paramData
+	PartKey    []paramData
+	Update     []string
+}
+
+// paramData is a method parameter holding value of column.
+type paramData struct {
+	Name  string
+	Type  string
+	Const string
+}
+
+// reservedParams are names of variables and packages used by generated
+// repository methods.
+var reservedParams = map[string]bool{
+	"ctx":     true,
+	"opts":    true,
+	"q":       true,
+	"r":       true,
+	"row":     true,
+	"rows":    true,
+	"context": true,
+	"gocql":   true,
+	"gocqlx":  true,
+	"qb":      true,
+	"table":   true,
+}
+
+// paramName returns method parameter name of field with Go name name.
+func paramName(name string) string {
+	r := []rune(name)
+	// lower case the leading upper case run but keep the first letter of
+	// the next word i.e. URLPath is urlPath
+	for i := range r {
+		if !unicode.IsUpper(r[i]) {
+			if i > 1 && unicode.IsLower(r[i]) {
+				r[i-1] = unicode.ToUpper(r[i-1])
+			}
+			break
+		}
+		r[i] = unicode.ToLower(r[i])
+	}
+	p := string(r)
+	if token.IsKeyword(p) || reservedParams[p] {
+		p += "Value"
+	}
+	return p
+}
+
+// setRepository sets repository data of td.
+func setRepository(td *tableData) {
+	params := func(columns []string) []paramData {
+		var ps []paramData
+		for _, c := range columns {
+			for _, f := range td.Fields {
+				if f.Column == c {
+					ps = append(ps, paramData{Name: paramName(f.Name), Type: f.Type, Const: f.Const})
+				}
+			}
+		}
+		return ps
+	}
+
+	r := &repositoryData{
+		PrimaryKey: params(append(append([]string(nil), td.Metadata.PartKey...), td.Metadata.SortKey...)),
+		PartKey:    params(td.Metadata.PartKey),
+	}
+	for _, f := range td.Fields {
+		if !contains(td.Metadata.PartKey, f.Column) && !contains(td.Metadata.SortKey, f.Column) {
+			r.Update = append(r.Update, f.Const)
+		}
+	}
+	td.Repository = r
+}
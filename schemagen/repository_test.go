@@ -0,0 +1,30 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package schemagen
+
+import (
+	"testing"
+)
+
+func TestParamName(t *testing.T) {
+	table := []struct {
+		Name  string
+		Param string
+	}{
+		{Name: "ID", Param: "id"},
+		{Name: "SongID", Param: "songID"},
+		{Name: "URLPath", Param: "urlPath"},
+		{Name: "Title", Param: "title"},
+		{Name: "Type", Param: "typeValue"},
+		{Name: "Ctx", Param: "ctxValue"},
+		{Name: "Table", Param: "tableValue"},
+	}
+
+	for _, test := range table {
+		if v := paramName(test.Name); v != test.Param {
+			t.Errorf("paramName(%q)=%q expected %q", test.Name, v, test.Param)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package schemagen
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+	"github.com/scylladb/gocqlx/v2/table"
+)
+
+// keyspaceSchema is the schema of a keyspace read from system_schema.
+type keyspaceSchema struct {
+	Name   string
+	Tables []table.Metadata
+	Views  []viewSchema
+	Types  []userType
+}
+
+// viewSchema is a materialized view of base table.
+type viewSchema struct {
+	Metadata table.Metadata
+	Base     string
+}
+
+// userType is a row of system_schema.types.
+type userType struct {
+	TypeName   string
+	FieldNames []string
+	FieldTypes []string
+}
+
+// readKeyspace reads tables, materialized views and user defined types of
+// keyspace, all sorted by name. Table metadata names are not qualified with
+// the keyspace name. If match is not nil only tables and views it matches
+// are read, views of tables that are not read are skipped. Views backing
+// secondary indexes are skipped.
+func readKeyspace(ctx context.Context, session gocqlx.Session, keyspace string, match func(table string) bool) (*keyspaceSchema, error) {
+	stmt, names := qb.Select("system_schema.tables").
+		Columns("table_name").
+		Where(qb.Eq("keyspace_name")).
+		ToCql()
+	var tables []string
+	err := session.ContextQuery(ctx, stmt, names).
+		Bind(keyspace).
+		SelectRelease(&tables)
+	if err != nil {
+		return nil, fmt.Errorf("read tables of %s: %s", keyspace, err)
+	}
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("keyspace %s has no tables", keyspace)
+	}
+	sort.Strings(tables)
+
+	ks := &keyspaceSchema{Name: keyspace}
+	for _, name := range tables {
+		if match != nil && !match(name) {
+			continue
+		}
+		m, err := table.FromClusterContext(ctx, session, keyspace, name)
+		if err != nil {
+			return nil, err
+		}
+		m.Name = name
+		ks.Tables = append(ks.Tables, m)
+	}
+
+	if len(ks.Tables) == 0 {
+		return nil, fmt.Errorf("keyspace %s has no matching tables", keyspace)
+	}
+
+	if ks.Views, err = readViews(ctx, session, ks, match); err != nil {
+		return nil, err
+	}
+
+	stmt, names = qb.Select("system_schema.types").
+		Columns("type_name", "field_names", "field_types").
+		Where(qb.Eq("keyspace_name")).
+		ToCql()
+	err = session.ContextQuery(ctx, stmt, names).
+		Bind(keyspace).
+		SelectRelease(&ks.Types)
+	if err != nil {
+		return nil, fmt.Errorf("read types of %s: %s", keyspace, err)
+	}
+	sort.Slice(ks.Types, func(i, j int) bool {
+		return ks.Types[i].TypeName < ks.Types[j].TypeName
+	})
+
+	return ks, nil
+}
+
+func readViews(ctx context.Context, session gocqlx.Session, ks *keyspaceSchema, match func(table string) bool) ([]viewSchema, error) {
+	stmt, names := qb.Select("system_schema.indexes").
+		Columns("index_name").
+		Where(qb.Eq("keyspace_name")).
+		ToCql()
+	var indexes []string
+	err := session.ContextQuery(ctx, stmt, names).
+		Bind(ks.Name).
+		SelectRelease(&indexes)
+	if err != nil {
+		return nil, fmt.Errorf("read indexes of %s: %s", ks.Name, err)
+	}
+	indexViews := make(map[string]bool, len(indexes))
+	for _, name := range indexes {
+		indexViews[name+"_index"] = true
+	}
+	tables := make(map[string]bool, len(ks.Tables))
+	for _, t := range ks.Tables {
+		tables[t.Name] = true
+	}
+
+	stmt, names = qb.Select("system_schema.views").
+		Columns("view_name", "base_table_name").
+		Where(qb.Eq("keyspace_name")).
+		ToCql()
+	var views []struct {
+		ViewName      string
+		BaseTableName string
+	}
+	err = session.ContextQuery(ctx, stmt, names).
+		Bind(ks.Name).
+		SelectRelease(&views)
+	if err != nil {
+		return nil, fmt.Errorf("read views of %s: %s", ks.Name, err)
+	}
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].ViewName < views[j].ViewName
+	})
+
+	var vs []viewSchema
+	for _, v := range views {
+		if indexViews[v.ViewName] || !tables[v.BaseTableName] {
+			continue
+		}
+		if match != nil && !match(v.ViewName) {
+			continue
+		}
+		m, err := table.FromClusterContext(ctx, session, ks.Name, v.ViewName)
+		if err != nil {
+			return nil, err
+		}
+		m.Name = v.ViewName
+		vs = append(vs, viewSchema{Metadata: m, Base: v.BaseTableName})
+	}
+	return vs, nil
+}
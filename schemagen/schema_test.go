@@ -0,0 +1,121 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+// +build all integration
+
+package schemagen
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/gocqlx/v2"
+	. "github.com/scylladb/gocqlx/v2/gocqlxtest"
+)
+
+func createSchema(t *testing.T, session gocqlx.Session) {
+	t.Helper()
+
+	for _, stmt := range []string{
+		"CREATE KEYSPACE IF NOT EXISTS gocqlx_schemagen WITH replication = {'class' : 'SimpleStrategy', 'replication_factor' : 1}",
+		"CREATE TYPE IF NOT EXISTS gocqlx_schemagen.track (title text, length time)",
+		"CREATE TABLE IF NOT EXISTS gocqlx_schemagen.playlists (id uuid, title text, tracks list<frozen<track>>, owner text static, PRIMARY KEY (id, title))",
+		"CREATE TABLE IF NOT EXISTS gocqlx_schemagen.songs (id uuid PRIMARY KEY, title text, artist text)",
+		"CREATE INDEX IF NOT EXISTS ON gocqlx_schemagen.songs (artist)",
+		"CREATE MATERIALIZED VIEW IF NOT EXISTS gocqlx_schemagen.songs_by_title AS SELECT * FROM gocqlx_schemagen.songs WHERE title IS NOT NULL AND id IS NOT NULL PRIMARY KEY (title, id)",
+	} {
+		if err := session.ExecStmt(stmt); err != nil {
+			t.Fatal("create:", err)
+		}
+	}
+}
+
+func TestReadKeyspace(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	createSchema(t, session)
+
+	ks, err := readKeyspace(context.Background(), session, "gocqlx_schemagen", nil)
+	if err != nil {
+		t.Fatal("readKeyspace() error:", err)
+	}
+	if len(ks.Tables) != 2 {
+		t.Fatalf("expected 2 tables got %d", len(ks.Tables))
+	}
+	m := ks.Tables[0]
+	if m.Name != "playlists" {
+		t.Fatal("expected playlists got", m.Name)
+	}
+	if diff := cmp.Diff([]string{"id", "title", "owner", "tracks"}, m.Columns); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff([]string{"artist"}, ks.Tables[1].Indexes); diff != "" {
+		t.Fatal(diff)
+	}
+	if len(ks.Views) != 1 || ks.Views[0].Metadata.Name != "songs_by_title" || ks.Views[0].Base != "songs" {
+		t.Fatalf("unexpected views %+v", ks.Views)
+	}
+	if diff := cmp.Diff([]userType{{
+		TypeName:   "track",
+		FieldNames: []string{"title", "length"},
+		FieldTypes: []string{"text", "time"},
+	}}, ks.Types); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if _, err := renderKeyspace(ks, "models", Config{}); err != nil {
+		t.Fatal("renderKeyspace() error:", err)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+	createSchema(t, session)
+
+	dir, err := ioutil.TempDir("", "schemagen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	output := filepath.Join(dir, "models", "models.go")
+	err = Generate(Config{
+		Cluster: CreateCluster(),
+		Keyspaces: []KeyspaceConfig{
+			{Name: "gocqlx_schemagen", Output: output},
+		},
+		Include:    []*regexp.Regexp{regexp.MustCompile("^play")},
+		Repository: true,
+	})
+	if err != nil {
+		t.Fatal("Generate() error:", err)
+	}
+	b, err := ioutil.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b, []byte("package gocqlxschemagen")) {
+		t.Fatal("unexpected package name")
+	}
+	if !bytes.Contains(b, []byte(`"gocqlx_schemagen.playlists"`)) {
+		t.Fatal("expected table name qualified with keyspace")
+	}
+
+	err = Generate(Config{
+		Cluster:  CreateCluster(),
+		Keyspace: "gocqlx_schemagen",
+		Output:   output,
+		Exclude:  []*regexp.Regexp{regexp.MustCompile(".")},
+	})
+	if err == nil {
+		t.Fatal("Generate() expected error")
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+// Package schemagen generates Go structs and table metadata from the schema
+// of a keyspace, it connects to a cluster and reads system_schema tables.
+//
+// For every table it generates column name constants, table.Metadata and
+// table.Table variables and a struct with a field for every column, for
+// every user defined type it generates a struct embedding gocqlx.UDT.
+// Column name constants are named after the table and the column i.e.
+// column email of table users is UsersColEmail, use them in query builders
+// so that renamed columns break compilation.
+//
+// Every table also gets type safe SELECT, UPDATE and DELETE builders, their
+// methods restrict only the table key columns and accept values of the
+// column Go types, i.e. UsersSelect().WhereIDEq(id).Query(session). Columns
+// with secondary indexes are recorded in table metadata Indexes and can be
+// restricted in SELECT builders as they do not need ALLOW FILTERING.
+//
+// Materialized views get table.Metadata and table.View variables and
+// a SELECT builder, rows of a view are read into the base table struct.
+//
+// If Config.Repository is set every table also gets a repository i.e.
+// UsersRepository with context aware Get, List, Insert, Update and Delete
+// methods. Query options such as WithConsistency can be set for all queries
+// of a repository or passed to a single call.
+//
+// If Config.UDTMarshalers is set user defined types implement
+// gocql.UDTMarshaler and gocql.UDTUnmarshaler with generated code instead
+// of embedding gocqlx.UDT.
+//
+// Generate can be called from a program run by go:generate, the schemagen
+// command provides the same functionality with flags. Code of several
+// keyspaces can be generated at once, each keyspace is written to its own
+// package, tables can be filtered with regular expressions.
+package schemagen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/gocql/gocql"
+	"github.com/scylladb/gocqlx/v2"
+)
+
+// DefaultPackage is the default name of the generated package.
+const DefaultPackage = "models"
+
+// Config specifies the schema to read and the generated code.
+type Config struct {
+	// Cluster is the cluster to read the schema from.
+	Cluster *gocql.ClusterConfig
+	// Keyspace is the keyspace to generate code for.
+	Keyspace string
+	// Package is the name of the package generated for Keyspace, by default
+	// DefaultPackage.
+	Package string
+	// Output is the path of the file generated for Keyspace, by default
+	// it's <Package>/<Package>.go.
+	Output string
+	// Keyspaces lists keyspaces to generate code for in addition to
+	// Keyspace, each keyspace is written to its own package.
+	Keyspaces []KeyspaceConfig
+	// Include if not empty limits generated tables to tables with names
+	// matching any of the expressions.
+	Include []*regexp.Regexp
+	// Exclude skips tables with names matching any of the expressions.
+	Exclude []*regexp.Regexp
+	// Types overrides Go types of CQL types, keys are CQL type names such as
+	// timestamp or names of user defined types, values are Go types
+	// qualified with import path i.e. int64, net.IP or
+	// github.com/google/uuid.UUID.
+	Types map[string]string
+	// Columns overrides Go types of single columns, keys are table and column
+	// names i.e. users.status, values are Go types as in Types. Use it for
+	// types that implement gocql.Marshaler and gocql.Unmarshaler i.e. enum
+	// types backed by gocqlx.Enum, or for types of blob columns encoded with
+	// a codec registered with gocqlx.RegisterCodec i.e. protobuf messages
+	// with *github.com/acme/pb.Event.
+	Columns map[string]string
+	// Naming converts CQL table, column and type names to exported Go names,
+	// by default Camelize.
+	Naming func(name string) string
+	// Repository enables generation of a repository type for every table.
+	Repository bool
+	// UDTMarshalers enables generation of MarshalUDT and UnmarshalUDT
+	// methods of user defined types instead of embedding gocqlx.UDT, so
+	// that the types are marshalled without reflection.
+	UDTMarshalers bool
+}
+
+// KeyspaceConfig specifies the generated code of a keyspace.
+type KeyspaceConfig struct {
+	// Name is the keyspace name.
+	Name string
+	// Package is the name of the generated package, by default it's
+	// PackageName of the keyspace.
+	Package string
+	// Output is the path of the generated file, by default it's
+	// <Package>/<Package>.go.
+	Output string
+}
+
+// PackageName returns the default package name of keyspace, it's the keyspace
+// name in lower case without underscores.
+func PackageName(keyspace string) string {
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, keyspace)
+}
+
+func (k KeyspaceConfig) pkg() string {
+	if k.Package != "" {
+		return k.Package
+	}
+	return PackageName(k.Name)
+}
+
+func (k KeyspaceConfig) output() string {
+	if k.Output != "" {
+		return k.Output
+	}
+	return filepath.Join(k.pkg(), k.pkg()+".go")
+}
+
+// keyspaces returns Keyspace and Keyspaces with Keyspace package defaulting
+// to DefaultPackage.
+func (c *Config) keyspaces() []KeyspaceConfig {
+	var ks []KeyspaceConfig
+	if c.Keyspace != "" {
+		k := KeyspaceConfig{Name: c.Keyspace, Package: c.Package, Output: c.Output}
+		if k.Package == "" {
+			k.Package = DefaultPackage
+		}
+		ks = append(ks, k)
+	}
+	return append(ks, c.Keyspaces...)
+}
+
+// match returns true if table should be generated.
+func (c *Config) match(table string) bool {
+	for _, re := range c.Exclude {
+		if re.MatchString(table) {
+			return false
+		}
+	}
+	if len(c.Include) == 0 {
+		return true
+	}
+	for _, re := range c.Include {
+		if re.MatchString(table) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Config) naming() func(name string) string {
+	if c.Naming == nil {
+		return Camelize
+	}
+	return c.Naming
+}
+
+// Generate reads the schema of the configured keyspaces and writes
+// generated code of every keyspace to its output creating the directory if
+// needed.
+func Generate(cfg Config) error { // nolint: gocritic
+	if cfg.Cluster == nil {
+		return errors.New("missing cluster")
+	}
+	keyspaces := cfg.keyspaces()
+	if len(keyspaces) == 0 {
+		return errors.New("missing keyspace")
+	}
+	outputs := make(map[string]string, len(keyspaces))
+	for _, k := range keyspaces {
+		if k.Name == "" {
+			return errors.New("missing keyspace name")
+		}
+		if ks, ok := outputs[k.output()]; ok {
+			return fmt.Errorf("keyspaces %s and %s have the same output %s", ks, k.Name, k.output())
+		}
+		outputs[k.output()] = k.Name
+	}
+
+	session, err := gocqlx.WrapSession(cfg.Cluster.CreateSession())
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %s", err)
+	}
+	defer session.Close()
+
+	for _, k := range keyspaces {
+		if err := generateKeyspace(context.Background(), session, k, cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func generateKeyspace(ctx context.Context, session gocqlx.Session, k KeyspaceConfig, cfg Config) error { // nolint: gocritic
+	ks, err := readKeyspace(ctx, session, k.Name, cfg.match)
+	if err != nil {
+		return err
+	}
+	b, err := renderKeyspace(ks, k.pkg(), cfg)
+	if err != nil {
+		return fmt.Errorf("keyspace %s: %s", k.Name, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(k.output()), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(k.output(), b, 0644)
+}
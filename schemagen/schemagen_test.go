@@ -0,0 +1,63 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package schemagen
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConfigMatch(t *testing.T) {
+	cfg := Config{
+		Include: []*regexp.Regexp{regexp.MustCompile("^users"), regexp.MustCompile("^orders$")},
+		Exclude: []*regexp.Regexp{regexp.MustCompile("_tmp$")},
+	}
+
+	table := []struct {
+		Table string
+		Match bool
+	}{
+		{Table: "users", Match: true},
+		{Table: "users_by_email", Match: true},
+		{Table: "users_tmp", Match: false},
+		{Table: "orders", Match: true},
+		{Table: "orders_by_user", Match: false},
+		{Table: "songs", Match: false},
+	}
+	for _, test := range table {
+		if v := cfg.match(test.Table); v != test.Match {
+			t.Errorf("match(%q)=%v expected %v", test.Table, v, test.Match)
+		}
+	}
+
+	if !(&Config{Exclude: cfg.Exclude}).match("songs") {
+		t.Error("expected match with empty include")
+	}
+}
+
+func TestConfigKeyspaces(t *testing.T) {
+	cfg := Config{
+		Keyspace: "examples",
+		Keyspaces: []KeyspaceConfig{
+			{Name: "user_data"},
+			{Name: "Billing", Package: "bills", Output: "gen/bills.go"},
+		},
+	}
+
+	var v [][]string
+	for _, k := range cfg.keyspaces() {
+		v = append(v, []string{k.Name, k.pkg(), k.output()})
+	}
+	expected := [][]string{
+		{"examples", "models", "models/models.go"},
+		{"user_data", "userdata", "userdata/userdata.go"},
+		{"Billing", "bills", "gen/bills.go"},
+	}
+	if diff := cmp.Diff(expected, v); diff != "" {
+		t.Fatal(diff)
+	}
+}
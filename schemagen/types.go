@@ -0,0 +1,281 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package schemagen
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// cqlType is a parsed CQL type such as map<text, frozen<list<int>>>.
+type cqlType struct {
+	Name string
+	Args []cqlType
+}
+
+// parseType parses CQL type as found in system_schema tables.
+func parseType(s string) (cqlType, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexByte(s, '<')
+	if i == -1 {
+		if s == "" {
+			return cqlType{}, fmt.Errorf("empty type")
+		}
+		return cqlType{Name: unquote(s)}, nil
+	}
+	if !strings.HasSuffix(s, ">") {
+		return cqlType{}, fmt.Errorf("invalid type %q", s)
+	}
+
+	t := cqlType{Name: strings.TrimSpace(s[:i])}
+	args, err := splitArgs(s[i+1 : len(s)-1])
+	if err != nil {
+		return cqlType{}, fmt.Errorf("invalid type %q: %s", s, err)
+	}
+	for _, a := range args {
+		at, err := parseType(a)
+		if err != nil {
+			return cqlType{}, err
+		}
+		t.Args = append(t.Args, at)
+	}
+	return t, nil
+}
+
+// splitArgs splits type arguments on top level commas.
+func splitArgs(s string) ([]string, error) {
+	var (
+		args   []string
+		depth  int
+		quoted bool
+		start  int
+	)
+	for i, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == '<':
+			depth++
+		case r == '>':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced brackets")
+			}
+		case r == ',' && depth == 0:
+			args = append(args, s[start:i])
+			start = i + 1
+		}
+	}
+	if depth != 0 || quoted {
+		return nil, fmt.Errorf("unbalanced brackets or quotes")
+	}
+	return append(args, s[start:]), nil
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return strings.Replace(s[1:len(s)-1], `""`, `"`, -1)
+	}
+	return s
+}
+
+// typeMapper maps CQL types to Go types and records imports needed by
+// the mapped types.
+type typeMapper struct {
+	naming    func(name string) string
+	overrides map[string]string
+	columns   map[string]string
+	types     map[string]bool
+	imports   map[string]bool
+}
+
+func newTypeMapper(ks *keyspaceSchema, cfg Config) *typeMapper { // nolint: gocritic
+	m := &typeMapper{
+		naming:    cfg.naming(),
+		overrides: cfg.Types,
+		columns:   cfg.Columns,
+		types:     make(map[string]bool, len(ks.Types)),
+		imports:   make(map[string]bool),
+	}
+	for _, t := range ks.Types {
+		m.types[t.TypeName] = true
+	}
+	return m
+}
+
+// goType returns Go type of CQL type s.
+func (m *typeMapper) goType(s string) (string, error) {
+	t, err := parseType(s)
+	if err != nil {
+		return "", err
+	}
+	return m.mapType(t)
+}
+
+// columnType returns Go type of column of table with CQL type s.
+func (m *typeMapper) columnType(table, column, s string) (string, error) {
+	if v, ok := m.columns[table+"."+column]; ok {
+		return m.override(v), nil
+	}
+	return m.goType(s)
+}
+
+var simpleTypes = map[string]string{
+	"ascii":     "string",
+	"bigint":    "int64",
+	"blob":      "[]byte",
+	"boolean":   "bool",
+	"counter":   "int64",
+	"date":      "time.Time",
+	"decimal":   "*inf.Dec",
+	"double":    "float64",
+	"duration":  "gocql.Duration",
+	"float":     "float32",
+	"inet":      "string",
+	"int":       "int32",
+	"smallint":  "int16",
+	"text":      "string",
+	"time":      "time.Duration",
+	"timestamp": "time.Time",
+	"timeuuid":  "gocql.UUID",
+	"tinyint":   "int8",
+	"uuid":      "gocql.UUID",
+	"varchar":   "string",
+	"varint":    "*big.Int",
+}
+
+var typeImports = map[string]string{
+	"gocql": "github.com/gocql/gocql",
+	"inf":   "gopkg.in/inf.v0",
+	"big":   "math/big",
+	"time":  "time",
+}
+
+func (m *typeMapper) mapType(t cqlType) (string, error) {
+	args := func(n int) error {
+		if len(t.Args) != n {
+			return fmt.Errorf("%s expects %d type arguments got %d", t.Name, n, len(t.Args))
+		}
+		return nil
+	}
+
+	switch t.Name {
+	case "frozen":
+		if err := args(1); err != nil {
+			return "", err
+		}
+		return m.mapType(t.Args[0])
+	case "list", "set":
+		if err := args(1); err != nil {
+			return "", err
+		}
+		v, err := m.mapType(t.Args[0])
+		if err != nil {
+			return "", err
+		}
+		return "[]" + v, nil
+	case "map":
+		if err := args(2); err != nil {
+			return "", err
+		}
+		k, err := m.mapType(t.Args[0])
+		if err != nil {
+			return "", err
+		}
+		v, err := m.mapType(t.Args[1])
+		if err != nil {
+			return "", err
+		}
+		return "map[" + k + "]" + v, nil
+	case "tuple":
+		if len(t.Args) == 0 {
+			return "", fmt.Errorf("tuple expects type arguments")
+		}
+		var b strings.Builder
+		b.WriteString("struct {\n")
+		for i, a := range t.Args {
+			v, err := m.mapType(a)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&b, "Field%d %s\n", i+1, v)
+		}
+		b.WriteString("}")
+		return b.String(), nil
+	}
+
+	if len(t.Args) != 0 {
+		return "", fmt.Errorf("unsupported type %s", t.Name)
+	}
+	if v, ok := m.overrides[t.Name]; ok {
+		return m.override(v), nil
+	}
+	if v, ok := simpleTypes[t.Name]; ok {
+		if i := strings.IndexByte(v, '.'); i != -1 {
+			m.imports[typeImports[strings.TrimPrefix(v[:i], "*")]] = true
+		}
+		return v, nil
+	}
+	if m.types[t.Name] {
+		return m.udtName(t.Name), nil
+	}
+	return "", fmt.Errorf("unsupported type %s", t.Name)
+}
+
+// override returns Go type of type override v and records its import,
+// qualified types are written with import path i.e. net.IP or
+// *github.com/google/uuid.UUID.
+func (m *typeMapper) override(v string) string {
+	prefix := v[:len(v)-len(strings.TrimLeft(v, "*[]"))]
+	typ := v[len(prefix):]
+
+	i := strings.LastIndexByte(typ, '.')
+	if i == -1 {
+		return v
+	}
+	path := typ[:i]
+	m.imports[path] = true
+	return prefix + path[strings.LastIndexByte(path, '/')+1:] + typ[i:]
+}
+
+func (m *typeMapper) udtName(name string) string {
+	return m.naming(name) + "UserType"
+}
+
+// commonInitialisms are written in upper case in Go identifiers.
+var commonInitialisms = map[string]bool{
+	"acl": true, "api": true, "ascii": true, "cpu": true, "css": true,
+	"dns": true, "eof": true, "guid": true, "html": true, "http": true,
+	"https": true, "id": true, "ip": true, "json": true, "lhs": true,
+	"qps": true, "ram": true, "rhs": true, "rpc": true, "sla": true,
+	"smtp": true, "sql": true, "ssh": true, "tcp": true, "tls": true,
+	"ttl": true, "udp": true, "ui": true, "uid": true, "uuid": true,
+	"uri": true, "url": true, "utf8": true, "vm": true, "xml": true,
+}
+
+// Camelize converts CQL name such as user_id to exported Go name UserID,
+// it's the default naming strategy.
+func Camelize(s string) string {
+	var b strings.Builder
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		if commonInitialisms[strings.ToLower(w)] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
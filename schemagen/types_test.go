@@ -0,0 +1,154 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package schemagen
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGoType(t *testing.T) {
+	table := []struct {
+		CQL string
+		Go  string
+		Err bool
+	}{
+		{CQL: "text", Go: "string"},
+		{CQL: "bigint", Go: "int64"},
+		{CQL: "varint", Go: "*big.Int"},
+		{CQL: "timestamp", Go: "time.Time"},
+		{CQL: "list<int>", Go: "[]int32"},
+		{CQL: "frozen<set<timeuuid>>", Go: "[]gocql.UUID"},
+		{CQL: "map<text, frozen<list<blob>>>", Go: "map[string][][]byte"},
+		{CQL: "tuple<int, text>", Go: "struct {\nField1 int32\nField2 string\n}"},
+		{CQL: "frozen<address>", Go: "AddressUserType"},
+		{CQL: `frozen<"HomeAddress">`, Go: "HomeAddressUserType"},
+		{CQL: "org.apache.cassandra.db.marshal.CustomType", Err: true},
+		{CQL: "map<text>", Err: true},
+		{CQL: "list<int", Err: true},
+	}
+
+	m := newTypeMapper(&keyspaceSchema{
+		Types: []userType{{TypeName: "address"}, {TypeName: "HomeAddress"}},
+	}, Config{})
+	for _, test := range table {
+		v, err := m.goType(test.CQL)
+		if test.Err {
+			if err == nil {
+				t.Errorf("goType(%q) expected error", test.CQL)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("goType(%q) error: %s", test.CQL, err)
+			continue
+		}
+		if v != test.Go {
+			t.Errorf("goType(%q)=%q expected %q", test.CQL, v, test.Go)
+		}
+	}
+}
+
+func TestGoTypeOverride(t *testing.T) {
+	m := newTypeMapper(&keyspaceSchema{
+		Types: []userType{{TypeName: "address"}},
+	}, Config{
+		Types: map[string]string{
+			"inet":      "net.IP",
+			"timestamp": "int64",
+			"uuid":      "*github.com/google/uuid.UUID",
+			"address":   "github.com/acme/geo.Address",
+		},
+	})
+
+	table := []struct {
+		CQL string
+		Go  string
+	}{
+		{CQL: "inet", Go: "net.IP"},
+		{CQL: "list<timestamp>", Go: "[]int64"},
+		{CQL: "map<uuid, text>", Go: "map[*uuid.UUID]string"},
+		{CQL: "frozen<address>", Go: "geo.Address"},
+	}
+	for _, test := range table {
+		v, err := m.goType(test.CQL)
+		if err != nil {
+			t.Errorf("goType(%q) error: %s", test.CQL, err)
+			continue
+		}
+		if v != test.Go {
+			t.Errorf("goType(%q)=%q expected %q", test.CQL, v, test.Go)
+		}
+	}
+
+	imports := map[string]bool{
+		"net":                    true,
+		"github.com/google/uuid": true,
+		"github.com/acme/geo":    true,
+	}
+	if diff := cmp.Diff(imports, m.imports); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestColumnTypeOverride(t *testing.T) {
+	m := newTypeMapper(&keyspaceSchema{}, Config{
+		Columns: map[string]string{
+			"users.status":   "github.com/acme/models.Status",
+			"events.payload": "*github.com/acme/pb.Event",
+		},
+	})
+
+	table := []struct {
+		Table  string
+		Column string
+		CQL    string
+		Go     string
+	}{
+		{Table: "users", Column: "status", CQL: "text", Go: "models.Status"},
+		{Table: "users", Column: "name", CQL: "text", Go: "string"},
+		{Table: "orders", Column: "status", CQL: "int", Go: "int32"},
+		{Table: "events", Column: "payload", CQL: "blob", Go: "*pb.Event"},
+	}
+	for _, test := range table {
+		v, err := m.columnType(test.Table, test.Column, test.CQL)
+		if err != nil {
+			t.Errorf("columnType(%q, %q) error: %s", test.Table, test.Column, err)
+			continue
+		}
+		if v != test.Go {
+			t.Errorf("columnType(%q, %q)=%q expected %q", test.Table, test.Column, v, test.Go)
+		}
+	}
+
+	imports := map[string]bool{
+		"github.com/acme/models": true,
+		"github.com/acme/pb":     true,
+	}
+	if diff := cmp.Diff(imports, m.imports); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestCamelize(t *testing.T) {
+	table := []struct {
+		Name string
+		Go   string
+	}{
+		{Name: "id", Go: "ID"},
+		{Name: "user_id", Go: "UserID"},
+		{Name: "songs", Go: "Songs"},
+		{Name: "HomeAddress", Go: "HomeAddress"},
+		{Name: "release_date", Go: "ReleaseDate"},
+		{Name: "2fa_secret", Go: "X2faSecret"},
+	}
+
+	for _, test := range table {
+		if v := Camelize(test.Name); v != test.Go {
+			t.Errorf("Camelize(%q)=%q expected %q", test.Name, v, test.Go)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ScyllaDB cluster features, see Session.HasScyllaFeature.
+const (
+	FeaturePerPartitionRateLimit  = "PER_PARTITION_RATE_LIMIT"
+	FeatureWorkloadPrioritization = "WORKLOAD_PRIORITIZATION"
+)
+
+// ScyllaFeatures returns features enabled in the cluster the session is
+// connected to. It returns an error if the cluster is not ScyllaDB.
+func (s Session) ScyllaFeatures(ctx context.Context) ([]string, error) {
+	var v string
+	q := s.ContextQuery(ctx, "SELECT value FROM system.scylla_local WHERE key='enabled_features'", nil)
+	if err := q.GetRelease(&v); err != nil {
+		return nil, fmt.Errorf("failed to get enabled features: %s", err)
+	}
+	return parseFeatures(v), nil
+}
+
+// HasScyllaFeature checks if the feature is enabled in the cluster the session
+// is connected to. It returns false if the cluster is not ScyllaDB.
+func (s Session) HasScyllaFeature(ctx context.Context, feature string) bool {
+	features, err := s.ScyllaFeatures(ctx)
+	if err != nil {
+		return false
+	}
+	for _, f := range features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckUsingTimeout returns an error if the cluster the session is connected
+// to does not support the USING TIMEOUT clause, see qb.SelectBuilder.Timeout.
+// USING TIMEOUT is supported by ScyllaDB only, the cluster is detected with
+// ScyllaFeatures.
+func (s Session) CheckUsingTimeout(ctx context.Context) error {
+	if _, err := s.ScyllaFeatures(ctx); err != nil {
+		return fmt.Errorf("USING TIMEOUT is not supported: %s", err)
+	}
+	return nil
+}
+
+func parseFeatures(v string) []string {
+	var features []string
+	for _, f := range strings.Split(v, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			features = append(features, f)
+		}
+	}
+	return features
+}
@@ -0,0 +1,36 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseFeatures(t *testing.T) {
+	table := []struct {
+		V string
+		F []string
+	}{
+		{
+			V: "",
+		},
+		{
+			V: "LWT",
+			F: []string{"LWT"},
+		},
+		{
+			V: "LWT,PER_PARTITION_RATE_LIMIT, UDA,",
+			F: []string{"LWT", FeaturePerPartitionRateLimit, "UDA"},
+		},
+	}
+
+	for _, test := range table {
+		if diff := cmp.Diff(test.F, parseFeatures(test.V)); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
@@ -6,6 +6,7 @@ package gocqlx
 
 import (
 	"context"
+	"time"
 
 	"github.com/gocql/gocql"
 	"github.com/scylladb/go-reflectx"
@@ -17,9 +18,17 @@ import (
 // The default mapper uses `db` tag and automatically converts struct field
 // names to snake case. If needed package reflectx provides constructors
 // for other types of mappers.
+//
+// Location, if set, is applied to time.Time values of timestamp and date
+// columns. Scanned timestamps are converted to Location instead of UTC and
+// scanned dates are midnight in Location. Dates of bound time.Time values
+// are taken in Location, bound timestamps denote the same instant in any
+// location. It applies to top level values only, not to UDT fields or
+// collection elements.
 type Session struct {
 	*gocql.Session
-	Mapper *reflectx.Mapper
+	Mapper   *reflectx.Mapper
+	Location *time.Location
 }
 
 // WrapSession should be called on CreateSession() gocql function to convert
@@ -35,9 +44,10 @@ func WrapSession(session *gocql.Session, err error) (Session, error) {
 // a query, see the "Query" function .
 func (s Session) ContextQuery(ctx context.Context, stmt string, names []string) *Queryx {
 	return &Queryx{
-		Query:  s.Session.Query(stmt).WithContext(ctx),
-		Names:  names,
-		Mapper: s.Mapper,
+		Query:    s.Session.Query(stmt).WithContext(ctx),
+		Names:    names,
+		Mapper:   s.Mapper,
+		location: s.Location,
 	}
 }
 
@@ -48,9 +58,10 @@ func (s Session) ContextQuery(ctx context.Context, stmt string, names []string)
 // binding.
 func (s Session) Query(stmt string, names []string) *Queryx {
 	return &Queryx{
-		Query:  s.Session.Query(stmt),
-		Names:  names,
-		Mapper: s.Mapper,
+		Query:    s.Session.Query(stmt),
+		Names:    names,
+		Mapper:   s.Mapper,
+		location: s.Location,
 	}
 }
 
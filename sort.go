@@ -0,0 +1,159 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/scylladb/go-reflectx"
+)
+
+// sortKey is a column results are sorted by client side.
+type sortKey struct {
+	column string
+	desc   bool
+}
+
+// SortBy sorts results of Select client side by columns in ascending order,
+// it can be chained with SortByDesc i.e. SortBy("last_name").SortByDesc("age").
+// It's meant for orderings that can not be achieved with clustering keys.
+//
+// Columns are mapped to fields of the destination struct with mapper, they
+// can be of numeric, string, bool, time.Time or byte array types or pointers
+// to them, nil pointers come first. The sort is stable, rows equal in all the
+// columns keep the clustering order. Rows are sorted in place, no memory is
+// allocated apart from the destination slice, use MaxRows to bound it.
+func (q *Queryx) SortBy(columns ...string) *Queryx {
+	for _, c := range columns {
+		q.sortBy = append(q.sortBy, sortKey{column: c})
+	}
+	return q
+}
+
+// SortByDesc sorts results of Select client side by columns in descending
+// order, see SortBy.
+func (q *Queryx) SortByDesc(columns ...string) *Queryx {
+	for _, c := range columns {
+		q.sortBy = append(q.sortBy, sortKey{column: c, desc: true})
+	}
+	return q
+}
+
+// sortSlice stable sorts slice of structs or struct pointers pointed by dest
+// by keys.
+func sortSlice(dest interface{}, keys []sortKey, m *reflectx.Mapper) error {
+	slice := reflect.ValueOf(dest).Elem()
+	base := reflectx.Deref(slice.Type().Elem())
+	if base.Kind() != reflect.Struct {
+		return fmt.Errorf("sort: expected a slice of structs but got %s", slice.Type())
+	}
+
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = k.column
+	}
+	traversals := m.TraversalsByName(base, names)
+	for i, t := range traversals {
+		if len(t) == 0 {
+			return fmt.Errorf("sort: could not find name %q in %s", names[i], base)
+		}
+		if ft := reflectx.Deref(base.FieldByIndex(t).Type); !sortable(ft) {
+			return fmt.Errorf("sort: unsupported type %s of %q", ft, names[i])
+		}
+	}
+
+	field := func(i, k int) reflect.Value {
+		return reflect.Indirect(slice.Index(i)).FieldByIndex(traversals[k])
+	}
+	sort.SliceStable(slice.Interface(), func(i, j int) bool {
+		for k, key := range keys {
+			c := compare(field(i, k), field(j, k))
+			if c == 0 {
+				continue
+			}
+			if key.desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	return nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func sortable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.String, reflect.Bool:
+		return true
+	case reflect.Array:
+		return t.Elem().Kind() == reflect.Uint8
+	}
+	return t == timeType
+}
+
+// compare returns -1, 0 or 1 if a is less, equal or greater than b.
+func compare(a, b reflect.Value) int {
+	if a.Kind() == reflect.Ptr {
+		switch {
+		case a.IsNil() && b.IsNil():
+			return 0
+		case a.IsNil():
+			return -1
+		case b.IsNil():
+			return 1
+		}
+		a, b = a.Elem(), b.Elem()
+	}
+
+	if a.Type() == timeType {
+		ta, tb := a.Interface().(time.Time), b.Interface().(time.Time)
+		switch {
+		case ta.Before(tb):
+			return -1
+		case ta.After(tb):
+			return 1
+		}
+		return 0
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareOrdered(a.Int() < b.Int(), a.Int() > b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return compareOrdered(a.Uint() < b.Uint(), a.Uint() > b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return compareOrdered(a.Float() < b.Float(), a.Float() > b.Float())
+	case reflect.String:
+		return compareOrdered(a.String() < b.String(), a.String() > b.String())
+	case reflect.Bool:
+		return compareOrdered(!a.Bool() && b.Bool(), a.Bool() && !b.Bool())
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			x, y := a.Index(i).Uint(), b.Index(i).Uint()
+			if c := compareOrdered(x < y, x > y); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+func compareOrdered(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSortSlice(t *testing.T) {
+	type row struct {
+		ID       int
+		LastName string
+		Age      *int
+		Joined   time.Time
+		UUID     gocql.UUID
+	}
+
+	age := func(v int) *int { return &v }
+	day := func(d int) time.Time { return time.Date(2020, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	rows := func() []row {
+		return []row{
+			{ID: 1, LastName: "b", Age: age(30), Joined: day(3), UUID: gocql.UUID{3}},
+			{ID: 2, LastName: "a", Age: age(40), Joined: day(1), UUID: gocql.UUID{1}},
+			{ID: 3, LastName: "b", Age: nil, Joined: day(2), UUID: gocql.UUID{2}},
+			{ID: 4, LastName: "a", Age: age(40), Joined: day(4), UUID: gocql.UUID{4}},
+		}
+	}
+
+	table := []struct {
+		Name string
+		Keys []sortKey
+		IDs  []int
+	}{
+		{
+			Name: "single column",
+			Keys: []sortKey{{column: "joined"}},
+			IDs:  []int{2, 3, 1, 4},
+		},
+		{
+			Name: "stable",
+			Keys: []sortKey{{column: "last_name"}},
+			IDs:  []int{2, 4, 1, 3},
+		},
+		{
+			Name: "desc",
+			Keys: []sortKey{{column: "last_name", desc: true}, {column: "age", desc: true}},
+			IDs:  []int{1, 3, 2, 4},
+		},
+		{
+			Name: "nil first",
+			Keys: []sortKey{{column: "age"}, {column: "id", desc: true}},
+			IDs:  []int{3, 1, 4, 2},
+		},
+		{
+			Name: "byte array",
+			Keys: []sortKey{{column: "uuid", desc: true}},
+			IDs:  []int{4, 1, 3, 2},
+		},
+	}
+
+	for i := range table {
+		test := table[i]
+		t.Run(test.Name, func(t *testing.T) {
+			v := rows()
+			if err := sortSlice(&v, test.Keys, DefaultMapper); err != nil {
+				t.Fatal("sortSlice() error:", err)
+			}
+			var ids []int
+			for _, r := range v {
+				ids = append(ids, r.ID)
+			}
+			if diff := cmp.Diff(test.IDs, ids); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+
+	t.Run("pointers", func(t *testing.T) {
+		var v []*row
+		for _, r := range rows() {
+			r := r
+			v = append(v, &r)
+		}
+		if err := sortSlice(&v, []sortKey{{column: "id", desc: true}}, DefaultMapper); err != nil {
+			t.Fatal("sortSlice() error:", err)
+		}
+		if v[0].ID != 4 || v[3].ID != 1 {
+			t.Fatalf("sortSlice() unexpected order %d...%d", v[0].ID, v[3].ID)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		v := rows()
+		if err := sortSlice(&v, []sortKey{{column: "not_found"}}, DefaultMapper); err == nil {
+			t.Fatal("expected error")
+		}
+		s := []struct{ M map[string]int }{{}}
+		if err := sortSlice(&s, []sortKey{{column: "m"}}, DefaultMapper); err == nil {
+			t.Fatal("expected error")
+		}
+		ints := []int{2, 1}
+		if err := sortSlice(&ints, []sortKey{{column: "id"}}, DefaultMapper); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
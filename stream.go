@@ -0,0 +1,142 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/scylladb/go-reflectx"
+)
+
+// RowErrorPolicy specifies how Iterx handles rows that fail to scan into
+// a struct.
+type RowErrorPolicy int
+
+const (
+	// StopOnRowError stops the iteration on the first row that fails to scan,
+	// the error is returned by Close.
+	StopOnRowError RowErrorPolicy = iota
+	// CollectRowErrors skips rows that fail to scan, errors of skipped rows
+	// are returned by Close as RowErrors.
+	CollectRowErrors
+	// DeliverRowErrors makes ForEach and SelectChan deliver errors of rows
+	// that fail to scan as *RowError and continue with the next row. Get and
+	// Select handle row errors as with CollectRowErrors.
+	DeliverRowErrors
+)
+
+// ForEach scans rows one by one into dest and calls fn after every row until
+// fn returns false or there are no more rows, and closes the iterator. The
+// dest value is overwritten by every row, copy it if it's needed after fn
+// returns.
+//
+// With the DeliverRowErrors policy fn is called with a *RowError for every
+// row that fails to scan, the content of dest is undefined then. Otherwise
+// err is always nil and row errors are handled according to the policy.
+// Errors of scannable types i.e. a single string column stop the iteration
+// regardless of the policy.
+func (iter *Iterx) ForEach(dest interface{}, fn func(err error) bool) error {
+	iter.each(dest, false, func(_ int, _ reflect.Value, err error) bool {
+		return fn(err)
+	})
+	return iter.Close()
+}
+
+// RowResult is a row or an error delivered by SelectChan.
+type RowResult struct {
+	// Row is the index of the row in the result, starting from zero. For
+	// an error that ends the stream it's the number of rows read.
+	Row int
+	// Value is a pointer to a new value of the SelectChan dest type, it's
+	// nil if Err is set.
+	Value interface{}
+	// Err is a *RowError for rows that fail to scan with the
+	// DeliverRowErrors policy, or the error that ended the stream.
+	Err error
+}
+
+// SelectChan scans rows in a new goroutine and sends them to the returned
+// channel. Every row is scanned into a new value of the type pointed by dest,
+// dest itself is not modified. The iterator is closed when there are no more
+// rows or ctx is done, if closing returns an error it's sent as the last
+// result. The channel is closed after that.
+//
+// The iterator must not be used by the caller after calling SelectChan.
+func (iter *Iterx) SelectChan(ctx context.Context, dest interface{}) <-chan RowResult {
+	ch := make(chan RowResult)
+
+	go func() {
+		defer close(ch)
+
+		send := func(r RowResult) bool {
+			select {
+			case ch <- r:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		iter.each(dest, true, func(row int, v reflect.Value, err error) bool {
+			if err != nil {
+				return send(RowResult{Row: row, Err: err})
+			}
+			return send(RowResult{Row: row, Value: v.Interface()})
+		})
+		if err := iter.Close(); err != nil {
+			send(RowResult{Row: iter.row, Err: err})
+		}
+	}()
+
+	return ch
+}
+
+// each scans rows into dest, or into new values of dest type if alloc is
+// set, and calls fn with every row until fn returns false or there are no
+// more rows. Row errors are passed to fn with the DeliverRowErrors policy.
+func (iter *Iterx) each(dest interface{}, alloc bool, fn func(row int, v reflect.Value, err error) bool) {
+	value := reflect.ValueOf(dest)
+
+	if value.Kind() != reflect.Ptr {
+		iter.err = fmt.Errorf("expected a pointer but got %T", dest)
+		return
+	}
+	if value.IsNil() {
+		iter.err = errors.New("expected a pointer but got nil")
+		return
+	}
+
+	scannable, err := iter.checkScannable(reflectx.Deref(value.Type()))
+	if err != nil {
+		iter.err = err
+		return
+	}
+
+	if iter.rowErrorPolicy == DeliverRowErrors {
+		iter.deliver = func(err *RowError) bool {
+			return fn(err.Row, reflect.Value{}, err)
+		}
+		defer func() { iter.deliver = nil }()
+	}
+
+	for {
+		if alloc {
+			value = reflect.New(value.Type().Elem())
+		}
+
+		var ok bool
+		if scannable {
+			ok = iter.scan(value)
+		} else {
+			ok = iter.structScan(value)
+		}
+		if !ok || !fn(iter.row-1, value, nil) {
+			return
+		}
+	}
+}
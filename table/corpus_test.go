@@ -0,0 +1,340 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package table
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/gocqlx/v2"
+)
+
+// Examples in testdata/examples document behaviour of Table, every file holds
+// a table Metadata, CQL types of the columns and steps executed in order
+// against a fake backend. A step names a Table statement, the expected CQL
+// and parameter names, values to bind by name and for reads the expected
+// rows. Bound values and returned rows are passed through gocqlx.RoundTrip,
+// so they are bound and scanned as by Queryx and Iterx. To add a regression
+// test add a file, see testdata/examples/crud.json for the format.
+
+type corpusExample struct {
+	Metadata Metadata          `json:"metadata"`
+	Types    map[string]string `json:"types"`
+	Steps    []corpusStep      `json:"steps"`
+}
+
+type corpusStep struct {
+	// Statement is get, select, insert, update, delete or delete_partition.
+	Statement string                   `json:"statement"`
+	Columns   []string                 `json:"columns"`
+	CQL       string                   `json:"cql"`
+	Names     []string                 `json:"names"`
+	Bind      map[string]interface{}   `json:"bind"`
+	Rows      []map[string]interface{} `json:"rows"`
+}
+
+func TestExampleCorpus(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "examples", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no examples found")
+	}
+
+	for _, f := range files {
+		f := f
+		t.Run(strings.TrimSuffix(filepath.Base(f), ".json"), func(t *testing.T) {
+			b, err := ioutil.ReadFile(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var e corpusExample
+			d := json.NewDecoder(bytes.NewReader(b))
+			d.DisallowUnknownFields()
+			if err := d.Decode(&e); err != nil {
+				t.Fatal("decode:", err)
+			}
+			runExample(t, e)
+		})
+	}
+}
+
+func runExample(t *testing.T, e corpusExample) {
+	t.Helper()
+
+	tbl := New(e.Metadata)
+	backend := newFakeBackend(e.Metadata)
+
+	for i, s := range e.Steps {
+		var stmt string
+		var names []string
+		switch s.Statement {
+		case "get":
+			stmt, names = tbl.Get(s.Columns...)
+		case "select":
+			stmt, names = tbl.Select(s.Columns...)
+		case "insert":
+			stmt, names = tbl.Insert()
+		case "update":
+			stmt, names = tbl.Update(s.Columns...)
+		case "delete":
+			stmt, names = tbl.Delete(s.Columns...)
+		case "delete_partition":
+			stmt, names = tbl.DeletePartition()
+		default:
+			t.Fatalf("step %d: unknown statement %q", i, s.Statement)
+		}
+
+		if diff := cmp.Diff(s.CQL, stmt); diff != "" {
+			t.Fatalf("step %d: CQL mismatch %s", i, diff)
+		}
+		if diff := cmp.Diff(s.Names, names); diff != "" {
+			t.Fatalf("step %d: names mismatch %s", i, diff)
+		}
+
+		values := make(map[string]interface{}, len(names))
+		for _, n := range names {
+			v, ok := s.Bind[n]
+			if !ok {
+				t.Fatalf("step %d: could not find name %q in bind", i, n)
+			}
+			values[n] = v
+		}
+		values, err := roundTripRow(e.Types, values)
+		if err != nil {
+			t.Fatalf("step %d: bind: %s", i, err)
+		}
+
+		rows := backend.exec(s.Statement, s.Columns, values)
+		if s.Statement == "get" || s.Statement == "select" {
+			for j := range rows {
+				if rows[j], err = roundTripRow(e.Types, rows[j]); err != nil {
+					t.Fatalf("step %d: scan: %s", i, err)
+				}
+			}
+			expected := make([]map[string]interface{}, len(s.Rows))
+			for j := range s.Rows {
+				if expected[j], err = goRow(e.Types, s.Rows[j]); err != nil {
+					t.Fatalf("step %d: rows: %s", i, err)
+				}
+			}
+			if len(expected) == 0 {
+				expected = nil
+			}
+			if diff := cmp.Diff(expected, rows); diff != "" {
+				t.Fatalf("step %d: rows mismatch %s", i, diff)
+			}
+		}
+	}
+}
+
+// corpusTypes maps CQL types of example columns to Go types.
+var corpusTypes = map[string]struct {
+	typ    gocql.Type
+	goType reflect.Type
+}{
+	"ascii":   {gocql.TypeAscii, reflect.TypeOf("")},
+	"text":    {gocql.TypeText, reflect.TypeOf("")},
+	"int":     {gocql.TypeInt, reflect.TypeOf(int32(0))},
+	"bigint":  {gocql.TypeBigInt, reflect.TypeOf(int64(0))},
+	"double":  {gocql.TypeDouble, reflect.TypeOf(float64(0))},
+	"boolean": {gocql.TypeBoolean, reflect.TypeOf(false)},
+}
+
+// goRow converts JSON values of row to Go types of the columns.
+func goRow(types map[string]string, row map[string]interface{}) (map[string]interface{}, error) {
+	r := make(map[string]interface{}, len(row))
+	for c, v := range row {
+		t, ok := corpusTypes[types[c]]
+		if !ok {
+			return nil, fmt.Errorf("unsupported type %q of column %s", types[c], c)
+		}
+		rv := reflect.ValueOf(v)
+		if !rv.Type().ConvertibleTo(t.goType) {
+			return nil, fmt.Errorf("can not convert %v to %s of column %s", v, t.goType, c)
+		}
+		r[c] = rv.Convert(t.goType).Interface()
+	}
+	return r, nil
+}
+
+// roundTripRow binds row to a struct with a field for every column of row,
+// and returns values scanned by gocqlx.RoundTrip.
+func roundTripRow(types map[string]string, row map[string]interface{}) (map[string]interface{}, error) {
+	row, err := goRow(types, row)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, 0, len(row))
+	for c := range row {
+		columns = append(columns, c)
+	}
+	sort.Strings(columns)
+
+	fields := make([]reflect.StructField, len(columns))
+	info := make([]gocql.ColumnInfo, len(columns))
+	for i, c := range columns {
+		t := corpusTypes[types[c]]
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprint("F", i),
+			Type: t.goType,
+			Tag:  reflect.StructTag(fmt.Sprintf(`db:"%s"`, c)),
+		}
+		info[i] = gocql.ColumnInfo{Name: c, TypeInfo: gocql.NewNativeType(4, t.typ, "")}
+	}
+	v := reflect.New(reflect.StructOf(fields)).Elem()
+	for i, c := range columns {
+		v.Field(i).Set(reflect.ValueOf(row[c]))
+	}
+
+	got, err := gocqlx.RoundTrip(nil, info, v.Interface())
+	if err != nil {
+		return nil, err
+	}
+	gv := reflect.ValueOf(got)
+	r := make(map[string]interface{}, len(columns))
+	for i, c := range columns {
+		r[c] = gv.Field(i).Interface()
+	}
+	return r, nil
+}
+
+// fakeBackend stores rows of a single table in memory and executes Table
+// statements by kind, it mimics CQL semantics of upserts and deletes.
+type fakeBackend struct {
+	m    Metadata
+	rows map[string]map[string]interface{}
+}
+
+func newFakeBackend(m Metadata) *fakeBackend {
+	return &fakeBackend{
+		m:    m,
+		rows: make(map[string]map[string]interface{}),
+	}
+}
+
+func (b *fakeBackend) exec(statement string, columns []string, values map[string]interface{}) []map[string]interface{} {
+	switch statement {
+	case "get":
+		if r, ok := b.rows[b.key(values, b.primaryKey())]; ok {
+			return []map[string]interface{}{project(r, columns)}
+		}
+		return nil
+	case "select":
+		var rows []map[string]interface{}
+		for _, r := range b.sorted() {
+			if b.key(r, b.m.PartKey) == b.key(values, b.m.PartKey) {
+				rows = append(rows, project(r, columns))
+			}
+		}
+		return rows
+	case "insert", "update":
+		k := b.key(values, b.primaryKey())
+		r, ok := b.rows[k]
+		if !ok {
+			r = make(map[string]interface{})
+			b.rows[k] = r
+		}
+		for c, v := range values {
+			r[c] = v
+		}
+	case "delete":
+		k := b.key(values, b.primaryKey())
+		if len(columns) == 0 {
+			delete(b.rows, k)
+		} else if r, ok := b.rows[k]; ok {
+			for _, c := range columns {
+				delete(r, c)
+			}
+		}
+	case "delete_partition":
+		for k, r := range b.rows {
+			if b.key(r, b.m.PartKey) == b.key(values, b.m.PartKey) {
+				delete(b.rows, k)
+			}
+		}
+	}
+	return nil
+}
+
+func (b *fakeBackend) primaryKey() []string {
+	return append(append([]string(nil), b.m.PartKey...), b.m.SortKey...)
+}
+
+func (b *fakeBackend) key(values map[string]interface{}, columns []string) string {
+	var k strings.Builder
+	for _, c := range columns {
+		fmt.Fprintf(&k, "%v\x00", values[c])
+	}
+	return k.String()
+}
+
+// sorted returns rows ordered by primary key.
+func (b *fakeBackend) sorted() []map[string]interface{} {
+	rows := make([]map[string]interface{}, 0, len(b.rows))
+	for _, r := range b.rows {
+		rows = append(rows, r)
+	}
+	pk := b.primaryKey()
+	sort.Slice(rows, func(i, j int) bool {
+		for _, c := range pk {
+			if less, equal := lessValue(rows[i][c], rows[j][c]); !equal {
+				return less
+			}
+		}
+		return false
+	})
+	return rows
+}
+
+func lessValue(a, b interface{}) (less, equal bool) {
+	x, y := reflect.ValueOf(a), reflect.ValueOf(b)
+	if x.IsValid() && y.IsValid() && x.Kind() == y.Kind() {
+		switch x.Kind() {
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return x.Int() < y.Int(), x.Int() == y.Int()
+		case reflect.Float32, reflect.Float64:
+			return x.Float() < y.Float(), x.Float() == y.Float()
+		}
+	}
+	s, t := fmt.Sprint(a), fmt.Sprint(b)
+	return s < t, s == t
+}
+
+// project returns a copy of r with only columns, or all columns if columns
+// is empty.
+func project(r map[string]interface{}, columns []string) map[string]interface{} {
+	p := make(map[string]interface{}, len(r))
+	for c, v := range r {
+		p[c] = v
+	}
+	if len(columns) == 0 {
+		return p
+	}
+	for c := range p {
+		found := false
+		for _, v := range columns {
+			if c == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			delete(p, c)
+		}
+	}
+	return p
+}
@@ -3,5 +3,6 @@
 // license that can be found in the LICENSE file.
 
 // Package table adds support for super simple CRUD operations based on table
-// model.
+// model. Table produces ready to bind statements, or Queryx instances for
+// a session, from table Metadata.
 package table
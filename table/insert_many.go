@@ -0,0 +1,113 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package table
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/gocql/gocql"
+	"github.com/scylladb/gocqlx/v2"
+)
+
+// InsertManyOptions specifies how InsertMany executes the inserts.
+type InsertManyOptions struct {
+	// BatchSize, if greater than zero, groups rows into unlogged batches of
+	// at most BatchSize rows, otherwise every row is inserted with a single
+	// insert. Batches shall be used only if rows share a partition key.
+	BatchSize int
+	// Concurrency is the number of inserts or batches executed in parallel,
+	// if it's not set inserts are executed one by one.
+	Concurrency int
+}
+
+// InsertMany inserts all columns of every element of rows, which must be
+// a slice of structs, struct pointers or map[string]interface{}. If some
+// rows fail gocqlx.ExecErrors is returned, it holds errors by the index of
+// the row. If a batch fails the error is reported for every row of the
+// batch. If ctx is done no new queries are started and ctx error is returned,
+// if rows inserted before failed *gocqlx.ExecCanceledError is returned.
+func (t *Table) InsertMany(ctx context.Context, session gocqlx.Session, rows interface{}, opts InsertManyOptions) error {
+	bindings, err := gocqlx.SliceBindings(rows)
+	if err != nil {
+		return err
+	}
+
+	stmt, names := t.Insert()
+	if opts.BatchSize <= 0 {
+		return session.ExecMany(ctx, stmt, names, bindings, opts.Concurrency)
+	}
+	v := reflect.ValueOf(rows)
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs gocqlx.ExecErrors
+		sem  = make(chan struct{}, concurrency)
+	)
+	exec := func(start, end int) {
+		defer func() {
+			<-sem
+			wg.Done()
+		}()
+
+		b := session.NewBatch(gocql.UnloggedBatch)
+		b.WithContext(ctx)
+		for i := start; i < end; i++ {
+			arg := v.Index(i).Interface()
+			if m, ok := arg.(map[string]interface{}); ok {
+				b.BindMap(stmt, names, m)
+			} else {
+				b.BindStruct(stmt, names, arg)
+			}
+		}
+		if err := b.Exec(); err != nil {
+			mu.Lock()
+			for i := start; i < end; i++ {
+				errs = append(errs, gocqlx.ExecError{Index: i, Err: err})
+			}
+			mu.Unlock()
+		}
+	}
+
+	var ctxErr error
+	for start := 0; start < v.Len(); start += opts.BatchSize {
+		end := start + opts.BatchSize
+		if end > v.Len() {
+			end = v.Len()
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctxErr = ctx.Err(); ctxErr != nil {
+			break
+		}
+		wg.Add(1)
+		go exec(start, end)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Index < errs[j].Index })
+	}
+	switch {
+	case ctxErr != nil && len(errs) > 0:
+		return &gocqlx.ExecCanceledError{Err: ctxErr, Errors: errs}
+	case ctxErr != nil:
+		return ctxErr
+	case len(errs) > 0:
+		return errs
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+// +build all integration
+
+package table_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/scylladb/gocqlx/v2"
+	. "github.com/scylladb/gocqlx/v2/gocqlxtest"
+	"github.com/scylladb/gocqlx/v2/table"
+)
+
+func TestTableInsertMany(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.insert_many (pk int, ck int, name text, PRIMARY KEY (pk, ck))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	tbl := table.New(table.Metadata{
+		Name:    "gocqlx_test.insert_many",
+		Columns: []string{"pk", "ck", "name"},
+		PartKey: []string{"pk"},
+		SortKey: []string{"ck"},
+	})
+
+	type row struct {
+		Pk   int
+		Ck   int
+		Name string
+	}
+
+	tests := []struct {
+		Name string
+		Pk   int
+		Opts table.InsertManyOptions
+	}{
+		{
+			Name: "single inserts",
+			Pk:   1,
+			Opts: table.InsertManyOptions{Concurrency: 4},
+		},
+		{
+			Name: "batches",
+			Pk:   2,
+			Opts: table.InsertManyOptions{BatchSize: 3, Concurrency: 2},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.Name, func(t *testing.T) {
+			var rows []row
+			for i := 0; i < 10; i++ {
+				rows = append(rows, row{Pk: test.Pk, Ck: i, Name: "name"})
+			}
+			if err := tbl.InsertMany(context.Background(), session, rows, test.Opts); err != nil {
+				t.Fatal("InsertMany() error:", err)
+			}
+
+			var got []row
+			if err := tbl.SelectQuery(session).BindStruct(row{Pk: test.Pk}).SelectRelease(&got); err != nil {
+				t.Fatal("select:", err)
+			}
+			if len(got) != len(rows) {
+				t.Fatalf("got %d rows, expected %d", len(got), len(rows))
+			}
+		})
+	}
+
+	t.Run("row errors", func(t *testing.T) {
+		rows := []interface{}{
+			row{Pk: 3, Ck: 0},
+			struct{ Pk int }{Pk: 3},
+			map[string]interface{}{"pk": 3, "ck": 2, "name": "map"},
+		}
+		err := tbl.InsertMany(context.Background(), session, rows, table.InsertManyOptions{})
+
+		var errs gocqlx.ExecErrors
+		if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Index != 1 {
+			t.Fatalf("InsertMany() error %v, expected error of row 1", err)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package table
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+
+	"github.com/scylladb/go-reflectx"
+	"github.com/scylladb/gocqlx/v2"
+)
+
+// MaskFunc returns a masked representation of a column value.
+type MaskFunc func(column string, v interface{}) interface{}
+
+// Redacted is the value returned by Redact.
+const Redacted = "[REDACTED]"
+
+// Redact is a MaskFunc that replaces values with the Redacted marker.
+func Redact(column string, v interface{}) interface{} {
+	return Redacted
+}
+
+// HMAC returns a MaskFunc that replaces values with hex encoded HMAC-SHA256
+// of their default text representation keyed with key. Equal values have
+// equal hashes so masked data can still be correlated, without the key
+// values cannot be recovered by hashing guesses. The key must be kept
+// secret.
+func HMAC(key []byte) MaskFunc {
+	return func(column string, v interface{}) interface{} {
+		h := hmac.New(sha256.New, key)
+		fmt.Fprint(h, v)
+		return hex.EncodeToString(h.Sum(nil))
+	}
+}
+
+// PIIMask returns a MaskFunc applying fn to values of PII columns, values of
+// other columns are returned unchanged. If fn is nil Redact is used. Use it
+// to mask rows encoded by gocqlx.Iterx.Reader i.e.
+// iter.Mask(m.PIIMask(HMAC(key))).Reader(gocqlx.JSONLines).
+func (m Metadata) PIIMask(fn MaskFunc) MaskFunc { // nolint: gocritic
+	if fn == nil {
+		fn = Redact
+	}
+	return func(column string, v interface{}) interface{} {
+		if m.IsPII(column) {
+			return fn(column, v)
+		}
+		return v
+	}
+}
+
+// Mask returns a copy of row where values of PII columns are replaced using
+// fn, see PIIMask. Row can be obtained from a struct with gocqlx.StructToMap,
+// the result is meant for exports and logs.
+func (m Metadata) Mask(row map[string]interface{}, fn MaskFunc) map[string]interface{} { // nolint: gocritic
+	mask := m.PIIMask(fn)
+
+	r := make(map[string]interface{}, len(row))
+	for k, v := range row {
+		r[k] = mask(k, v)
+	}
+	return r
+}
+
+// FromStruct sets PII and Descriptions from fields of the struct v, see
+// gocqlx.StructFields. Fields are mapped to columns using mapper, if mapper
+// is nil gocqlx.DefaultMapper is used.
+func (m *Metadata) FromStruct(mapper *reflectx.Mapper, v interface{}) error {
+	fields, err := gocqlx.StructFields(mapper, reflect.TypeOf(v))
+	if err != nil {
+		return err
+	}
+
+	m.PII = nil
+	m.Descriptions = nil
+	for _, f := range fields {
+		if f.PII {
+			m.PII = append(m.PII, f.Column)
+		}
+		if f.Description != "" {
+			if m.Descriptions == nil {
+				m.Descriptions = make(map[string]string)
+			}
+			m.Descriptions[f.Column] = f.Description
+		}
+	}
+	return nil
+}
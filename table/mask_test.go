@@ -0,0 +1,97 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package table
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/gocqlx/v2"
+)
+
+func TestMetadataMask(t *testing.T) {
+	m := Metadata{
+		Name:    "person",
+		Columns: []string{"id", "email", "phone"},
+		PartKey: []string{"id"},
+		PII:     []string{"email", "phone"},
+	}
+	row := map[string]interface{}{
+		"id":    1,
+		"email": "michal@scylladb.com",
+		"phone": nil,
+	}
+
+	t.Run("redact", func(t *testing.T) {
+		golden := map[string]interface{}{
+			"id":    1,
+			"email": Redacted,
+			"phone": Redacted,
+		}
+		if diff := cmp.Diff(golden, m.Mask(row, nil)); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("hmac", func(t *testing.T) {
+		masked := m.Mask(row, HMAC([]byte("key")))
+		if masked["id"] != 1 {
+			t.Fatal("id should not be masked")
+		}
+		if masked["email"] == row["email"] {
+			t.Fatal("email should be masked")
+		}
+		if masked["email"] != m.Mask(row, HMAC([]byte("key")))["email"] {
+			t.Fatal("hash should be stable")
+		}
+		if masked["email"] == m.Mask(row, HMAC([]byte("other")))["email"] {
+			t.Fatal("hash should depend on key")
+		}
+	})
+
+	if row["email"] != "michal@scylladb.com" {
+		t.Fatal("row modified")
+	}
+}
+
+func TestMetadataFromStruct(t *testing.T) {
+	type person struct {
+		ID    int
+		Email string `db:"email,pii" doc:"primary contact email"`
+		Phone string `db:"phone,pii"`
+	}
+
+	m := Metadata{
+		Name:    "person",
+		Columns: []string{"id", "email", "phone"},
+		PartKey: []string{"id"},
+	}
+	if err := m.FromStruct(nil, &person{}); err != nil {
+		t.Fatal("FromStruct() error:", err)
+	}
+	if diff := cmp.Diff([]string{"email", "phone"}, m.PII); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff(map[string]string{"email": "primary contact email"}, m.Descriptions); diff != "" {
+		t.Fatal(diff)
+	}
+
+	row, err := gocqlx.StructToMap(nil, person{ID: 1, Email: "michal@scylladb.com", Phone: "123"})
+	if err != nil {
+		t.Fatal("StructToMap() error:", err)
+	}
+	golden := map[string]interface{}{
+		"id":    1,
+		"email": Redacted,
+		"phone": Redacted,
+	}
+	if diff := cmp.Diff(golden, m.Mask(row, nil)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if err := m.FromStruct(nil, 1); err == nil {
+		t.Fatal("FromStruct() expected error")
+	}
+}
@@ -0,0 +1,340 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+// +build all integration
+
+package table_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/gocqlx/v2"
+	. "github.com/scylladb/gocqlx/v2/gocqlxtest"
+	"github.com/scylladb/gocqlx/v2/table"
+)
+
+func TestTableQueries(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.table_queries (id int, seq int, name text, PRIMARY KEY (id, seq))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	tbl := table.New(table.Metadata{
+		Name:    "gocqlx_test.table_queries",
+		Columns: []string{"id", "seq", "name"},
+		PartKey: []string{"id"},
+		SortKey: []string{"seq"},
+	})
+
+	type row struct {
+		ID   int
+		Seq  int
+		Name string
+	}
+
+	rows := []row{{1, 1, "a"}, {1, 2, "b"}}
+	for _, r := range rows {
+		if err := tbl.InsertQuery(session).BindStruct(r).ExecRelease(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	var sel []row
+	if err := tbl.SelectQuery(session).BindStruct(row{ID: 1}).SelectRelease(&sel); err != nil {
+		t.Fatal("select:", err)
+	}
+	if diff := cmp.Diff(rows, sel); diff != "" {
+		t.Fatal(diff)
+	}
+
+	u := row{ID: 1, Seq: 2, Name: "c"}
+	if err := tbl.UpdateQuery(session, "name").BindStruct(u).ExecRelease(); err != nil {
+		t.Fatal("update:", err)
+	}
+	var got row
+	if err := tbl.GetQuery(session).BindStruct(u).GetRelease(&got); err != nil {
+		t.Fatal("get:", err)
+	}
+	if diff := cmp.Diff(u, got); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if err := tbl.DeleteQuery(session).BindStruct(u).ExecRelease(); err != nil {
+		t.Fatal("delete:", err)
+	}
+	if err := tbl.GetQuery(session).BindStruct(u).GetRelease(&got); err != gocql.ErrNotFound {
+		t.Fatalf("get error %v, expected %v", err, gocql.ErrNotFound)
+	}
+}
+
+func TestTableUpdateNonZero(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.update_non_zero (id int PRIMARY KEY, name text, age int)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	tbl := table.New(table.Metadata{
+		Name:    "gocqlx_test.update_non_zero",
+		Columns: []string{"id", "name", "age"},
+		PartKey: []string{"id"},
+	})
+
+	type row struct {
+		ID   int
+		Name string
+		Age  int
+	}
+	if err := tbl.InsertQuery(session).BindStruct(row{ID: 1, Name: "name", Age: 30}).ExecRelease(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	q, err := tbl.UpdateNonZero(session, row{ID: 1, Age: 31})
+	if err != nil {
+		t.Fatal("UpdateNonZero() error:", err)
+	}
+	if err := q.ExecRelease(); err != nil {
+		t.Fatal("update:", err)
+	}
+
+	var got row
+	if err := tbl.GetQuery(session).BindStruct(row{ID: 1}).GetRelease(&got); err != nil {
+		t.Fatal("get:", err)
+	}
+	if diff := cmp.Diff(row{ID: 1, Name: "name", Age: 31}, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestTableQueryContext(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.query_context (id int PRIMARY KEY, name text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	tbl := table.New(table.Metadata{
+		Name:    "gocqlx_test.query_context",
+		Columns: []string{"id", "name"},
+		PartKey: []string{"id"},
+	})
+
+	type row struct {
+		ID   int
+		Name string
+	}
+	v := row{ID: 1, Name: "name"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		Name  string
+		Query func() (*gocqlx.Queryx, error)
+	}{
+		{
+			Name:  "get",
+			Query: func() (*gocqlx.Queryx, error) { return tbl.GetQueryContext(ctx, session), nil },
+		},
+		{
+			Name:  "select",
+			Query: func() (*gocqlx.Queryx, error) { return tbl.SelectQueryContext(ctx, session), nil },
+		},
+		{
+			Name:  "insert",
+			Query: func() (*gocqlx.Queryx, error) { return tbl.InsertQueryContext(ctx, session), nil },
+		},
+		{
+			Name:  "update",
+			Query: func() (*gocqlx.Queryx, error) { return tbl.UpdateQueryContext(ctx, session, "name"), nil },
+		},
+		{
+			Name:  "update non zero",
+			Query: func() (*gocqlx.Queryx, error) { return tbl.UpdateNonZeroContext(ctx, session, v) },
+		},
+		{
+			Name:  "delete",
+			Query: func() (*gocqlx.Queryx, error) { return tbl.DeleteQueryContext(ctx, session), nil },
+		},
+		{
+			Name:  "delete partition",
+			Query: func() (*gocqlx.Queryx, error) { return tbl.DeletePartitionQueryContext(ctx, session), nil },
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.Name, func(t *testing.T) {
+			q, err := test.Query()
+			if err != nil {
+				t.Fatal("query error:", err)
+			}
+			if err := q.BindStruct(v).ExecRelease(); err != context.Canceled {
+				t.Fatalf("ExecRelease() error %v, expected %v", err, context.Canceled)
+			}
+		})
+	}
+}
+
+func TestTableUpdateVersioned(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.update_versioned (id int PRIMARY KEY, name text, version bigint)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	tbl := table.New(table.Metadata{
+		Name:    "gocqlx_test.update_versioned",
+		Columns: []string{"id", "name", "version"},
+		PartKey: []string{"id"},
+		Version: "version",
+	})
+
+	type row struct {
+		ID      int
+		Name    string
+		Version int64
+	}
+	if err := tbl.InsertQuery(session).BindStruct(row{ID: 1, Name: "a", Version: 1}).ExecRelease(); err != nil {
+		t.Fatal("insert:", err)
+	}
+
+	r := row{ID: 1, Name: "b", Version: 1}
+	if err := tbl.UpdateVersioned(session, &r, "name"); err != nil {
+		t.Fatal("UpdateVersioned() error:", err)
+	}
+	if r.Version != 2 {
+		t.Fatalf("Version=%d expected 2", r.Version)
+	}
+
+	stale := row{ID: 1, Name: "c", Version: 1}
+	if err := tbl.UpdateVersioned(session, &stale, "name"); err != table.ErrStaleVersion {
+		t.Fatalf("UpdateVersioned() error=%v expected %v", err, table.ErrStaleVersion)
+	}
+
+	var got row
+	if err := tbl.GetQuery(session).BindStruct(row{ID: 1}).GetRelease(&got); err != nil {
+		t.Fatal("get:", err)
+	}
+	if diff := cmp.Diff(row{ID: 1, Name: "b", Version: 2}, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestTableTokenRangeQuery(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.token_range (id int PRIMARY KEY)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	tbl := table.New(table.Metadata{
+		Name:    "gocqlx_test.token_range",
+		Columns: []string{"id"},
+		PartKey: []string{"id"},
+	})
+
+	const n = 100
+	for i := 0; i < n; i++ {
+		if err := tbl.InsertQuery(session).Bind(i).ExecRelease(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	seen := make(map[int]bool)
+	for _, r := range table.SplitTokenRing(7) {
+		var ids []int
+		if err := tbl.TokenRangeQuery(session, r, "id").SelectRelease(&ids); err != nil {
+			t.Fatal("select:", err)
+		}
+		for _, id := range ids {
+			if seen[id] {
+				t.Fatalf("id %d in many ranges", id)
+			}
+			seen[id] = true
+		}
+	}
+	if len(seen) != n {
+		t.Fatalf("got %d rows expected %d", len(seen), n)
+	}
+}
+
+func TestFromCluster(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.from_cluster (id int, seq int, owner text STATIC, name text, PRIMARY KEY (id, seq))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	m, err := table.FromCluster(session, "gocqlx_test", "from_cluster")
+	if err != nil {
+		t.Fatal("FromCluster() error:", err)
+	}
+	expected := table.Metadata{
+		Name:    "gocqlx_test.from_cluster",
+		Columns: []string{"id", "seq", "name", "owner"},
+		PartKey: []string{"id"},
+		SortKey: []string{"seq"},
+		Static:  []string{"owner"},
+		Types: map[string]string{
+			"id":    "int",
+			"seq":   "int",
+			"name":  "text",
+			"owner": "text",
+		},
+	}
+	if diff := cmp.Diff(expected, m); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if _, err := table.FromCluster(session, "gocqlx_test", "not_found"); err != table.ErrTableNotFound {
+		t.Fatalf("FromCluster() error=%v expected %v", err, table.ErrTableNotFound)
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	m := table.Metadata{
+		Name:    "gocqlx_test.reconcile",
+		Columns: []string{"id", "name"},
+		PartKey: []string{"id"},
+		Types:   map[string]string{"id": "int", "name": "text"},
+	}
+	ctx := context.Background()
+
+	if _, err := table.Reconcile(ctx, session, m, table.ReconcileOptions{Apply: true}); err != nil {
+		t.Fatal("Reconcile() create error:", err)
+	}
+
+	m.Columns = append(m.Columns, "age")
+	m.Types["age"] = "int"
+	m.Indexes = []string{"age"}
+	stmts, err := table.Reconcile(ctx, session, m, table.ReconcileOptions{Apply: true})
+	if err != nil {
+		t.Fatal("Reconcile() alter error:", err)
+	}
+	if len(stmts) != 2 {
+		t.Fatalf("Reconcile()=%v expected add column and create index", stmts)
+	}
+
+	stmts, err = table.Reconcile(ctx, session, m, table.ReconcileOptions{})
+	if err != nil {
+		t.Fatal("Reconcile() error:", err)
+	}
+	if len(stmts) != 0 {
+		t.Fatalf("Reconcile()=%v expected no changes", stmts)
+	}
+}
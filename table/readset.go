@@ -0,0 +1,159 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package table
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gocql/gocql"
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+)
+
+// ErrReadSetChanged is returned by ReadSet.Commit if any of the read rows was
+// changed after it was read.
+var ErrReadSetChanged = errors.New("read set changed")
+
+// ReadSet emulates snapshot isolation for a set of rows, it packages an
+// optimistic multi-row transaction. Rows of versioned tables are read with
+// Get which records the version of every row, the caller computes the
+// changes and Commit executes them in a conditional batch together with
+// a version increment of every read row. If the version of any of the rows
+// changed the batch is not applied.
+//
+// Conditional batches are limited to a single partition, all the rows read
+// and written shall belong to the same partition. The statements of the
+// batch shall not write the version column, see Metadata.Version.
+type ReadSet struct {
+	session gocqlx.Session
+	reads   []readSetEntry
+}
+
+type readSetEntry struct {
+	table *Table
+	// key holds primary key values of the row.
+	key map[string]interface{}
+	// version is nil if the row was not found.
+	version interface{}
+	next    interface{}
+}
+
+// NewReadSet returns an empty ReadSet.
+func NewReadSet(session gocqlx.Session) *ReadSet {
+	return &ReadSet{
+		session: session,
+	}
+}
+
+// Get reads a row of versioned table t by primary key taken from arg into
+// dest and records the version of the row read into dest. If the row does
+// not exist ErrNotFound is returned and the absence of the row is recorded,
+// Commit fails if the row is created and otherwise creates the row with the
+// first version.
+func (rs *ReadSet) Get(t *Table, arg, dest interface{}) error {
+	if t.metadata.Version == "" {
+		return errors.New("table is not versioned")
+	}
+
+	key, err := t.primaryKey(rs.session, arg)
+	if err != nil {
+		return err
+	}
+
+	stmt, names := t.Get()
+	found := true
+	if err := rs.session.Query(stmt, names).BindStruct(arg).GetRelease(dest); err != nil {
+		if err != gocql.ErrNotFound {
+			return err
+		}
+		found = false
+	}
+
+	m, err := gocqlx.StructToMap(rs.session.Mapper, dest)
+	if err != nil {
+		return err
+	}
+	e := readSetEntry{
+		table: t,
+		key:   key,
+	}
+	if found {
+		e.version = m[t.metadata.Version]
+	}
+	if e.next, err = nextVersion(m[t.metadata.Version]); err != nil {
+		return err
+	}
+	rs.reads = append(rs.reads, e)
+
+	if !found {
+		return gocql.ErrNotFound
+	}
+	return nil
+}
+
+// Commit adds a conditional version increment of every read row to the
+// batch and executes it. If any row changed ErrReadSetChanged is returned
+// and the batch is not applied.
+func (rs *ReadSet) Commit(b *gocqlx.Batchx) error {
+	for _, e := range rs.reads {
+		stmt, names := e.guard()
+		b.BindMap(stmt, names, e.bindMap())
+	}
+
+	applied, err := b.ExecCAS()
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return ErrReadSetChanged
+	}
+	return nil
+}
+
+// guard returns statement setting the next version of the row if the
+// version did not change.
+func (e readSetEntry) guard() (stmt string, names []string) {
+	t := e.table
+	if e.version == nil {
+		v := t.metadata.Version
+		return qb.Update(t.metadata.Name).
+			SetNamed(v, nextVersionName(v)).
+			Where(t.primaryKeyCmp...).
+			If(qb.EqLit(v, "null")).
+			ToCql()
+	}
+	return t.versioned(qb.Update(t.metadata.Name).Where(t.primaryKeyCmp...)).ToCql()
+}
+
+func (e readSetEntry) bindMap() map[string]interface{} {
+	v := e.table.metadata.Version
+	m := make(map[string]interface{}, len(e.key)+2)
+	for k, val := range e.key {
+		m[k] = val
+	}
+	m[v] = e.version
+	m[nextVersionName(v)] = e.next
+	return m
+}
+
+// primaryKey returns primary key values of struct arg.
+func (t *Table) primaryKey(session gocqlx.Session, arg interface{}) (map[string]interface{}, error) {
+	m, err := gocqlx.StructToMap(session.Mapper, arg)
+	if err != nil {
+		return nil, err
+	}
+	key := make(map[string]interface{}, len(t.metadata.PartKey)+len(t.metadata.SortKey))
+	for _, keys := range [][]string{t.metadata.PartKey, t.metadata.SortKey} {
+		for _, k := range keys {
+			val, ok := m[k]
+			if !ok {
+				return nil, fmt.Errorf("missing primary key column %q", k)
+			}
+			key[k] = val
+		}
+	}
+	return key, nil
+}
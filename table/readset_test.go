@@ -0,0 +1,105 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+// +build all integration
+
+package table_test
+
+import (
+	"testing"
+
+	"github.com/gocql/gocql"
+	. "github.com/scylladb/gocqlx/v2/gocqlxtest"
+	"github.com/scylladb/gocqlx/v2/qb"
+	"github.com/scylladb/gocqlx/v2/table"
+)
+
+func TestReadSet(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.read_set (bank int, id int, balance int, version int, PRIMARY KEY (bank, id))`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	accounts := table.New(table.Metadata{
+		Name:    "gocqlx_test.read_set",
+		Columns: []string{"bank", "id", "balance", "version"},
+		PartKey: []string{"bank"},
+		SortKey: []string{"id"},
+		Version: "version",
+	})
+
+	type account struct {
+		Bank    int
+		ID      int
+		Balance int
+		Version int
+	}
+	for _, a := range []account{{ID: 1, Balance: 100}, {ID: 2, Balance: 0}} {
+		if err := session.Query(accounts.Insert()).BindStruct(a).ExecRelease(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	transfer := func(rs *table.ReadSet, from, to *account) {
+		if err := rs.Get(accounts, from, from); err != nil {
+			t.Fatal("Get() error:", err)
+		}
+		if err := rs.Get(accounts, to, to); err != nil {
+			t.Fatal("Get() error:", err)
+		}
+		from.Balance -= 10
+		to.Balance += 10
+	}
+
+	stmt, names := qb.Update(accounts.Name()).Set("balance").Where(accounts.PrimaryKeyCmp()...).ToCql()
+
+	t.Run("commit", func(t *testing.T) {
+		from, to := &account{ID: 1}, &account{ID: 2}
+		rs := table.NewReadSet(session)
+		transfer(rs, from, to)
+
+		b := session.NewBatch(gocql.LoggedBatch).BindStruct(stmt, names, from).BindStruct(stmt, names, to)
+		if err := rs.Commit(b); err != nil {
+			t.Fatal("Commit() error:", err)
+		}
+
+		var got account
+		if err := session.Query(accounts.Get()).BindStruct(from).GetRelease(&got); err != nil {
+			t.Fatal("get:", err)
+		}
+		if got.Balance != 90 || got.Version != 1 {
+			t.Fatalf("got %+v, expected balance 90 and version 1", got)
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		from, to := &account{ID: 1}, &account{ID: 2}
+		rs := table.NewReadSet(session)
+		transfer(rs, from, to)
+
+		if err := accounts.UpdateVersioned(session, &account{ID: 2, Balance: 1000, Version: to.Version}, "balance"); err != nil {
+			t.Fatal("UpdateVersioned() error:", err)
+		}
+
+		b := session.NewBatch(gocql.LoggedBatch).BindStruct(stmt, names, from).BindStruct(stmt, names, to)
+		if err := rs.Commit(b); err != table.ErrReadSetChanged {
+			t.Fatalf("Commit() error %v, expected %v", err, table.ErrReadSetChanged)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		rs := table.NewReadSet(session)
+		if err := rs.Get(accounts, &account{ID: 3}, &account{}); err != gocql.ErrNotFound {
+			t.Fatalf("Get() error %v, expected %v", err, gocql.ErrNotFound)
+		}
+		if err := session.Query(accounts.Insert()).BindStruct(&account{ID: 3}).ExecRelease(); err != nil {
+			t.Fatal("insert:", err)
+		}
+		if err := rs.Commit(session.NewBatch(gocql.LoggedBatch)); err != table.ErrReadSetChanged {
+			t.Fatalf("Commit() error %v, expected %v", err, table.ErrReadSetChanged)
+		}
+	})
+}
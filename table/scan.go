@@ -0,0 +1,163 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/scylladb/gocqlx/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultScanRanges is the default number of token ranges of Scan.
+const DefaultScanRanges = 256
+
+// ScanOptions specifies how Scan scans a table.
+type ScanOptions struct {
+	// Columns to select, by default all columns are selected.
+	Columns []string
+	// Ranges is the number of token ranges the token ring is split into,
+	// if 0 DefaultScanRanges is used.
+	Ranges int
+	// Concurrency is the number of ranges scanned in parallel, if 0 ranges
+	// are scanned one by one.
+	Concurrency int
+	// Done lists ranges that are already scanned, they are skipped. To
+	// resume a scan collect ranges reported by Progress and pass them along
+	// with the same number of Ranges.
+	Done []TokenRange
+	// Progress is called when scanning of a range is done, it's called
+	// from one goroutine at a time.
+	Progress func(p ScanProgress)
+}
+
+// ScanProgress reports a scanned token range.
+type ScanProgress struct {
+	Range TokenRange
+	// Rows is the number of rows in the range.
+	Rows int
+	// Done is the number of scanned ranges including skipped ranges.
+	Done int
+	// Total is the number of ranges.
+	Total int
+}
+
+// Scan scans the whole table, it splits the token ring into ranges and
+// scans them in parallel. Every row is scanned into a new value of the type
+// pointed by dest with StructScan, and passed to fn. Functions fn can be
+// called concurrently, if fn returns an error the scan is stopped and
+// the error is returned.
+func (t *Table) Scan(ctx context.Context, session gocqlx.Session, dest interface{}, fn func(row interface{}) error, opts ScanOptions) error { // nolint: gocritic
+	typ := reflect.TypeOf(dest)
+	if typ == nil || typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return errors.New("expected a pointer to a struct")
+	}
+	typ = typ.Elem()
+
+	if opts.Ranges < 1 {
+		opts.Ranges = DefaultScanRanges
+	}
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+
+	all := SplitTokenRing(opts.Ranges)
+	done := make(map[TokenRange]bool, len(opts.Done))
+	for _, r := range opts.Done {
+		done[r] = true
+	}
+	var ranges []TokenRange
+	for _, r := range all {
+		if !done[r] {
+			ranges = append(ranges, r)
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		progress = len(all) - len(ranges)
+	)
+	report := func(r TokenRange, rows int) {
+		mu.Lock()
+		defer mu.Unlock()
+		progress++
+		if opts.Progress != nil {
+			opts.Progress(ScanProgress{Range: r, Rows: rows, Done: progress, Total: len(all)})
+		}
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	ch := make(chan TokenRange)
+	g.Go(func() error {
+		defer close(ch)
+		for _, r := range ranges {
+			select {
+			case ch <- r:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+		}
+		return nil
+	})
+	for i := 0; i < opts.Concurrency; i++ {
+		g.Go(func() error {
+			for r := range ch {
+				rows, err := t.scanRange(gctx, session, r, typ, fn, opts.Columns)
+				if err != nil {
+					return fmt.Errorf("scan range (%d, %d]: %s", r.Start, r.End, err)
+				}
+				report(r, rows)
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+func (t *Table) scanRange(ctx context.Context, session gocqlx.Session, r TokenRange, typ reflect.Type, fn func(row interface{}) error, columns []string) (int, error) {
+	q := t.TokenRangeQueryContext(ctx, session, r, columns...)
+	defer q.Release()
+
+	iter := q.Iter()
+	rows := 0
+	for {
+		v := reflect.New(typ).Interface()
+		if !iter.StructScan(v) {
+			break
+		}
+		rows++
+		if err := fn(v); err != nil {
+			iter.Close()
+			return rows, err
+		}
+	}
+	return rows, iter.Close()
+}
+
+// ScanChan is like Scan but it sends rows to the returned channel. The rows
+// channel is closed when the scan is done, then the scan error, if any, is
+// sent to the error channel. The caller must consume all rows or cancel ctx.
+func (t *Table) ScanChan(ctx context.Context, session gocqlx.Session, dest interface{}, opts ScanOptions) (<-chan interface{}, <-chan error) { // nolint: gocritic
+	rows := make(chan interface{})
+	errc := make(chan error, 1)
+	go func() {
+		err := t.Scan(ctx, session, dest, func(row interface{}) error {
+			select {
+			case rows <- row:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}, opts)
+		close(rows)
+		errc <- err
+		close(errc)
+	}()
+	return rows, errc
+}
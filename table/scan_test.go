@@ -0,0 +1,108 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+// +build all integration
+
+package table_test
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	. "github.com/scylladb/gocqlx/v2/gocqlxtest"
+	"github.com/scylladb/gocqlx/v2/table"
+)
+
+func TestTableScan(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.table_scan (id int PRIMARY KEY, name text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+
+	tbl := table.New(table.Metadata{
+		Name:    "gocqlx_test.table_scan",
+		Columns: []string{"id", "name"},
+		PartKey: []string{"id"},
+	})
+
+	type row struct {
+		ID   int
+		Name string
+	}
+	const n = 100
+	for i := 0; i < n; i++ {
+		if err := tbl.InsertQuery(session).BindStruct(row{ID: i, Name: "name"}).ExecRelease(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	t.Run("callback", func(t *testing.T) {
+		var (
+			mu   sync.Mutex
+			ids  []int
+			done []table.TokenRange
+		)
+		opts := table.ScanOptions{
+			Ranges:      16,
+			Concurrency: 4,
+			Progress: func(p table.ScanProgress) {
+				done = append(done, p.Range)
+				if p.Total != 16 {
+					t.Errorf("Total=%d expected 16", p.Total)
+				}
+			},
+		}
+		err := tbl.Scan(context.Background(), session, &row{}, func(v interface{}) error {
+			mu.Lock()
+			ids = append(ids, v.(*row).ID)
+			mu.Unlock()
+			return nil
+		}, opts)
+		if err != nil {
+			t.Fatal("Scan() error:", err)
+		}
+
+		sort.Ints(ids)
+		expected := make([]int, n)
+		for i := range expected {
+			expected[i] = i
+		}
+		if diff := cmp.Diff(expected, ids); diff != "" {
+			t.Fatal(diff)
+		}
+		if len(done) != 16 {
+			t.Fatalf("got progress of %d ranges expected 16", len(done))
+		}
+
+		// resume with all ranges done
+		opts.Done = done
+		opts.Progress = nil
+		err = tbl.Scan(context.Background(), session, &row{}, func(v interface{}) error {
+			t.Error("unexpected row", v)
+			return nil
+		}, opts)
+		if err != nil {
+			t.Fatal("Scan() error:", err)
+		}
+	})
+
+	t.Run("chan", func(t *testing.T) {
+		rows, errc := tbl.ScanChan(context.Background(), session, &row{}, table.ScanOptions{Concurrency: 2})
+		count := 0
+		for range rows {
+			count++
+		}
+		if err := <-errc; err != nil {
+			t.Fatal("ScanChan() error:", err)
+		}
+		if count != n {
+			t.Fatalf("got %d rows expected %d", count, n)
+		}
+	})
+}
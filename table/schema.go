@@ -0,0 +1,130 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+)
+
+// ErrTableNotFound is returned by FromCluster if the table does not exist.
+var ErrTableNotFound = errors.New("table not found")
+
+// schemaColumn is a row of system_schema.columns.
+type schemaColumn struct {
+	ColumnName string
+	Kind       string
+	Position   int
+	Type       string
+}
+
+// FromCluster returns Metadata of table name in keyspace read from
+// system_schema of the cluster. Metadata name is keyspace.name, columns are
+// ordered as in SELECT * i.e. partition key, clustering key and the other
+// columns by name. Indexes lists columns with secondary indexes on the whole
+// column value. If the table does not exist ErrTableNotFound is returned.
+func FromCluster(session gocqlx.Session, keyspace, name string) (Metadata, error) {
+	return FromClusterContext(context.Background(), session, keyspace, name)
+}
+
+// FromClusterContext is FromCluster with context.
+func FromClusterContext(ctx context.Context, session gocqlx.Session, keyspace, name string) (Metadata, error) {
+	stmt, names := qb.Select("system_schema.columns").
+		Columns("column_name", "kind", "position", "type").
+		Where(qb.Eq("keyspace_name"), qb.Eq("table_name")).
+		ToCql()
+
+	var columns []schemaColumn
+	err := session.ContextQuery(ctx, stmt, names).
+		BindMap(qb.M{"keyspace_name": keyspace, "table_name": name}).
+		SelectRelease(&columns)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("read schema of %s.%s: %s", keyspace, name, err)
+	}
+	if len(columns) == 0 {
+		return Metadata{}, ErrTableNotFound
+	}
+	m := metadataFromSchema(keyspace+"."+name, columns)
+
+	stmt, names = qb.Select("system_schema.indexes").
+		Columns("options").
+		Where(qb.Eq("keyspace_name"), qb.Eq("table_name")).
+		ToCql()
+	var options []map[string]string
+	err = session.ContextQuery(ctx, stmt, names).
+		BindMap(qb.M{"keyspace_name": keyspace, "table_name": name}).
+		SelectRelease(&options)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("read indexes of %s.%s: %s", keyspace, name, err)
+	}
+	for _, o := range options {
+		if c, ok := indexTarget(o["target"]); ok {
+			m.Indexes = append(m.Indexes, c)
+		}
+	}
+	sort.Strings(m.Indexes)
+
+	return m, nil
+}
+
+// indexTarget returns the column of index target if the whole column value
+// is indexed, targets such as keys(column) are not supported.
+func indexTarget(target string) (string, bool) {
+	if len(target) >= 2 && target[0] == '"' && target[len(target)-1] == '"' {
+		return strings.Replace(target[1:len(target)-1], `""`, `"`, -1), true
+	}
+	if target == "" || strings.ContainsAny(target, `()"`) {
+		return "", false
+	}
+	return target, true
+}
+
+func metadataFromSchema(name string, columns []schemaColumn) Metadata {
+	sort.Slice(columns, func(i, j int) bool {
+		a, b := columns[i], columns[j]
+		if kindOrder(a.Kind) != kindOrder(b.Kind) {
+			return kindOrder(a.Kind) < kindOrder(b.Kind)
+		}
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.ColumnName < b.ColumnName
+	})
+
+	m := Metadata{
+		Name:  name,
+		Types: make(map[string]string, len(columns)),
+	}
+	for _, c := range columns {
+		m.Columns = append(m.Columns, c.ColumnName)
+		m.Types[c.ColumnName] = c.Type
+		switch c.Kind {
+		case "partition_key":
+			m.PartKey = append(m.PartKey, c.ColumnName)
+		case "clustering":
+			m.SortKey = append(m.SortKey, c.ColumnName)
+		case "static":
+			m.Static = append(m.Static, c.ColumnName)
+		}
+	}
+	return m
+}
+
+func kindOrder(kind string) int {
+	switch kind {
+	case "partition_key":
+		return 0
+	case "clustering":
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+)
+
+// SchemaDiff returns statements that change table live to table desired.
+// Columns and indexes missing in live are added, Types of desired must hold
+// types of the added columns. Columns missing in desired are dropped only if
+// drop is true, indexes are never dropped. Changes of primary key, column
+// types and static columns can not be done with ALTER TABLE, an error is
+// returned for them.
+func SchemaDiff(desired, live Metadata, drop bool) ([]string, error) { // nolint: gocritic
+	if !equalColumns(desired.PartKey, live.PartKey) || !equalColumns(desired.SortKey, live.SortKey) {
+		return nil, fmt.Errorf("table %s: primary key can not be changed", desired.Name)
+	}
+
+	alter := qb.AlterTable(desired.Name)
+	add := false
+	for _, c := range desired.Columns {
+		if !contains(live.Columns, c) {
+			typ, ok := desired.Types[c]
+			if !ok {
+				return nil, fmt.Errorf("table %s: missing type of column %q", desired.Name, c)
+			}
+			if desired.IsStatic(c) {
+				alter.AddStatic(c, typ)
+			} else {
+				alter.Add(c, typ)
+			}
+			add = true
+			continue
+		}
+
+		dt, lt := desired.Types[c], live.Types[c]
+		if dt != "" && lt != "" && normalizeType(dt) != normalizeType(lt) {
+			return nil, fmt.Errorf("table %s: type of column %q can not be changed from %s to %s", desired.Name, c, lt, dt)
+		}
+		if desired.IsStatic(c) != live.IsStatic(c) {
+			return nil, fmt.Errorf("table %s: column %q can not be changed to or from static", desired.Name, c)
+		}
+	}
+
+	var stmts []string
+	if add {
+		stmt, _ := alter.ToCql()
+		stmts = append(stmts, stmt)
+	}
+
+	if drop {
+		var columns []string
+		for _, c := range live.Columns {
+			if !contains(desired.Columns, c) {
+				columns = append(columns, c)
+			}
+		}
+		if len(columns) > 0 {
+			stmt, _ := qb.AlterTable(desired.Name).Drop(columns...).ToCql()
+			stmts = append(stmts, stmt)
+		}
+	}
+
+	for _, c := range desired.Indexes {
+		if !contains(live.Indexes, c) {
+			stmt, _ := qb.CreateIndex("").IfNotExists().On(desired.Name, c).ToCql()
+			stmts = append(stmts, stmt)
+		}
+	}
+
+	return stmts, nil
+}
+
+// CreateStmts returns statements that create table m with its indexes,
+// Types of m must hold types of all columns.
+func CreateStmts(m Metadata) ([]string, error) { // nolint: gocritic
+	b := qb.CreateTable(m.Name).IfNotExists().PartitionKey(m.PartKey...).ClusteringKey(m.SortKey...)
+	for _, c := range m.Columns {
+		typ, ok := m.Types[c]
+		if !ok {
+			return nil, fmt.Errorf("table %s: missing type of column %q", m.Name, c)
+		}
+		if m.IsStatic(c) {
+			b.StaticColumn(c, typ)
+		} else {
+			b.Column(c, typ)
+		}
+	}
+
+	stmt, _ := b.ToCql()
+	stmts := []string{stmt}
+	for _, c := range m.Indexes {
+		stmt, _ := qb.CreateIndex("").IfNotExists().On(m.Name, c).ToCql()
+		stmts = append(stmts, stmt)
+	}
+	return stmts, nil
+}
+
+// ReconcileOptions specifies how Reconcile changes the schema.
+type ReconcileOptions struct {
+	// Drop enables dropping columns missing in the desired metadata.
+	Drop bool
+	// Apply enables executing the statements, otherwise they are only
+	// returned.
+	Apply bool
+}
+
+// Reconcile compares desired metadata, with keyspace.table name, with the
+// schema of the cluster and returns statements that change the schema to
+// match it, see SchemaDiff. If the table does not exist the statements
+// create it, see CreateStmts. With opts.Apply the statements are executed
+// in order, on error the executed statements are returned.
+func Reconcile(ctx context.Context, session gocqlx.Session, desired Metadata, opts ReconcileOptions) ([]string, error) { // nolint: gocritic
+	dot := strings.IndexByte(desired.Name, '.')
+	if dot < 0 {
+		return nil, fmt.Errorf("table %q: expected keyspace.table name", desired.Name)
+	}
+
+	var stmts []string
+	live, err := FromClusterContext(ctx, session, desired.Name[:dot], desired.Name[dot+1:])
+	switch {
+	case err == ErrTableNotFound:
+		stmts, err = CreateStmts(desired)
+	case err == nil:
+		stmts, err = SchemaDiff(desired, live, opts.Drop)
+	}
+	if err != nil || !opts.Apply {
+		return stmts, err
+	}
+
+	for i, stmt := range stmts {
+		if err := session.ContextQuery(ctx, stmt, nil).ExecRelease(); err != nil {
+			return stmts[:i], fmt.Errorf("exec %q: %s", stmt, err)
+		}
+	}
+	return stmts, nil
+}
+
+// normalizeType returns CQL type in a canonical form for comparison.
+func normalizeType(typ string) string {
+	typ = strings.ToLower(strings.Replace(typ, " ", "", -1))
+	if typ == "varchar" {
+		return "text"
+	}
+	return typ
+}
+
+func equalColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(columns []string, column string) bool {
+	for _, c := range columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
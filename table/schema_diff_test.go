@@ -0,0 +1,160 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package table
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSchemaDiff(t *testing.T) {
+	live := Metadata{
+		Name:    "ks.t",
+		Columns: []string{"id", "seq", "name", "old"},
+		PartKey: []string{"id"},
+		SortKey: []string{"seq"},
+		Types:   map[string]string{"id": "int", "seq": "int", "name": "text", "old": "int"},
+		Indexes: []string{"name"},
+	}
+
+	table := []struct {
+		Name    string
+		Desired Metadata
+		Drop    bool
+		S       []string
+		Err     string
+	}{
+		{
+			Name:    "equal",
+			Desired: live,
+		},
+		{
+			Name: "add columns and index",
+			Desired: Metadata{
+				Name:    "ks.t",
+				Columns: []string{"id", "seq", "name", "old", "age", "owner"},
+				PartKey: []string{"id"},
+				SortKey: []string{"seq"},
+				Static:  []string{"owner"},
+				Types:   map[string]string{"name": "varchar", "age": "int", "owner": "text"},
+				Indexes: []string{"name", "age"},
+			},
+			S: []string{
+				"ALTER TABLE ks.t ADD (age int,owner text STATIC) ",
+				"CREATE INDEX IF NOT EXISTS ON ks.t (age) ",
+			},
+		},
+		{
+			Name: "drop",
+			Desired: Metadata{
+				Name:    "ks.t",
+				Columns: []string{"id", "seq"},
+				PartKey: []string{"id"},
+				SortKey: []string{"seq"},
+			},
+			Drop: true,
+			S:    []string{"ALTER TABLE ks.t DROP (name,old) "},
+		},
+		{
+			Name: "no drop",
+			Desired: Metadata{
+				Name:    "ks.t",
+				Columns: []string{"id", "seq"},
+				PartKey: []string{"id"},
+				SortKey: []string{"seq"},
+			},
+		},
+		{
+			Name: "primary key",
+			Desired: Metadata{
+				Name:    "ks.t",
+				Columns: []string{"id", "seq"},
+				PartKey: []string{"id", "seq"},
+			},
+			Err: "table ks.t: primary key can not be changed",
+		},
+		{
+			Name: "type",
+			Desired: Metadata{
+				Name:    "ks.t",
+				Columns: []string{"id", "seq", "name"},
+				PartKey: []string{"id"},
+				SortKey: []string{"seq"},
+				Types:   map[string]string{"name": "int"},
+			},
+			Err: `table ks.t: type of column "name" can not be changed from text to int`,
+		},
+		{
+			Name: "missing type",
+			Desired: Metadata{
+				Name:    "ks.t",
+				Columns: []string{"id", "seq", "age"},
+				PartKey: []string{"id"},
+				SortKey: []string{"seq"},
+			},
+			Err: `table ks.t: missing type of column "age"`,
+		},
+	}
+
+	for _, test := range table {
+		stmts, err := SchemaDiff(test.Desired, live, test.Drop)
+		if test.Err != "" {
+			if err == nil || err.Error() != test.Err {
+				t.Errorf("%s: SchemaDiff() error=%v expected %s", test.Name, err, test.Err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: SchemaDiff() error=%s", test.Name, err)
+			continue
+		}
+		if diff := cmp.Diff(test.S, stmts); diff != "" {
+			t.Error(test.Name, diff)
+		}
+	}
+}
+
+func TestCreateStmts(t *testing.T) {
+	stmts, err := CreateStmts(Metadata{
+		Name:    "ks.t",
+		Columns: []string{"id", "seq", "owner", "name"},
+		PartKey: []string{"id"},
+		SortKey: []string{"seq"},
+		Static:  []string{"owner"},
+		Types:   map[string]string{"id": "int", "seq": "int", "owner": "text", "name": "text"},
+		Indexes: []string{"name"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"CREATE TABLE IF NOT EXISTS ks.t (id int,seq int,owner text STATIC,name text,PRIMARY KEY (id,seq)) ",
+		"CREATE INDEX IF NOT EXISTS ON ks.t (name) ",
+	}
+	if diff := cmp.Diff(expected, stmts); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestIndexTarget(t *testing.T) {
+	table := []struct {
+		T  string
+		C  string
+		OK bool
+	}{
+		{T: "name", C: "name", OK: true},
+		{T: `"Name"`, C: "Name", OK: true},
+		{T: "keys(m)", OK: false},
+		{T: "", OK: false},
+	}
+
+	for _, test := range table {
+		c, ok := indexTarget(test.T)
+		if c != test.C || ok != test.OK {
+			t.Errorf("indexTarget(%q)=%q, %v expected %q, %v", test.T, c, ok, test.C, test.OK)
+		}
+	}
+}
@@ -4,7 +4,12 @@
 
 package table
 
-import "github.com/scylladb/gocqlx/v2/qb"
+import (
+	"context"
+
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+)
 
 // Metadata represents table schema.
 type Metadata struct {
@@ -12,6 +17,44 @@ type Metadata struct {
 	Columns []string
 	PartKey []string
 	SortKey []string
+	// Static lists static columns, values of static columns are shared by
+	// all rows of a partition.
+	Static []string
+	// Version is name of an integer version column, if set the table is
+	// versioned. Updates of a versioned table set the version to the
+	// next_<version> parameter and are conditional on the version
+	// parameter, see UpdateVersioned.
+	Version string
+	// Types maps column names to CQL types, it's optional.
+	Types map[string]string
+	// Indexes lists columns with secondary indexes, it's optional.
+	Indexes []string
+
+	// PII lists columns holding personally identifiable information.
+	PII []string
+	// Descriptions maps column names to column documentation.
+	Descriptions map[string]string
+}
+
+// IsPII returns true if column is listed as holding personally identifiable
+// information.
+func (m Metadata) IsPII(column string) bool { // nolint: gocritic
+	for _, c := range m.PII {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStatic returns true if column is listed as static.
+func (m Metadata) IsStatic(column string) bool { // nolint: gocritic
+	for _, c := range m.Static {
+		if c == column {
+			return true
+		}
+	}
+	return false
 }
 
 type cql struct {
@@ -29,6 +72,9 @@ type Table struct {
 	get    cql
 	sel    cql
 	insert cql
+
+	insertRow    cql
+	insertStatic cql
 }
 
 // New creates new Table based on table schema read from Metadata.
@@ -55,6 +101,19 @@ func New(m Metadata) *Table { // nolint: gocritic
 	// prepare insert stmt
 	t.insert.stmt, t.insert.names = qb.Insert(m.Name).Columns(m.Columns...).ToCql()
 
+	// prepare insert row and insert static stmts
+	var row, static []string
+	for _, c := range m.Columns {
+		if m.IsStatic(c) {
+			static = append(static, c)
+		} else {
+			row = append(row, c)
+		}
+	}
+	t.insertRow.stmt, t.insertRow.names = qb.Insert(m.Name).Columns(row...).ToCql()
+	static = append(append([]string(nil), m.PartKey...), static...)
+	t.insertStatic.stmt, t.insertStatic.names = qb.Insert(m.Name).Columns(static...).ToCql()
+
 	return t
 }
 
@@ -70,6 +129,14 @@ func (t *Table) PrimaryKeyCmp() []qb.Cmp {
 	return primaryKeyCmp
 }
 
+// Validator returns qb.Validator for statements on the table.
+func (t *Table) Validator() qb.Validator {
+	return qb.Validator{
+		PartKey: t.metadata.PartKey,
+		SortKey: t.metadata.SortKey,
+	}
+}
+
 // Name returns table name.
 func (t *Table) Name() string {
 	return t.metadata.Name
@@ -110,16 +177,57 @@ func (t *Table) Insert() (stmt string, names []string) {
 	return t.insert.stmt, t.insert.names
 }
 
-// Update returns update by primary key statement.
+// InsertRow returns insert all columns but static columns statement. Use it
+// to insert rows without overwriting values shared by the partition.
+func (t *Table) InsertRow() (stmt string, names []string) {
+	return t.insertRow.stmt, t.insertRow.names
+}
+
+// InsertStatic returns insert partition key and static columns statement.
+func (t *Table) InsertStatic() (stmt string, names []string) {
+	return t.insertStatic.stmt, t.insertStatic.names
+}
+
+// Update returns update by primary key statement. If all columns are static
+// it's update by partition key statement. If the table is versioned it's
+// conditional on the row version.
 func (t *Table) Update(columns ...string) (stmt string, names []string) {
 	return t.UpdateBuilder(columns...).ToCql()
 }
 
-// UpdateBuilder returns a builder initialised to update by primary key statement.
+// UpdateBuilder returns a builder initialised to update by primary key
+// statement. If all columns are static it's initialised to update by
+// partition key statement. If the table is versioned it's initialised to
+// update the version conditionally, static only updates are versioned only
+// if the version column is static.
 func (t *Table) UpdateBuilder(columns ...string) *qb.UpdateBuilder {
+	if t.staticOnly(columns) {
+		b := qb.Update(t.metadata.Name).Set(columns...).Where(t.partKeyCmp...)
+		if t.metadata.Version != "" && t.metadata.IsStatic(t.metadata.Version) {
+			b = t.versioned(b)
+		}
+		return b
+	}
+	if t.metadata.Version != "" {
+		return t.versioned(qb.Update(t.metadata.Name).Set(t.withoutVersion(columns)...).Where(t.primaryKeyCmp...))
+	}
 	return qb.Update(t.metadata.Name).Set(columns...).Where(t.primaryKeyCmp...)
 }
 
+// staticOnly returns true if columns is not empty and all columns are
+// static.
+func (t *Table) staticOnly(columns []string) bool {
+	if len(columns) == 0 {
+		return false
+	}
+	for _, c := range columns {
+		if !t.metadata.IsStatic(c) {
+			return false
+		}
+	}
+	return true
+}
+
 // Delete returns delete by primary key statement.
 func (t *Table) Delete(columns ...string) (stmt string, names []string) {
 	return t.DeleteBuilder(columns...).ToCql()
@@ -129,3 +237,80 @@ func (t *Table) Delete(columns ...string) (stmt string, names []string) {
 func (t *Table) DeleteBuilder(columns ...string) *qb.DeleteBuilder {
 	return qb.Delete(t.metadata.Name).Columns(columns...).Where(t.primaryKeyCmp...)
 }
+
+// DeletePartition returns delete by partition key statement, it deletes
+// the whole partition.
+func (t *Table) DeletePartition() (stmt string, names []string) {
+	return t.DeletePartitionBuilder().ToCql()
+}
+
+// DeletePartitionBuilder returns a builder initialised to delete by
+// partition key statement.
+func (t *Table) DeletePartitionBuilder() *qb.DeleteBuilder {
+	return qb.Delete(t.metadata.Name).Where(t.partKeyCmp...)
+}
+
+// GetQuery returns query which gets by primary key.
+func (t *Table) GetQuery(session gocqlx.Session, columns ...string) *gocqlx.Queryx {
+	return session.Query(t.Get(columns...))
+}
+
+// GetQueryContext returns query wrapped with context which gets by primary key.
+func (t *Table) GetQueryContext(ctx context.Context, session gocqlx.Session, columns ...string) *gocqlx.Queryx {
+	return t.GetQuery(session, columns...).WithContext(ctx)
+}
+
+// SelectQuery returns query which selects by partition key statement.
+func (t *Table) SelectQuery(session gocqlx.Session, columns ...string) *gocqlx.Queryx {
+	return session.Query(t.Select(columns...))
+}
+
+// SelectQueryContext returns query wrapped with context which selects by
+// partition key statement.
+func (t *Table) SelectQueryContext(ctx context.Context, session gocqlx.Session, columns ...string) *gocqlx.Queryx {
+	return t.SelectQuery(session, columns...).WithContext(ctx)
+}
+
+// InsertQuery returns query which inserts all columns.
+func (t *Table) InsertQuery(session gocqlx.Session) *gocqlx.Queryx {
+	return session.Query(t.Insert())
+}
+
+// InsertQueryContext returns query wrapped with context which inserts all
+// columns.
+func (t *Table) InsertQueryContext(ctx context.Context, session gocqlx.Session) *gocqlx.Queryx {
+	return t.InsertQuery(session).WithContext(ctx)
+}
+
+// UpdateQuery returns query which updates by primary key.
+func (t *Table) UpdateQuery(session gocqlx.Session, columns ...string) *gocqlx.Queryx {
+	return session.Query(t.Update(columns...))
+}
+
+// UpdateQueryContext returns query wrapped with context which updates by
+// primary key.
+func (t *Table) UpdateQueryContext(ctx context.Context, session gocqlx.Session, columns ...string) *gocqlx.Queryx {
+	return t.UpdateQuery(session, columns...).WithContext(ctx)
+}
+
+// DeleteQuery returns query which deletes by primary key.
+func (t *Table) DeleteQuery(session gocqlx.Session, columns ...string) *gocqlx.Queryx {
+	return session.Query(t.Delete(columns...))
+}
+
+// DeleteQueryContext returns query wrapped with context which deletes by
+// primary key.
+func (t *Table) DeleteQueryContext(ctx context.Context, session gocqlx.Session, columns ...string) *gocqlx.Queryx {
+	return t.DeleteQuery(session, columns...).WithContext(ctx)
+}
+
+// DeletePartitionQuery returns query which deletes by partition key.
+func (t *Table) DeletePartitionQuery(session gocqlx.Session) *gocqlx.Queryx {
+	return session.Query(t.DeletePartition())
+}
+
+// DeletePartitionQueryContext returns query wrapped with context which
+// deletes by partition key.
+func (t *Table) DeletePartitionQueryContext(ctx context.Context, session gocqlx.Session) *gocqlx.Queryx {
+	return t.DeletePartitionQuery(session).WithContext(ctx)
+}
@@ -5,10 +5,13 @@
 package table
 
 import (
+	"context"
+	"math"
 	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/gocqlx/v2"
 	"github.com/scylladb/gocqlx/v2/qb"
 )
 
@@ -27,7 +30,7 @@ func TestTableGet(t *testing.T) {
 				SortKey: []string{"b"},
 			},
 			N: []string{"a", "b"},
-			S: "SELECT * FROM table WHERE a=? AND b=? ",
+			S: "SELECT * FROM \"table\" WHERE a=? AND b=? ",
 		},
 		{
 			M: Metadata{
@@ -36,7 +39,7 @@ func TestTableGet(t *testing.T) {
 				PartKey: []string{"a"},
 			},
 			N: []string{"a"},
-			S: "SELECT * FROM table WHERE a=? ",
+			S: "SELECT * FROM \"table\" WHERE a=? ",
 		},
 		{
 			M: Metadata{
@@ -46,7 +49,7 @@ func TestTableGet(t *testing.T) {
 			},
 			C: []string{"d"},
 			N: []string{"a"},
-			S: "SELECT d FROM table WHERE a=? ",
+			S: "SELECT d FROM \"table\" WHERE a=? ",
 		},
 	}
 
@@ -76,7 +79,7 @@ func TestTableSelect(t *testing.T) {
 				SortKey: []string{"b"},
 			},
 			N: []string{"a"},
-			S: "SELECT * FROM table WHERE a=? ",
+			S: "SELECT * FROM \"table\" WHERE a=? ",
 		},
 		{
 			M: Metadata{
@@ -87,7 +90,7 @@ func TestTableSelect(t *testing.T) {
 			},
 			C: []string{"d"},
 			N: []string{"a"},
-			S: "SELECT d FROM table WHERE a=? ",
+			S: "SELECT d FROM \"table\" WHERE a=? ",
 		},
 	}
 
@@ -127,7 +130,17 @@ func TestTableInsert(t *testing.T) {
 				SortKey: []string{"b"},
 			},
 			N: []string{"a", "b", "c", "d"},
-			S: "INSERT INTO table (a,b,c,d) VALUES (?,?,?,?) ",
+			S: "INSERT INTO \"table\" (a,b,c,d) VALUES (?,?,?,?) ",
+		},
+		{
+			M: Metadata{
+				Name:    "ks.orders",
+				Columns: []string{"id", "order", "token"},
+				PartKey: []string{"id"},
+				SortKey: []string{"order"},
+			},
+			N: []string{"id", "order", "token"},
+			S: "INSERT INTO ks.orders (id,\"order\",\"token\") VALUES (?,?,?) ",
 		},
 	}
 
@@ -142,6 +155,51 @@ func TestTableInsert(t *testing.T) {
 	}
 }
 
+func TestTableInsertStatic(t *testing.T) {
+	m := Metadata{
+		Name:    "table",
+		Columns: []string{"a", "b", "c", "d"},
+		PartKey: []string{"a"},
+		SortKey: []string{"b"},
+		Static:  []string{"c"},
+	}
+	table := []struct {
+		Name string
+		Stmt func() (string, []string)
+		N    []string
+		S    string
+	}{
+		{
+			Name: "insert",
+			Stmt: New(m).Insert,
+			N:    []string{"a", "b", "c", "d"},
+			S:    "INSERT INTO \"table\" (a,b,c,d) VALUES (?,?,?,?) ",
+		},
+		{
+			Name: "insert row",
+			Stmt: New(m).InsertRow,
+			N:    []string{"a", "b", "d"},
+			S:    "INSERT INTO \"table\" (a,b,d) VALUES (?,?,?) ",
+		},
+		{
+			Name: "insert static",
+			Stmt: New(m).InsertStatic,
+			N:    []string{"a", "c"},
+			S:    "INSERT INTO \"table\" (a,c) VALUES (?,?) ",
+		},
+	}
+
+	for _, test := range table {
+		stmt, names := test.Stmt()
+		if diff := cmp.Diff(test.S, stmt); diff != "" {
+			t.Error(test.Name, diff)
+		}
+		if diff := cmp.Diff(test.N, names); diff != "" {
+			t.Error(test.Name, diff, names)
+		}
+	}
+}
+
 func TestTableUpdate(t *testing.T) {
 	table := []struct {
 		M Metadata
@@ -158,7 +216,43 @@ func TestTableUpdate(t *testing.T) {
 			},
 			C: []string{"d"},
 			N: []string{"d", "a", "b"},
-			S: "UPDATE table SET d=? WHERE a=? AND b=? ",
+			S: "UPDATE \"table\" SET d=? WHERE a=? AND b=? ",
+		},
+		{
+			M: Metadata{
+				Name:    "table",
+				Columns: []string{"a", "b", "c", "d"},
+				PartKey: []string{"a"},
+				SortKey: []string{"b"},
+				Static:  []string{"c"},
+			},
+			C: []string{"c"},
+			N: []string{"c", "a"},
+			S: "UPDATE \"table\" SET c=? WHERE a=? ",
+		},
+		{
+			M: Metadata{
+				Name:    "table",
+				Columns: []string{"a", "b", "c", "d"},
+				PartKey: []string{"a"},
+				SortKey: []string{"b"},
+				Static:  []string{"c"},
+			},
+			C: []string{"c", "d"},
+			N: []string{"c", "d", "a", "b"},
+			S: "UPDATE \"table\" SET c=?,d=? WHERE a=? AND b=? ",
+		},
+		{
+			M: Metadata{
+				Name:    "table",
+				Columns: []string{"a", "b", "c", "version"},
+				PartKey: []string{"a"},
+				SortKey: []string{"b"},
+				Version: "version",
+			},
+			C: []string{"c", "version"},
+			N: []string{"c", "next_version", "a", "b", "version"},
+			S: "UPDATE \"table\" SET c=?,version=? WHERE a=? AND b=? IF version=? ",
 		},
 	}
 
@@ -199,7 +293,7 @@ func TestTableDelete(t *testing.T) {
 				SortKey: []string{"b"},
 			},
 			N: []string{"a", "b"},
-			S: "DELETE FROM table WHERE a=? AND b=? ",
+			S: "DELETE FROM \"table\" WHERE a=? AND b=? ",
 		},
 		{
 			M: Metadata{
@@ -208,7 +302,7 @@ func TestTableDelete(t *testing.T) {
 				PartKey: []string{"a"},
 			},
 			N: []string{"a"},
-			S: "DELETE FROM table WHERE a=? ",
+			S: "DELETE FROM \"table\" WHERE a=? ",
 		},
 		{
 			M: Metadata{
@@ -218,7 +312,7 @@ func TestTableDelete(t *testing.T) {
 			},
 			C: []string{"d"},
 			N: []string{"a"},
-			S: "DELETE d FROM table WHERE a=? ",
+			S: "DELETE d FROM \"table\" WHERE a=? ",
 		},
 	}
 
@@ -244,6 +338,45 @@ func TestTableDelete(t *testing.T) {
 	}
 }
 
+func TestTableDeletePartition(t *testing.T) {
+	table := []struct {
+		M Metadata
+		N []string
+		S string
+	}{
+		{
+			M: Metadata{
+				Name:    "table",
+				Columns: []string{"a", "b", "c", "d"},
+				PartKey: []string{"a"},
+				SortKey: []string{"b", "c"},
+			},
+			N: []string{"a"},
+			S: "DELETE FROM \"table\" WHERE a=? ",
+		},
+		{
+			M: Metadata{
+				Name:    "table",
+				Columns: []string{"a", "b", "c", "d"},
+				PartKey: []string{"a", "b"},
+				SortKey: []string{"c"},
+			},
+			N: []string{"a", "b"},
+			S: "DELETE FROM \"table\" WHERE a=? AND b=? ",
+		},
+	}
+
+	for _, test := range table {
+		stmt, names := New(test.M).DeletePartition()
+		if diff := cmp.Diff(test.S, stmt); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(test.N, names); diff != "" {
+			t.Error(diff, names)
+		}
+	}
+}
+
 func TestTableConcurrentUsage(t *testing.T) {
 	table := []struct {
 		Name string
@@ -261,7 +394,7 @@ func TestTableConcurrentUsage(t *testing.T) {
 				SortKey: []string{"b"},
 			},
 			N: []string{"a", "b"},
-			S: "SELECT * FROM table WHERE a=? AND b=? ",
+			S: "SELECT * FROM \"table\" WHERE a=? AND b=? ",
 		},
 		{
 			Name: "Sub select",
@@ -273,7 +406,7 @@ func TestTableConcurrentUsage(t *testing.T) {
 			},
 			C: []string{"d"},
 			N: []string{"a", "b"},
-			S: "SELECT d FROM table WHERE a=? AND b=? ",
+			S: "SELECT d FROM \"table\" WHERE a=? AND b=? ",
 		},
 	}
 
@@ -299,3 +432,316 @@ func TestTableConcurrentUsage(t *testing.T) {
 		wg.Wait()
 	}
 }
+
+func TestMetadataIsPII(t *testing.T) {
+	m := Metadata{
+		Name:    "table",
+		Columns: []string{"a", "b", "c"},
+		PartKey: []string{"a"},
+		PII:     []string{"b"},
+	}
+
+	if !m.IsPII("b") {
+		t.Error("expected b to be PII")
+	}
+	if m.IsPII("c") {
+		t.Error("expected c not to be PII")
+	}
+}
+
+func TestTableValidator(t *testing.T) {
+	tbl := New(Metadata{
+		Name:    "table",
+		Columns: []string{"a", "b", "c"},
+		PartKey: []string{"a"},
+		SortKey: []string{"b"},
+	})
+	v := tbl.Validator()
+
+	if err := v.Validate(tbl.UpdateBuilder("c")); err != nil {
+		t.Fatal("Validate() error", err)
+	}
+	if err := v.Validate(qb.Delete(tbl.Name()).Where(qb.Eq("a"))); err == nil {
+		t.Fatal("Validate() expected error")
+	}
+}
+
+func TestReadSetEntryGuard(t *testing.T) {
+	tbl := New(Metadata{
+		Name:    "table",
+		Columns: []string{"a", "b", "c", "version"},
+		PartKey: []string{"a"},
+		SortKey: []string{"b"},
+		Version: "version",
+	})
+
+	table := []struct {
+		Name  string
+		Entry readSetEntry
+		S     string
+		N     []string
+	}{
+		{
+			Name:  "found",
+			Entry: readSetEntry{table: tbl, version: 1},
+			S:     "UPDATE \"table\" SET version=? WHERE a=? AND b=? IF version=? ",
+			N:     []string{"next_version", "a", "b", "version"},
+		},
+		{
+			Name:  "not found",
+			Entry: readSetEntry{table: tbl},
+			S:     "UPDATE \"table\" SET version=? WHERE a=? AND b=? IF version=null ",
+			N:     []string{"next_version", "a", "b"},
+		},
+	}
+
+	for _, test := range table {
+		t.Run(test.Name, func(t *testing.T) {
+			stmt, names := test.Entry.guard()
+			if diff := cmp.Diff(test.S, stmt); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(test.N, names); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestTableNonZeroColumns(t *testing.T) {
+	tbl := New(Metadata{
+		Name:    "table",
+		Columns: []string{"a", "b", "c", "d", "e"},
+		PartKey: []string{"a"},
+		SortKey: []string{"b"},
+	})
+
+	type row struct {
+		A int
+		B int
+		C string
+		D *int
+		E []string
+	}
+	zero := 0
+
+	table := []struct {
+		V       interface{}
+		Columns []string
+		Err     error
+	}{
+		{
+			V:       &row{A: 1, C: "c", E: []string{"e"}},
+			Columns: []string{"c", "e"},
+		},
+		{
+			V:       row{A: 1, B: 2, D: &zero},
+			Columns: []string{"d"},
+		},
+		{
+			V:   row{A: 1, B: 2},
+			Err: ErrNoColumns,
+		},
+	}
+
+	for _, test := range table {
+		columns, err := tbl.nonZeroColumns(gocqlx.Session{}, test.V)
+		if err != test.Err {
+			t.Fatalf("nonZeroColumns() error %v, expected %v", err, test.Err)
+		}
+		if diff := cmp.Diff(test.Columns, columns); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
+
+func TestTableStaticUpdateNotVersioned(t *testing.T) {
+	type row struct {
+		A       int
+		B       int
+		C       string
+		D       string
+		Version int
+	}
+	v := row{A: 1, B: 2, C: "c", Version: 1}
+
+	tbl := New(Metadata{
+		Name:    "table",
+		Columns: []string{"a", "b", "c", "d", "version"},
+		PartKey: []string{"a"},
+		SortKey: []string{"b"},
+		Static:  []string{"c"},
+		Version: "version",
+	})
+	if err := tbl.UpdateVersioned(gocqlx.Session{}, v, "c"); err != ErrStaticUpdateNotVersioned {
+		t.Fatalf("UpdateVersioned() error %v, expected %v", err, ErrStaticUpdateNotVersioned)
+	}
+	if _, err := tbl.UpdateNonZero(gocqlx.Session{}, v); err != ErrStaticUpdateNotVersioned {
+		t.Fatalf("UpdateNonZero() error %v, expected %v", err, ErrStaticUpdateNotVersioned)
+	}
+
+	tbl = New(Metadata{
+		Name:    "table",
+		Columns: []string{"a", "b", "c", "d", "version"},
+		PartKey: []string{"a"},
+		SortKey: []string{"b"},
+		Static:  []string{"c", "version"},
+		Version: "version",
+	})
+	if err := tbl.checkVersioned([]string{"c"}); err != nil {
+		t.Fatal("checkVersioned() error:", err)
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	table := []struct {
+		V interface{}
+		N interface{}
+	}{
+		{V: 1, N: 2},
+		{V: int64(41), N: int64(42)},
+		{V: uint8(0), N: uint8(1)},
+	}
+
+	for _, test := range table {
+		n, err := nextVersion(test.V)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(test.N, n); diff != "" {
+			t.Error(diff)
+		}
+	}
+
+	if _, err := nextVersion("1"); err == nil {
+		t.Error("expected error for string version")
+	}
+	if _, err := nextVersion(nil); err == nil {
+		t.Error("expected error for missing version")
+	}
+}
+
+func TestTableView(t *testing.T) {
+	base := New(Metadata{
+		Name:    "person",
+		Columns: []string{"id", "email", "name"},
+		PartKey: []string{"id"},
+		PII:     []string{"email"},
+	})
+	v := base.View("person_by_email", []string{"email"}, []string{"id"})
+
+	if v.Base() != base {
+		t.Error("expected base table")
+	}
+	if !v.Metadata().IsPII("email") {
+		t.Error("expected email to be PII")
+	}
+
+	stmt, names := v.Get()
+	if diff := cmp.Diff("SELECT * FROM person_by_email WHERE email=? AND id=? ", stmt); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"email", "id"}, names); diff != "" {
+		t.Error(diff)
+	}
+
+	stmt, names = v.Select("name")
+	if diff := cmp.Diff("SELECT name FROM person_by_email WHERE email=? ", stmt); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"email"}, names); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestTableTokenRange(t *testing.T) {
+	tbl := New(Metadata{
+		Name:    "table",
+		Columns: []string{"a", "b", "c"},
+		PartKey: []string{"a", "b"},
+	})
+
+	stmt, names := tbl.TokenRange("c")
+	if diff := cmp.Diff("SELECT c FROM \"table\" WHERE token(a,b)>? AND token(a,b)<=? ", stmt); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{StartTokenName, EndTokenName}, names); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestSplitTokenRing(t *testing.T) {
+	table := []struct {
+		N int
+		R []TokenRange
+	}{
+		{
+			N: 0,
+			R: []TokenRange{{math.MinInt64, math.MaxInt64}},
+		},
+		{
+			N: 2,
+			R: []TokenRange{{math.MinInt64, -1}, {-1, math.MaxInt64}},
+		},
+		{
+			N: 4,
+			R: []TokenRange{
+				{math.MinInt64, -4611686018427387905},
+				{-4611686018427387905, -1},
+				{-1, 4611686018427387903},
+				{4611686018427387903, math.MaxInt64},
+			},
+		},
+	}
+
+	for _, test := range table {
+		if diff := cmp.Diff(test.R, SplitTokenRing(test.N)); diff != "" {
+			t.Error(test.N, diff)
+		}
+	}
+}
+
+func TestTableScanInvalidDest(t *testing.T) {
+	tbl := New(Metadata{
+		Name:    "table",
+		Columns: []string{"a"},
+		PartKey: []string{"a"},
+	})
+
+	fn := func(row interface{}) error { return nil }
+	for _, dest := range []interface{}{nil, 1, new(int), struct{}{}} {
+		if err := tbl.Scan(context.Background(), gocqlx.Session{}, dest, fn, ScanOptions{}); err == nil {
+			t.Errorf("Scan(%T) expected error", dest)
+		}
+	}
+}
+
+func TestMetadataFromSchema(t *testing.T) {
+	columns := []schemaColumn{
+		{ColumnName: "name", Kind: "regular", Position: -1, Type: "text"},
+		{ColumnName: "seq", Kind: "clustering", Position: 0, Type: "int"},
+		{ColumnName: "shard", Kind: "partition_key", Position: 1, Type: "int"},
+		{ColumnName: "age", Kind: "regular", Position: -1, Type: "int"},
+		{ColumnName: "id", Kind: "partition_key", Position: 0, Type: "uuid"},
+		{ColumnName: "owner", Kind: "static", Position: -1, Type: "text"},
+	}
+
+	expected := Metadata{
+		Name:    "ks.t",
+		Columns: []string{"id", "shard", "seq", "age", "name", "owner"},
+		PartKey: []string{"id", "shard"},
+		SortKey: []string{"seq"},
+		Static:  []string{"owner"},
+		Types: map[string]string{
+			"id":    "uuid",
+			"shard": "int",
+			"seq":   "int",
+			"age":   "int",
+			"name":  "text",
+			"owner": "text",
+		},
+	}
+	if diff := cmp.Diff(expected, metadataFromSchema("ks.t", columns)); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package table
+
+import (
+	"context"
+	"math"
+	"math/big"
+
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+)
+
+// Names of token range statement parameters.
+const (
+	StartTokenName = "start_token"
+	EndTokenName   = "end_token"
+)
+
+// TokenRange is a range of Murmur3Partitioner tokens (Start, End].
+type TokenRange struct {
+	Start int64
+	End   int64
+}
+
+// SplitTokenRing splits the Murmur3Partitioner token ring into n ranges of
+// roughly equal size that cover the whole ring. If n is smaller than 1 one
+// range is returned.
+func SplitTokenRing(n int) []TokenRange {
+	if n < 1 {
+		n = 1
+	}
+
+	var (
+		min   = big.NewInt(math.MinInt64)
+		size  = new(big.Int).Sub(big.NewInt(math.MaxInt64), min)
+		count = big.NewInt(int64(n))
+	)
+
+	ranges := make([]TokenRange, n)
+	start := int64(math.MinInt64)
+	for i := 0; i < n; i++ {
+		end := int64(math.MaxInt64)
+		if i < n-1 {
+			e := new(big.Int).Mul(size, big.NewInt(int64(i+1)))
+			e.Div(e, count).Add(e, min)
+			end = e.Int64()
+		}
+		ranges[i] = TokenRange{Start: start, End: end}
+		start = end
+	}
+	return ranges
+}
+
+// TokenRange returns select by token range statement, the statement takes
+// StartTokenName and EndTokenName parameters.
+func (t *Table) TokenRange(columns ...string) (stmt string, names []string) {
+	return t.TokenRangeBuilder(columns...).ToCql()
+}
+
+// TokenRangeBuilder returns a builder initialised to select by token range
+// statement.
+func (t *Table) TokenRangeBuilder(columns ...string) *qb.SelectBuilder {
+	token := qb.Token(t.metadata.PartKey...)
+	return qb.Select(t.metadata.Name).
+		Columns(columns...).
+		Where(token.GtValueNamed(StartTokenName), token.LtOrEqValueNamed(EndTokenName))
+}
+
+// TokenRangeQuery returns query which selects rows in token range r.
+func (t *Table) TokenRangeQuery(session gocqlx.Session, r TokenRange, columns ...string) *gocqlx.Queryx {
+	return session.Query(t.TokenRange(columns...)).BindMap(r.bindings())
+}
+
+// TokenRangeQueryContext returns query wrapped with context which selects
+// rows in token range r.
+func (t *Table) TokenRangeQueryContext(ctx context.Context, session gocqlx.Session, r TokenRange, columns ...string) *gocqlx.Queryx {
+	return t.TokenRangeQuery(session, r, columns...).WithContext(ctx)
+}
+
+func (r TokenRange) bindings() qb.M {
+	return qb.M{
+		StartTokenName: r.Start,
+		EndTokenName:   r.End,
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package table
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/scylladb/gocqlx/v2"
+)
+
+// ErrNoColumns is returned by UpdateNonZero if there are no columns to update.
+var ErrNoColumns = errors.New("no columns to update")
+
+// UpdateNonZero returns update by primary key query bound to struct v, only
+// columns with non-zero values of v are updated. Primary key columns are
+// never updated, they must be set in v. It's a partial update primitive for
+// services exposing PATCH-like APIs, note that zero values i.e. empty
+// strings can not be set with it. If all non-key columns of v are zero
+// ErrNoColumns is returned. If the table is versioned the update is
+// conditional on the version of v, see UpdateVersioned, use ExecCAS to run it.
+func (t *Table) UpdateNonZero(session gocqlx.Session, v interface{}) (*gocqlx.Queryx, error) {
+	columns, err := t.nonZeroColumns(session, v)
+	if err != nil {
+		return nil, err
+	}
+	if t.metadata.Version != "" {
+		if err := t.checkVersioned(columns); err != nil {
+			return nil, err
+		}
+		q, _, err := t.bindVersioned(t.UpdateQuery(session, columns...), session, v)
+		return q, err
+	}
+	return t.UpdateQuery(session, columns...).BindStruct(v), nil
+}
+
+// UpdateNonZeroContext returns UpdateNonZero query wrapped with context.
+func (t *Table) UpdateNonZeroContext(ctx context.Context, session gocqlx.Session, v interface{}) (*gocqlx.Queryx, error) {
+	q, err := t.UpdateNonZero(session, v)
+	if err != nil {
+		return nil, err
+	}
+	return q.WithContext(ctx), nil
+}
+
+func (t *Table) nonZeroColumns(session gocqlx.Session, v interface{}) ([]string, error) {
+	m, err := gocqlx.StructToMap(session.Mapper, v)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []string
+	for _, c := range t.metadata.Columns {
+		if t.isPrimaryKey(c) || c == t.metadata.Version {
+			continue
+		}
+		if val, ok := m[c]; ok && val != nil && !reflect.ValueOf(val).IsZero() {
+			columns = append(columns, c)
+		}
+	}
+	if len(columns) == 0 {
+		return nil, ErrNoColumns
+	}
+	return columns, nil
+}
+
+func (t *Table) isPrimaryKey(column string) bool {
+	for _, k := range t.metadata.PartKey {
+		if k == column {
+			return true
+		}
+	}
+	for _, k := range t.metadata.SortKey {
+		if k == column {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package table
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+)
+
+// ErrStaleVersion is returned by UpdateVersioned if the row version is not
+// the version of the updated value, the row was changed after it was read.
+var ErrStaleVersion = errors.New("stale version")
+
+// ErrStaticUpdateNotVersioned is returned by UpdateVersioned and
+// UpdateNonZero if all updated columns are static and the version column is
+// not, such an update is by partition key and can not be conditional on the
+// row version.
+var ErrStaticUpdateNotVersioned = errors.New("update of static columns can not be versioned, version column is not static")
+
+// checkVersioned returns ErrStaticUpdateNotVersioned if an update of columns
+// is not versioned by UpdateBuilder.
+func (t *Table) checkVersioned(columns []string) error {
+	if t.staticOnly(columns) && !t.metadata.IsStatic(t.metadata.Version) {
+		return ErrStaticUpdateNotVersioned
+	}
+	return nil
+}
+
+// nextVersionName returns name of the parameter holding the new version.
+func nextVersionName(column string) string {
+	return "next_" + column
+}
+
+// versioned adds version assignment and condition to b.
+func (t *Table) versioned(b *qb.UpdateBuilder) *qb.UpdateBuilder {
+	v := t.metadata.Version
+	return b.SetNamed(v, nextVersionName(v)).If(qb.Eq(v))
+}
+
+// withoutVersion returns columns without the version column.
+func (t *Table) withoutVersion(columns []string) []string {
+	var r []string
+	for _, c := range columns {
+		if c != t.metadata.Version {
+			r = append(r, c)
+		}
+	}
+	return r
+}
+
+// UpdateVersioned updates columns of the row by primary key if the row
+// version equals the version of v, the version is incremented. If the row
+// version is different ErrStaleVersion is returned. On success the version
+// of v is incremented if v is a pointer. The table must be versioned, see
+// Metadata.Version. If all columns are static the version column must be
+// static, otherwise ErrStaticUpdateNotVersioned is returned.
+func (t *Table) UpdateVersioned(session gocqlx.Session, v interface{}, columns ...string) error {
+	if t.metadata.Version == "" {
+		return errors.New("table is not versioned")
+	}
+	if err := t.checkVersioned(columns); err != nil {
+		return err
+	}
+
+	q, next, err := t.bindVersioned(t.UpdateQuery(session, columns...), session, v)
+	if err != nil {
+		return err
+	}
+	applied, err := q.ExecCASRelease()
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return ErrStaleVersion
+	}
+
+	if reflect.ValueOf(v).Kind() == reflect.Ptr {
+		return gocqlx.MapToStruct(session.Mapper, map[string]interface{}{t.metadata.Version: next}, v)
+	}
+	return nil
+}
+
+// bindVersioned binds struct v and the next version to q.
+func (t *Table) bindVersioned(q *gocqlx.Queryx, session gocqlx.Session, v interface{}) (*gocqlx.Queryx, interface{}, error) {
+	m, err := gocqlx.StructToMap(session.Mapper, v)
+	if err != nil {
+		q.Release()
+		return nil, nil, err
+	}
+	next, err := nextVersion(m[t.metadata.Version])
+	if err != nil {
+		q.Release()
+		return nil, nil, err
+	}
+	return q.BindStructMap(v, map[string]interface{}{nextVersionName(t.metadata.Version): next}), next, nil
+}
+
+// nextVersion returns v incremented by one, v must be an integer.
+func nextVersion(v interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return nil, errors.New("missing version")
+	}
+	next := reflect.New(rv.Type()).Elem()
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		next.SetInt(rv.Int() + 1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		next.SetUint(rv.Uint() + 1)
+	default:
+		return nil, fmt.Errorf("unsupported version type %s", rv.Type())
+	}
+	return next.Interface(), nil
+}
@@ -0,0 +1,264 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+)
+
+// View is a read-only companion of a Table for querying a materialized view
+// of the table. View shares column metadata with the base table so structs
+// mapped to the base table can be used with the view.
+type View struct {
+	base  *Table
+	table *Table
+}
+
+// View returns View of the materialized view name with primary key given by
+// partKey and sortKey. The view is assumed to select all columns of the
+// base table.
+func (t *Table) View(name string, partKey, sortKey []string) *View {
+	m := t.metadata
+	return &View{
+		base: t,
+		table: New(Metadata{
+			Name:         name,
+			Columns:      m.Columns,
+			PartKey:      partKey,
+			SortKey:      sortKey,
+			PII:          m.PII,
+			Descriptions: m.Descriptions,
+			Types:        m.Types,
+		}),
+	}
+}
+
+// Base returns the base table.
+func (v *View) Base() *Table {
+	return v.base
+}
+
+// Metadata returns copy of view metadata.
+func (v *View) Metadata() Metadata {
+	return v.table.Metadata()
+}
+
+// Name returns view name.
+func (v *View) Name() string {
+	return v.table.Name()
+}
+
+// PrimaryKeyCmp returns copy of view's primaryKeyCmp.
+func (v *View) PrimaryKeyCmp() []qb.Cmp {
+	return v.table.PrimaryKeyCmp()
+}
+
+// Get returns select by view primary key statement.
+func (v *View) Get(columns ...string) (stmt string, names []string) {
+	return v.table.Get(columns...)
+}
+
+// Select returns select by view partition key statement.
+func (v *View) Select(columns ...string) (stmt string, names []string) {
+	return v.table.Select(columns...)
+}
+
+// SelectBuilder returns a builder initialised to select by view partition
+// key statement.
+func (v *View) SelectBuilder(columns ...string) *qb.SelectBuilder {
+	return v.table.SelectBuilder(columns...)
+}
+
+// GetQuery returns query which gets by view primary key.
+func (v *View) GetQuery(session gocqlx.Session, columns ...string) *gocqlx.Queryx {
+	return v.table.GetQuery(session, columns...)
+}
+
+// GetQueryContext returns query wrapped with context which gets by view
+// primary key.
+func (v *View) GetQueryContext(ctx context.Context, session gocqlx.Session, columns ...string) *gocqlx.Queryx {
+	return v.table.GetQueryContext(ctx, session, columns...)
+}
+
+// SelectQuery returns query which selects by view partition key.
+func (v *View) SelectQuery(session gocqlx.Session, columns ...string) *gocqlx.Queryx {
+	return v.table.SelectQuery(session, columns...)
+}
+
+// SelectQueryContext returns query wrapped with context which selects by
+// view partition key.
+func (v *View) SelectQueryContext(ctx context.Context, session gocqlx.Session, columns ...string) *gocqlx.Queryx {
+	return v.table.SelectQueryContext(ctx, session, columns...)
+}
+
+// Check compares the view with the base table, see CheckView.
+func (v *View) Check(ctx context.Context, session gocqlx.Session, limit uint) ([]Divergence, error) {
+	return CheckView(ctx, session, v.base, v.table, limit)
+}
+
+// DivergenceKind specifies how a materialized view row differs from the base
+// table.
+type DivergenceKind int
+
+// Divergence kinds.
+const (
+	// MissingInView means a base table row has no corresponding view row.
+	MissingInView DivergenceKind = iota
+	// StaleInView means a view row has no corresponding base table row.
+	StaleInView
+	// Mismatch means view row values differ from base table row values.
+	Mismatch
+)
+
+func (k DivergenceKind) String() string {
+	switch k {
+	case MissingInView:
+		return "missing in view"
+	case StaleInView:
+		return "stale in view"
+	case Mismatch:
+		return "mismatch"
+	default:
+		return fmt.Sprintf("DivergenceKind(%d)", int(k))
+	}
+}
+
+// Divergence is a row that is not consistent between a materialized view and
+// its base table. Key holds primary key values of the row, for StaleInView it
+// is the view primary key, otherwise it's the base table primary key.
+type Divergence struct {
+	Kind DivergenceKind
+	Key  map[string]interface{}
+}
+
+// CheckView compares a sample of at most limit rows of the base table with
+// the materialized view and the other way around, and returns rows that
+// diverge. Only view columns are compared, view columns must be base table
+// columns. Base table rows with null view primary key columns are skipped as
+// they are not present in the view.
+//
+// Views with additional WHERE restrictions, other than IS NOT NULL, are not
+// supported as rows filtered out by the view are reported as MissingInView.
+// Stale view rows can be removed with the ScyllaDB PRUNE MATERIALIZED VIEW
+// statement, see qb.PruneView.
+func CheckView(ctx context.Context, session gocqlx.Session, base, view *Table, limit uint) ([]Divergence, error) {
+	bm := base.Metadata()
+	vm := view.Metadata()
+
+	var out []Divergence
+
+	// base -> view
+	stmt, _ := qb.Select(bm.Name).Columns(vm.Columns...).Limit(limit).ToCql()
+	err := scanRows(session.ContextQuery(ctx, stmt, nil).Iter(), func(row map[string]interface{}) error {
+		if hasNull(row, vm.PartKey, vm.SortKey) {
+			return nil
+		}
+		v, err := getRow(ctx, session, view, vm.Columns, row)
+		if err != nil {
+			return err
+		}
+		switch {
+		case v == nil:
+			out = append(out, Divergence{Kind: MissingInView, Key: pick(row, bm.PartKey, bm.SortKey)})
+		case !reflect.DeepEqual(row, v):
+			out = append(out, Divergence{Kind: Mismatch, Key: pick(row, bm.PartKey, bm.SortKey)})
+		}
+		return nil
+	})
+	if err != nil {
+		return out, fmt.Errorf("scan %s: %s", bm.Name, err)
+	}
+
+	// view -> base
+	stmt, _ = qb.Select(vm.Name).Columns(vm.Columns...).Limit(limit).ToCql()
+	err = scanRows(session.ContextQuery(ctx, stmt, nil).Iter(), func(row map[string]interface{}) error {
+		b, err := getRow(ctx, session, base, vm.Columns, row)
+		if err != nil {
+			return err
+		}
+		if b == nil {
+			out = append(out, Divergence{Kind: StaleInView, Key: pick(row, vm.PartKey, vm.SortKey)})
+		}
+		return nil
+	})
+	if err != nil {
+		return out, fmt.Errorf("scan %s: %s", vm.Name, err)
+	}
+
+	return out, nil
+}
+
+// getRow returns the row of t with primary key taken from key or nil if
+// the row does not exist.
+func getRow(ctx context.Context, session gocqlx.Session, t *Table, columns []string, key map[string]interface{}) (map[string]interface{}, error) {
+	stmt, names := t.Get(columns...)
+	q := session.ContextQuery(ctx, stmt, names).BindMap(key)
+	if err := q.Err(); err != nil {
+		return nil, err
+	}
+
+	var row map[string]interface{}
+	err := scanRows(q.Iter(), func(r map[string]interface{}) error {
+		row = r
+		return nil
+	})
+	return row, err
+}
+
+// scanRows calls fn for every row of iter. Values are pointers that are nil
+// for null values.
+func scanRows(iter *gocqlx.Iterx, fn func(row map[string]interface{}) error) error {
+	columns := iter.Columns()
+	for {
+		values := make([]interface{}, len(columns))
+		for i := range columns {
+			t := reflect.TypeOf(columns[i].TypeInfo.New())
+			values[i] = reflect.New(t).Interface()
+		}
+		if !iter.Scan(values...) {
+			break
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i := range columns {
+			row[columns[i].Name] = reflect.ValueOf(values[i]).Elem().Interface()
+		}
+		if err := fn(row); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+	return iter.Close()
+}
+
+func hasNull(row map[string]interface{}, columns ...[]string) bool {
+	for _, cs := range columns {
+		for _, c := range cs {
+			if v, ok := row[c]; !ok || reflect.ValueOf(v).IsNil() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func pick(row map[string]interface{}, columns ...[]string) map[string]interface{} {
+	m := make(map[string]interface{})
+	for _, cs := range columns {
+		for _, c := range cs {
+			if v := reflect.ValueOf(row[c]); v.Kind() == reflect.Ptr && !v.IsNil() {
+				m[c] = v.Elem().Interface()
+			} else {
+				m[c] = nil
+			}
+		}
+	}
+	return m
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+// +build all integration
+
+package table_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/scylladb/gocqlx/v2/gocqlxtest"
+	"github.com/scylladb/gocqlx/v2/table"
+)
+
+func TestCheckView(t *testing.T) {
+	session := CreateSession(t)
+	defer session.Close()
+
+	if err := session.ExecStmt(`CREATE TABLE gocqlx_test.check_view (id int PRIMARY KEY, age int, name text)`); err != nil {
+		t.Fatal("create table:", err)
+	}
+	if err := session.ExecStmt(`CREATE MATERIALIZED VIEW gocqlx_test.check_view_by_age AS
+			SELECT * FROM gocqlx_test.check_view WHERE age IS NOT NULL AND id IS NOT NULL PRIMARY KEY (age, id)`); err != nil {
+		t.Fatal("create view:", err)
+	}
+
+	base := table.New(table.Metadata{
+		Name:    "gocqlx_test.check_view",
+		Columns: []string{"id", "age", "name"},
+		PartKey: []string{"id"},
+	})
+	view := table.New(table.Metadata{
+		Name:    "gocqlx_test.check_view_by_age",
+		Columns: []string{"age", "id", "name"},
+		PartKey: []string{"age"},
+		SortKey: []string{"id"},
+	})
+
+	type row struct {
+		ID   int
+		Age  *int
+		Name string
+	}
+	age := 30
+	rows := []row{
+		{ID: 1, Age: &age, Name: "a"},
+		{ID: 2, Name: "no age"},
+	}
+	for _, r := range rows {
+		if err := session.Query(base.Insert()).BindStruct(r).ExecRelease(); err != nil {
+			t.Fatal("insert:", err)
+		}
+	}
+
+	d, err := table.CheckView(context.Background(), session, base, view, 100)
+	if err != nil {
+		t.Fatal("check view:", err)
+	}
+	if len(d) != 0 {
+		t.Fatal("unexpected divergence", d)
+	}
+
+	v := base.View("gocqlx_test.check_view_by_age", []string{"age"}, []string{"id"})
+	d, err = v.Check(context.Background(), session, 100)
+	if err != nil {
+		t.Fatal("check view:", err)
+	}
+	if len(d) != 0 {
+		t.Fatal("unexpected divergence", d)
+	}
+
+	var got []row
+	if err := v.SelectQuery(session).BindMap(map[string]interface{}{"age": age}).SelectRelease(&got); err != nil {
+		t.Fatal("select:", err)
+	}
+	if len(got) != 1 || got[0].ID != 1 {
+		t.Fatal("unexpected rows", got)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// timeUUIDBase is the start of the UUID epoch, 1582-10-15, in 100ns ticks
+// before the Unix epoch.
+const timeUUIDBase = 122192928000000000
+
+// TimeUUIDGenerator generates version 1 UUIDs, timeuuid values, that are
+// strictly increasing within the generator. If the clock does not advance or
+// goes back between calls the timestamp of the previous UUID is incremented
+// by 100ns, so UUIDs generated in a single process sort in the order of
+// generation. It's safe for concurrent use.
+//
+// It's a standalone utility generating values on the client, the values are
+// bound to query parameters like any other value. Use qb.Now() to generate
+// timeuuid on the server.
+type TimeUUIDGenerator struct {
+	node     [6]byte
+	clockSeq uint16
+	now      func() time.Time
+
+	mu   sync.Mutex
+	last int64
+}
+
+// NewTimeUUIDGenerator returns a generator using node as the node ID of the
+// generated UUIDs. Node must be 6 bytes long i.e. a MAC address, if it's
+// empty a random node ID is used. Processes generating UUIDs concurrently
+// should use distinct node IDs to avoid collisions.
+func NewTimeUUIDGenerator(node []byte) *TimeUUIDGenerator {
+	g := &TimeUUIDGenerator{
+		now: time.Now,
+	}
+
+	var seq [2]byte
+	if _, err := rand.Read(seq[:]); err != nil {
+		panic(fmt.Sprintf("timeuuid: failed to generate clock sequence: %s", err))
+	}
+	g.clockSeq = binary.BigEndian.Uint16(seq[:]) & 0x3FFF
+
+	switch len(node) {
+	case 0:
+		if _, err := rand.Read(g.node[:]); err != nil {
+			panic(fmt.Sprintf("timeuuid: failed to generate node ID: %s", err))
+		}
+		// Set the multicast bit as required for random node IDs by RFC 4122.
+		g.node[0] |= 0x01
+	case len(g.node):
+		copy(g.node[:], node)
+	default:
+		panic(fmt.Sprintf("timeuuid: invalid node ID length %d, expected 6", len(node)))
+	}
+
+	return g
+}
+
+// Next returns a new UUID based on the current time, it's greater than any
+// UUID previously returned by the generator.
+func (g *TimeUUIDGenerator) Next() gocql.UUID {
+	t := g.now()
+	ticks := t.Unix()*1e7 + int64(t.Nanosecond()/100) + timeUUIDBase
+
+	g.mu.Lock()
+	if ticks <= g.last {
+		ticks = g.last + 1
+	}
+	g.last = ticks
+	g.mu.Unlock()
+
+	var u gocql.UUID
+	binary.BigEndian.PutUint32(u[0:], uint32(ticks))
+	binary.BigEndian.PutUint16(u[4:], uint16(ticks>>32))
+	binary.BigEndian.PutUint16(u[6:], uint16(ticks>>48)&0x0FFF|0x1000)
+	binary.BigEndian.PutUint16(u[8:], g.clockSeq|0x8000)
+	copy(u[10:], g.node[:])
+	return u
+}
+
+var defaultTimeUUIDGenerator = NewTimeUUIDGenerator(nil)
+
+// TimeUUID returns a new UUID from a process wide generator with a random
+// node ID, see TimeUUIDGenerator.
+func TimeUUID() gocql.UUID {
+	return defaultTimeUUIDGenerator.Next()
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTimeUUIDGenerator(t *testing.T) {
+	node := []byte{1, 2, 3, 4, 5, 6}
+	now := time.Date(2020, 1, 2, 3, 4, 5, 600, time.UTC)
+
+	g := NewTimeUUIDGenerator(node)
+	g.now = func() time.Time { return now }
+
+	t.Run("fields", func(t *testing.T) {
+		u := g.Next()
+		if u.Version() != 1 {
+			t.Fatalf("Version()=%d, expected 1", u.Version())
+		}
+		if u.Variant() != gocql.VariantIETF {
+			t.Fatalf("Variant()=%d, expected %d", u.Variant(), gocql.VariantIETF)
+		}
+		if diff := cmp.Diff(node, u.Node()); diff != "" {
+			t.Fatal(diff)
+		}
+		if !u.Time().Equal(now) {
+			t.Fatalf("Time()=%s, expected %s", u.Time(), now)
+		}
+	})
+
+	t.Run("clock does not advance", func(t *testing.T) {
+		prev := g.Next()
+		for i := 0; i < 10; i++ {
+			u := g.Next()
+			if !timeUUIDLess(prev, u) {
+				t.Fatalf("Next()=%s, expected greater than %s", u, prev)
+			}
+			prev = u
+		}
+	})
+
+	t.Run("clock goes back", func(t *testing.T) {
+		prev := g.Next()
+		now = now.Add(-time.Second)
+		if u := g.Next(); !timeUUIDLess(prev, u) {
+			t.Fatalf("Next()=%s, expected greater than %s", u, prev)
+		}
+	})
+}
+
+func TestTimeUUIDGeneratorConcurrent(t *testing.T) {
+	const (
+		workers = 8
+		n       = 1000
+	)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		seen = make(map[gocql.UUID]struct{}, workers*n)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < n; j++ {
+				u := TimeUUID()
+				mu.Lock()
+				seen[u] = struct{}{}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(seen) != workers*n {
+		t.Fatalf("generated %d unique UUIDs, expected %d", len(seen), workers*n)
+	}
+}
+
+func TestNewTimeUUIDGeneratorRandomNode(t *testing.T) {
+	u := NewTimeUUIDGenerator(nil).Next()
+	if u.Node()[0]&0x01 == 0 {
+		t.Fatalf("Node()=%x, expected multicast bit set", u.Node())
+	}
+}
+
+// timeUUIDLess compares UUIDs as Cassandra compares timeuuid values, by
+// time and then by bytes.
+func timeUUIDLess(a, b gocql.UUID) bool {
+	if a.Timestamp() != b.Timestamp() {
+		return a.Timestamp() < b.Timestamp()
+	}
+	return bytes.Compare(a[:], b[:]) < 0
+}
@@ -55,19 +55,24 @@ func (u udt) UnmarshalUDT(name string, info gocql.TypeInfo, data []byte) error {
 	return gocql.Unmarshal(info, data, value.Addr().Interface())
 }
 
-// udtWrapValue adds UDT wrapper if needed.
+// udtWrapValue adds UDT or codec wrapper if needed.
 func udtWrapValue(value reflect.Value, mapper *reflectx.Mapper, unsafe bool) interface{} {
 	if value.Type().Implements(autoUDTInterface) {
 		return makeUDT(value, mapper, unsafe)
 	}
+	if c := codecWrapValue(value); c != nil {
+		return c
+	}
 	return value.Interface()
 }
 
-// udtWrapSlice adds UDT wrapper if needed.
+// udtWrapSlice adds UDT or codec wrapper if needed.
 func udtWrapSlice(mapper *reflectx.Mapper, unsafe bool, v []interface{}) []interface{} {
 	for i := range v {
 		if _, ok := v[i].(UDT); ok {
 			v[i] = makeUDT(reflect.ValueOf(v[i]), mapper, unsafe)
+		} else if c := codecWrapValue(reflect.ValueOf(v[i])); c != nil {
+			v[i] = c
 		}
 	}
 	return v
@@ -0,0 +1,155 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/scylladb/go-reflectx"
+)
+
+// Mismatch is a difference between a struct and a table schema found by
+// Validate.
+type Mismatch struct {
+	// Table is the name of the table as passed to Validate.
+	Table string
+	// Column is the name of the column, it's empty if the table is missing.
+	Column string
+	// Field is the path of the struct field i.e. "Address.City".
+	Field string
+	// GoType is the type of the struct field.
+	GoType reflect.Type
+	// Type is the CQL type of the column, it's nil if the column is missing.
+	Type gocql.TypeInfo
+	// Err is the reason of the mismatch.
+	Err error
+}
+
+func (m Mismatch) String() string {
+	switch {
+	case m.Column == "":
+		return fmt.Sprintf("table %s: %s", m.Table, m.Err)
+	case m.Type == nil:
+		return fmt.Sprintf("table %s: field %s: %s", m.Table, m.Field, m.Err)
+	default:
+		return fmt.Sprintf("table %s: column %q of type %s and field %s of type %s: %s", m.Table, m.Column, m.Type, m.Field, m.GoType, m.Err)
+	}
+}
+
+// ValidationError is returned by Validate if structs do not match the
+// schema, mismatches are ordered by table and column.
+type ValidationError []Mismatch
+
+func (e ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "found %d mismatches: ", len(e))
+	for i, m := range e {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(m.String())
+	}
+	return b.String()
+}
+
+// Validate compares structs with the schema of the tables, tables maps
+// keyspace.table names to struct values or pointers. Every struct field,
+// mapped by the session mapper, must have a column of a type that can be
+// scanned into and bound from the field. Columns without fields are allowed.
+// If structs do not match the schema ValidationError is returned. Validate
+// is meant to be called at startup to detect schema drift early.
+func Validate(session Session, tables map[string]interface{}) error {
+	return validate(session.Mapper, tables, func(keyspace, table string) (map[string]gocql.TypeInfo, error) {
+		km, err := session.KeyspaceMetadata(keyspace)
+		if err != nil {
+			return nil, err
+		}
+		tm, ok := km.Tables[table]
+		if !ok {
+			return nil, nil
+		}
+		columns := make(map[string]gocql.TypeInfo, len(tm.Columns))
+		for name, c := range tm.Columns {
+			columns[name] = c.Type
+		}
+		return columns, nil
+	})
+}
+
+// columnsFunc returns types of columns of a table or nil if the table does
+// not exist.
+type columnsFunc func(keyspace, table string) (map[string]gocql.TypeInfo, error)
+
+func validate(m *reflectx.Mapper, tables map[string]interface{}, columnsOf columnsFunc) error {
+	if m == nil {
+		m = DefaultMapper
+	}
+
+	var out ValidationError
+	for name, v := range tables {
+		dot := strings.IndexByte(name, '.')
+		if dot < 0 {
+			return fmt.Errorf("table %q: expected keyspace.table name", name)
+		}
+		t := reflect.TypeOf(v)
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			return fmt.Errorf("table %q: expected a struct but got %T", name, v)
+		}
+
+		columns, err := columnsOf(name[:dot], name[dot+1:])
+		if err != nil {
+			return fmt.Errorf("table %q: %s", name, err)
+		}
+		if columns == nil {
+			out = append(out, Mismatch{Table: name, Err: fmt.Errorf("table not found")})
+			continue
+		}
+
+		for _, fi := range columnFields(m.TypeMap(t)) {
+			field, goType := fieldPath(t, fi.Index)
+			info, ok := columns[fi.Name]
+			if !ok {
+				out = append(out, Mismatch{Table: name, Column: fi.Name, Field: field, GoType: goType, Err: fmt.Errorf("column %q not found", fi.Name)})
+				continue
+			}
+			if err := checkType(info, goType); err != nil {
+				out = append(out, Mismatch{Table: name, Column: fi.Name, Field: field, GoType: goType, Type: info, Err: err})
+			}
+		}
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Table != out[j].Table {
+			return out[i].Table < out[j].Table
+		}
+		return out[i].Column < out[j].Column
+	})
+	return out
+}
+
+// checkType returns an error if values of CQL type info can not be scanned
+// into or bound from values of type t.
+func checkType(info gocql.TypeInfo, t reflect.Type) error {
+	if err := gocql.Unmarshal(info, nil, reflect.New(t).Interface()); err != nil {
+		return err
+	}
+	// use non-nil pointers as nil values can be bound to any column
+	r := reflect.New(t).Elem()
+	for v := r; v.Kind() == reflect.Ptr; v = v.Elem() {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+	_, err := gocql.Marshal(info, r.Interface())
+	return err
+}
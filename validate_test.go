@@ -0,0 +1,96 @@
+// Copyright (C) 2017 ScyllaDB
+// Use of this source code is governed by a ALv2-style
+// license that can be found in the LICENSE file.
+
+package gocqlx
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidate(t *testing.T) {
+	type person struct {
+		ID        gocql.UUID
+		FirstName string
+		Age       *int
+		Emails    []string
+		CreatedAt time.Time
+		Nickname  string
+		Ignored   string `db:"-"`
+	}
+
+	schema := map[string]map[string]gocql.TypeInfo{
+		"ks.person": {
+			"id":         gocql.NewNativeType(4, gocql.TypeUUID, ""),
+			"first_name": gocql.NewNativeType(4, gocql.TypeText, ""),
+			"age":        gocql.NewNativeType(4, gocql.TypeText, ""),
+			"emails": gocql.CollectionType{
+				NativeType: gocql.NewNativeType(4, gocql.TypeList, ""),
+				Elem:       gocql.NewNativeType(4, gocql.TypeText, ""),
+			},
+			"created_at": gocql.NewNativeType(4, gocql.TypeTimestamp, ""),
+			"extra":      gocql.NewNativeType(4, gocql.TypeInt, ""),
+		},
+	}
+	columnsOf := func(keyspace, table string) (map[string]gocql.TypeInfo, error) {
+		if keyspace == "error" {
+			return nil, errors.New("error")
+		}
+		return schema[keyspace+"."+table], nil
+	}
+
+	t.Run("mismatches", func(t *testing.T) {
+		err := validate(nil, map[string]interface{}{
+			"ks.person":  &person{},
+			"ks.missing": person{},
+		}, columnsOf)
+
+		var got []string
+		if v, ok := err.(ValidationError); ok {
+			for _, m := range v {
+				got = append(got, m.String())
+			}
+		} else {
+			t.Fatalf("validate() error=%v expected ValidationError", err)
+		}
+		expected := []string{
+			"table ks.missing: table not found",
+			`table ks.person: column "age" of type text and field Age of type *int: can not marshal int into text`,
+			`table ks.person: field Nickname: column "nickname" not found`,
+		}
+		if diff := cmp.Diff(expected, got); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		type valid struct {
+			ID        gocql.UUID
+			FirstName *string
+			Emails    []string
+		}
+		if err := validate(nil, map[string]interface{}{"ks.person": valid{}}, columnsOf); err != nil {
+			t.Fatal("validate() error:", err)
+		}
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		table := []map[string]interface{}{
+			{"person": person{}},
+			{"ks.person": 1},
+			{"error.person": person{}},
+		}
+		for _, test := range table {
+			if err := validate(nil, test, columnsOf); err == nil {
+				t.Errorf("validate(%v) expected error", test)
+			} else if _, ok := err.(ValidationError); ok {
+				t.Errorf("validate(%v) unexpected ValidationError", test)
+			}
+		}
+	})
+}